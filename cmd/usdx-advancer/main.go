@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -38,6 +39,36 @@ type Config struct {
 	Backend *urlDecoder `default:"http://localhost:8080"`
 	Token   auth.Token  `required:"true"`
 	Base    string      `required:"true"`
+	// RefuseMismatch prevents advancing after a song that the current ticket
+	// did not request.
+	RefuseMismatch bool `default:"false"`
+	// Songs that ended before MinDuration or before reaching MinPosition
+	// (between 0 and 1) do not advance the queue on their own. An admin has to
+	// confirm the advance instead.
+	MinDuration time.Duration `default:"30s"`
+	MinPosition float64       `default:"0"`
+}
+
+// guard decides whether the queue may be advanced after a song.
+type guard struct {
+	refuseMismatch bool
+	minDuration    time.Duration
+	minPosition    float64
+}
+
+// check returns the reason why the queue should not be advanced or an empty
+// string if it may be.
+func (g guard) check(duration time.Duration, last model.State, mismatch bool) string {
+	switch {
+	case duration < g.minDuration:
+		return fmt.Sprintf("song lasted only %s", duration.Round(time.Second))
+	case last.RelPos() < g.minPosition:
+		return fmt.Sprintf("song ended at %.0f%%", last.RelPos()*100)
+	case mismatch && g.refuseMismatch:
+		return "song was not requested by the current ticket"
+	default:
+		return ""
+	}
 }
 
 func main() {
@@ -70,7 +101,11 @@ func main() {
 	}
 	err = group.Run(
 		watcher,
-		createNotifier(l.Named("notifier"), client, pathC, c.Base),
+		createNotifier(l.Named("notifier"), client, pathC, c.Base, guard{
+			refuseMismatch: c.RefuseMismatch,
+			minDuration:    c.MinDuration,
+			minPosition:    c.MinPosition,
+		}),
 		createInterruptActor(l.Named("interrupt")),
 	)
 	if err != nil && err != errInterrupted {
@@ -82,11 +117,15 @@ func main() {
 	}
 }
 
-func createNotifier(l log.Logger, client client.Client, c <-chan string, baseDir string) group.Actor {
+func createNotifier(l log.Logger, client client.Client, c <-chan string, baseDir string, g guard) group.Actor {
 	scoreProcessed := make(map[string]bool)
 	return group.WithChannel(func(done <-chan struct{}) error {
 		var lastStart time.Time
 		var running bool
+		var mismatch bool
+		// last is the most recent state of the current song, which is kept
+		// after the song stopped.
+		var last model.State
 		for {
 			select {
 			case path := <-c:
@@ -108,6 +147,9 @@ func createNotifier(l log.Logger, client client.Client, c <-chan string, baseDir
 					l.Debug("loaded state",
 						log.Any("state", state),
 					)
+					if state.Playback != model.Stopped {
+						last = state
+					}
 					switch {
 					case state.Playback == model.Stopped:
 						running = false
@@ -117,6 +159,7 @@ func createNotifier(l log.Logger, client client.Client, c <-chan string, baseDir
 						l.Debug("new song started",
 							log.Stringer("last", lastStart),
 						)
+						mismatch = !checkRequest(l, client, state)
 					}
 					err = client.SetState(state)
 					if err != nil {
@@ -134,13 +177,16 @@ func createNotifier(l log.Logger, client client.Client, c <-chan string, baseDir
 					}
 					scoreProcessed[base] = true
 					now := time.Now()
-					if lastStart.Add(30 * time.Second).After(now) {
-						l.Warn("song lasted less than 30s, will not advance",
+					if reason := g.check(now.Sub(lastStart), last, mismatch); reason != "" {
+						l.Warn("will not advance, waiting for confirmation",
+							log.String("reason", reason),
 							log.Stringer("last", lastStart),
 							log.Stringer("now", now),
 						)
+						proposeAdvance(l, client, reason, now.Sub(lastStart), last)
+						break
 					}
-					err := client.Advance()
+					err := client.Advance(model.DontCare)
 					if err != nil {
 						l.Warn("failed to advance queue",
 							log.Error(err),
@@ -156,6 +202,78 @@ func createNotifier(l log.Logger, client client.Client, c <-chan string, baseDir
 	})
 }
 
+// checkRequest compares the song that is being played with the song the current
+// ticket requested. Mismatches are reported to the backend. It returns false
+// only if the ticket requested a different song.
+func checkRequest(l log.Logger, c client.Client, s model.State) bool {
+	queue, err := c.GetQueue()
+	if err != nil {
+		l.Warn("failed to get queue, cannot check requested song",
+			log.Error(err),
+		)
+		return true
+	}
+	if queue.Position >= len(queue.Queue) {
+		return true
+	}
+
+	ticketID := queue.Queue[queue.Position]
+	requested, ok := queue.Requests[ticketID]
+	if !ok {
+		return true
+	}
+
+	source, err := base64.StdEncoding.DecodeString(requested)
+	if err == nil && filepath.Clean(string(source)) == filepath.Clean(s.Source) {
+		return true
+	}
+
+	l.Warn("song was not requested by the current ticket",
+		log.String("ticket", string(ticketID)),
+		log.String("requested", string(source)),
+		log.String("source", s.Source),
+	)
+	err = c.ReportAnomaly(model.Anomaly{
+		Message:   "song was not requested by the current ticket",
+		Ticket:    ticketID,
+		Requested: requested,
+		Source:    s.Source,
+	})
+	if err != nil {
+		l.Warn("failed to report anomaly",
+			log.Error(err),
+		)
+	}
+	return false
+}
+
+// proposeAdvance asks an admin to confirm advancing past the current ticket.
+func proposeAdvance(l log.Logger, c client.Client, reason string, duration time.Duration, last model.State) {
+	queue, err := c.GetQueue()
+	if err != nil {
+		l.Warn("failed to get queue, cannot propose advance",
+			log.Error(err),
+		)
+		return
+	}
+	if queue.Position >= len(queue.Queue) {
+		return
+	}
+
+	_, err = c.ProposeAdvance(model.PendingAdvance{
+		Ticket:   queue.Queue[queue.Position],
+		Reason:   reason,
+		Source:   last.Source,
+		Duration: duration,
+		Position: last.RelPos(),
+	})
+	if err != nil {
+		l.Warn("failed to propose advance",
+			log.Error(err),
+		)
+	}
+}
+
 func loadState(path, baseDir string) (model.State, error) {
 	data, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -16,10 +16,12 @@ import (
 	"github.com/Patagonicus/usdx-queue/pkg/api"
 	"github.com/Patagonicus/usdx-queue/pkg/auth"
 	"github.com/Patagonicus/usdx-queue/pkg/backend"
+	"github.com/Patagonicus/usdx-queue/pkg/cover"
+	"github.com/Patagonicus/usdx-queue/pkg/library"
 	"github.com/Patagonicus/usdx-queue/pkg/log"
-	"github.com/Patagonicus/usdx-reader/pkg/storage"
 	"github.com/Patagonicus/usdx-reader/pkg/storage/mysql"
 	bolt "github.com/coreos/bbolt"
+	"github.com/fsnotify/fsnotify"
 	"github.com/gorilla/mux"
 	cache "github.com/patrickmn/go-cache"
 )
@@ -30,13 +32,41 @@ func main() {
 	var (
 		listen      = flag.String("listen", ":8080", "listen address for the API")
 		dbPath      = flag.String("db", "usdx.db", "path to the database")
+		memory      = flag.Bool("memory", false, "keep tickets and the queue only in memory instead of the database")
+		migrateOnly = flag.Bool("migrate-only", false, "upgrade the database to the latest schema and exit")
+		checkDB     = flag.Bool("check-db", false, "report pending migrations and unreadable records and exit")
 		authDBPath  = flag.String("auth-db", "auth.db", "path to the database")
 		songs       = flag.String("songs", "", "connect string for mysql")
-		coverPath   = flag.String("cover-path", "", "")
+		songDir     = flag.String("song-dir", "", "UltraStar song directory to scan instead of using mysql")
+		coverPath   = flag.String("cover-path", "", "base path of covers, defaults to song-dir")
+		thumbDir    = flag.String("thumb-dir", "", "directory to keep cover thumbnails in, only in memory if empty")
+		thumbMaxAge = flag.Duration("thumb-max-age", 30*24*time.Hour, "remove thumbnails from thumb-dir that have not been used for this long")
+		watchSongs  = flag.Bool("watch-songs", false, "reload songs when song-dir changes")
+		reloadEvery = flag.Duration("reload-songs", 0, "reload songs in this interval, 0 to disable")
+		changeover  = flag.Duration("changeover", time.Minute, "expected time between two songs, used to estimate when tickets are up")
+		noShowDefer = flag.Int("no-show-defer", 3, "positions a ticket is moved back if its singers do not show up")
+		maxNoShows  = flag.Int("max-no-shows", 3, "no-shows after which a ticket is removed from the queue, 0 to never remove it")
+		pinLength   = flag.Int("pin-length", backend.DefaultPINPolicy.Length, "length of new PINs")
+		pinAlphabet = flag.String("pin-alphabet", backend.DefaultPINPolicy.Alphabet, "characters new PINs are made of")
+		pinAttempts = flag.Int("pin-attempts", backend.DefaultPINPolicy.FreeAttempts, "wrong PINs per ticket and per IP address before they are locked")
+		pinLockout  = flag.Duration("pin-lockout", backend.DefaultPINPolicy.Lockout, "first lockout after too many wrong PINs, doubled with every further one")
+		maxLockout  = flag.Duration("pin-max-lockout", backend.DefaultPINPolicy.MaxLockout, "longest lockout after too many wrong PINs")
 		createAdmin = flag.String("create-admin", "", "creates a new admin token with the given name")
 	)
 	flag.Parse()
 
+	noShow := backend.NoShowPolicy{
+		Defer: *noShowDefer,
+		Max:   *maxNoShows,
+	}
+	pins := backend.PINPolicy{
+		Length:       *pinLength,
+		Alphabet:     *pinAlphabet,
+		FreeAttempts: *pinAttempts,
+		Lockout:      *pinLockout,
+		MaxLockout:   *maxLockout,
+	}
+
 	//	l := log.NewProduction()
 	l := log.NewDevelopment()
 	defer l.Sync()
@@ -44,20 +74,43 @@ func main() {
 	l.Debug("parsed flags",
 		log.String("listen", *listen),
 		log.String("db", *dbPath),
+		log.Bool("memory", *memory),
 		log.String("auth-db", *authDBPath),
 		log.String("songs", *songs),
+		log.String("song-dir", *songDir),
 		log.String("create-admin", *createAdmin),
 	)
 
-	backendDB, err := openDB(*dbPath)
-	if err != nil {
-		l.Error("failed to open backend database",
-			log.String("path", *dbPath),
-			log.Error(err),
-		)
+	if *memory && (*checkDB || *migrateOnly) {
+		l.Error("check-db and migrate-only work on the database and cannot be used with memory")
 		return
 	}
-	defer backendDB.Close()
+
+	var store backend.Store
+	if *memory {
+		l.Warn("keeping everything in memory, it will be lost on exit")
+		store = backend.NewMemoryStore()
+	} else {
+		backendDB, err := openDB(*dbPath)
+		if err != nil {
+			l.Error("failed to open backend database",
+				log.String("path", *dbPath),
+				log.Error(err),
+			)
+			return
+		}
+		defer backendDB.Close()
+
+		switch {
+		case *checkDB:
+			checkDatabase(l, backendDB)
+			return
+		case *migrateOnly:
+			migrateDatabase(l, backendDB)
+			return
+		}
+		store = backend.NewBoltStore(backendDB)
+	}
 
 	authDB, err := openDB(*authDBPath)
 	if err != nil {
@@ -69,7 +122,7 @@ func main() {
 	}
 	defer authDB.Close()
 
-	backend, err := createBackend(l.Named("backend"), backendDB)
+	backend, err := createBackend(l.Named("backend"), store, pins)
 	if err != nil {
 		l.Error("failed to create backend",
 			log.Error(err),
@@ -86,7 +139,7 @@ func main() {
 	}
 
 	if len(*createAdmin) > 0 {
-		c, err := authenticator.CreateClient(*createAdmin, auth.TypeAdmin)
+		c, err := authenticator.CreateClient(*createAdmin, auth.TypeAdmin, "")
 		if err != nil {
 			l.Error("failed to create admin token",
 				log.Error(err),
@@ -99,12 +152,21 @@ func main() {
 		)
 	}
 
-	storage, err := mysql.OpenExisting(*songs)
-	if err != nil {
-		l.Error("failed to open mysql",
-			log.Error(err),
-		)
-		return
+	var lib library.Library
+	if len(*songDir) > 0 {
+		lib = library.NewDir(l.Named("library"), *songDir)
+		if len(*coverPath) == 0 {
+			*coverPath = *songDir
+		}
+	} else {
+		storage, err := mysql.OpenExisting(*songs)
+		if err != nil {
+			l.Error("failed to open mysql",
+				log.Error(err),
+			)
+			return
+		}
+		lib = library.FromStorage(storage)
 	}
 
 	coverLoader := coverLoader{
@@ -112,10 +174,34 @@ func main() {
 		cache: cache.New(5*time.Minute, 10*time.Minute),
 	}
 
-	err = group.Run(
-		createServerActor(l.Named("server"), *listen, authenticator, backend, storage, coverLoader),
+	thumbs := cover.NewCache(*thumbDir)
+	apiV1, songLib, err := api.New(l, authenticator, backend, lib, coverLoader, thumbs, *changeover, noShow)
+	if err != nil {
+		l.Error("failed to create api",
+			log.Error(err),
+		)
+		return
+	}
+
+	actors := []group.Actor{
+		createServerActor(l.Named("server"), *listen, apiV1),
 		createInterruptActor(l.Named("interrupt")),
-	)
+	}
+	if len(*thumbDir) > 0 {
+		actors = append(actors, createPruneActor(l.Named("thumbs"), *thumbMaxAge, thumbs))
+	}
+	if *reloadEvery > 0 {
+		actors = append(actors, createReloadActor(l.Named("reload"), *reloadEvery, songLib))
+	}
+	if *watchSongs {
+		if len(*songDir) == 0 {
+			l.Error("watch-songs requires song-dir")
+			return
+		}
+		actors = append(actors, createSongWatcher(l.Named("watcher"), *songDir, songLib))
+	}
+
+	err = group.Run(actors...)
 	if err != nil && err != errInterrupted {
 		l.Error("error running server",
 			log.Error(err),
@@ -123,24 +209,72 @@ func main() {
 	}
 }
 
+func checkDatabase(l log.Logger, db *bolt.DB) {
+	status, err := backend.CheckSchema(db)
+	if err != nil {
+		l.Error("failed to check schema",
+			log.Error(err),
+		)
+		return
+	}
+	l.Info("checked schema",
+		log.Int("version", status.Version),
+		log.Int("latest", status.Latest),
+	)
+	for _, m := range status.Pending {
+		l.Info("would apply migration",
+			log.String("migration", m),
+		)
+	}
+
+	errs := backend.Verify(db)
+	for _, err := range errs {
+		l.Warn("unreadable record",
+			log.Error(err),
+		)
+	}
+	l.Info("checked records",
+		log.Int("errors", len(errs)),
+	)
+}
+
+func migrateDatabase(l log.Logger, db *bolt.DB) {
+	status, err := backend.Migrate(db)
+	if err != nil {
+		l.Error("failed to migrate database",
+			log.Int("version", status.Version),
+			log.Error(err),
+		)
+		return
+	}
+	for _, m := range status.Pending {
+		l.Info("applied migration",
+			log.String("migration", m),
+		)
+	}
+	l.Info("database is up to date",
+		log.Int("from", status.Version),
+		log.Int("version", status.Latest),
+	)
+}
+
 func openDB(path string) (*bolt.DB, error) {
 	return bolt.Open(path, 0600, nil)
 }
 
-func createBackend(l log.Logger, db *bolt.DB) (*backend.Backend, error) {
-	return backend.New(l, db)
+func createBackend(l log.Logger, store backend.Store, pins backend.PINPolicy) (*backend.Backend, error) {
+	b, err := backend.New(l, store)
+	if err != nil {
+		return nil, err
+	}
+	return b, b.SetPINPolicy(pins)
 }
 
 func createAuth(l log.Logger, db *bolt.DB) (auth.Authenticator, error) {
 	return auth.New(db)
 }
 
-func createServerActor(l log.Logger, listen string, a auth.Authenticator, back *backend.Backend, storage storage.Backend, coverLoader coverLoader) group.Actor {
-	apiV1, err := api.New(l, a, back, storage, coverLoader)
-	if err != nil {
-		return group.Done(err)
-	}
-
+func createServerActor(l log.Logger, listen string, apiV1 http.Handler) group.Actor {
 	handler := mux.NewRouter()
 	handler.PathPrefix("/v1/").Handler(http.StripPrefix("/v1", apiV1))
 
@@ -149,13 +283,13 @@ func createServerActor(l log.Logger, listen string, a auth.Authenticator, back *
 		return group.Done(err)
 	}
 
+	// No WriteTimeout, as it would cut off the event streams.
 	server := &http.Server{
-		Addr:         listen,
-		Handler:      handler,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  30 * time.Second,
-		ErrorLog:     stdLog,
+		Addr:        listen,
+		Handler:     handler,
+		ReadTimeout: 15 * time.Second,
+		IdleTimeout: 30 * time.Second,
+		ErrorLog:    stdLog,
 	}
 
 	return group.New(
@@ -175,6 +309,122 @@ func createServerActor(l log.Logger, listen string, a auth.Authenticator, back *
 	)
 }
 
+func createReloadActor(l log.Logger, interval time.Duration, songs api.SongLibrary) group.Actor {
+	return group.WithChannel(func(done <-chan struct{}) error {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				l.Debug("reloading songs")
+				songs.Reload()
+			case <-done:
+				return nil
+			}
+		}
+	})
+}
+
+// createPruneActor removes unused thumbnails from the disk once at startup and
+// then every hour.
+func createPruneActor(l log.Logger, maxAge time.Duration, thumbs *cover.Cache) group.Actor {
+	prune := func() {
+		n, err := thumbs.Prune(maxAge)
+		if err != nil {
+			l.Warn("failed to prune thumbnails",
+				log.Error(err),
+			)
+		}
+		if n > 0 {
+			l.Info("pruned thumbnails",
+				log.Int("removed", n),
+			)
+		}
+	}
+
+	return group.WithChannel(func(done <-chan struct{}) error {
+		prune()
+		t := time.NewTicker(time.Hour)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				prune()
+			case <-done:
+				return nil
+			}
+		}
+	})
+}
+
+// createSongWatcher reloads the songs after changes below dir have settled
+// down, so copying a song with all its files only causes one reload.
+func createSongWatcher(l log.Logger, dir string, songs api.SongLibrary) group.Actor {
+	const settle = 5 * time.Second
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return group.Done(err)
+	}
+	err = watchRecursive(w, dir)
+	if err != nil {
+		w.Close()
+		return group.Done(err)
+	}
+
+	return group.WithChannel(func(done <-chan struct{}) error {
+		defer w.Close()
+		l.Info("now watching songs",
+			log.String("path", dir),
+		)
+		t := time.NewTimer(settle)
+		t.Stop()
+		for {
+			select {
+			case event := <-w.Events:
+				l.Debug("got event",
+					log.Any("event", event),
+				)
+				if event.Op&fsnotify.Create != 0 {
+					info, err := os.Stat(event.Name)
+					if err == nil && info.IsDir() {
+						err = watchRecursive(w, event.Name)
+						if err != nil {
+							l.Warn("failed to watch directory",
+								log.String("path", event.Name),
+								log.Error(err),
+							)
+						}
+					}
+				}
+				t.Reset(settle)
+			case err := <-w.Errors:
+				l.Warn("error watching",
+					log.Error(err),
+				)
+			case <-t.C:
+				l.Debug("reloading songs")
+				songs.Reload()
+			case <-done:
+				t.Stop()
+				return nil
+			}
+		}
+	})
+}
+
+func watchRecursive(w *fsnotify.Watcher, dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		return w.Add(path)
+	})
+}
+
 func createInterruptActor(l log.Logger) group.Actor {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
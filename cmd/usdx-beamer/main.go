@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"sync"
 	"time"
 
 	"github.com/Patagonicus/group"
@@ -52,6 +53,8 @@ type Config struct {
 	Backend *urlDecoder `default:"http://localhost:8080"`
 	Pub     *urlDecoder
 	Token   auth.Token `required:"true"`
+	// Leaderboard is how far back the leaderboard shown between songs looks.
+	Leaderboard time.Duration `default:"12h"`
 }
 
 func main() {
@@ -73,10 +76,24 @@ func main() {
 		c.Pub = c.Backend
 	}
 
-	client := client.NewWithPub(l.Named("client"), (*url.URL)(c.Backend), (*url.URL)(c.Pub), c.Token)
+	cl := client.NewWithPub(l.Named("client"), (*url.URL)(c.Backend), (*url.URL)(c.Pub), c.Token)
+
+	f := frontend{
+		tmpl:  templates.Must(templates.CreateWithFuncs("beamer/index.html", map[string]interface{}{"formatDuration": formatDuration})),
+		bg:    templates.MustResource(templates.NewResource("beamer/bg.png")),
+		c:     cl,
+		songs: client.NewSongNames(cl, 10*time.Minute),
+		hub:   newHub(),
+		board: &leaderboardCache{
+			m:     new(sync.Mutex),
+			since: c.Leaderboard,
+		},
+		l: l.Named("frontend"),
+	}
 
 	err = group.Run(
-		createServerActor(l.Named("server"), c.Listen, client),
+		createServerActor(l.Named("server"), c.Listen, f),
+		createUpdaterActor(l.Named("updater"), f),
 		createInterruptActor(l.Named("interrupt")),
 	)
 	if err != nil && err != errInterrupted {
@@ -93,6 +110,7 @@ type song struct {
 	CoverURL       string
 	Source         string
 	CompletionPerc int
+	Duet           bool
 }
 
 type score struct {
@@ -111,6 +129,16 @@ type state struct {
 	Position  time.Duration
 	Song      song
 	Scores    []score
+	// Leaderboard is only set while no song is playing.
+	Leaderboard *leaderboardJSON
+	// Request is the song the current ticket wants to sing.
+	Request string
+	// Reservation describes why the current ticket is placed out of order.
+	Reservation string
+	NextID      string
+	// NextRequest is the song the next ticket wants to sing.
+	NextRequest     string
+	NextReservation string
 }
 
 type ticketJSON struct {
@@ -118,6 +146,14 @@ type ticketJSON struct {
 	Names  []string `json:"names"`
 	Colors []string `json:"colors"`
 	Scores []int    `json:"scores"`
+	Song   string   `json:"song,omitempty"`
+	Label  string   `json:"label,omitempty"`
+}
+
+type nextJSON struct {
+	ID    string `json:"id"`
+	Song  string `json:"song,omitempty"`
+	Label string `json:"label,omitempty"`
 }
 
 type songJSON struct {
@@ -126,13 +162,26 @@ type songJSON struct {
 	CoverURL string `json:"cover"`
 	ElapsedS int    `json:"elapsed"`
 	TotalS   int    `json:"total"`
+	Duet     bool   `json:"duet,omitempty"`
+}
+
+type rankingJSON struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+type leaderboardJSON struct {
+	Singers []rankingJSON `json:"singers"`
+	Songs   []rankingJSON `json:"songs"`
 }
 
 type stateJSON struct {
-	Ticket  ticketJSON `json:"ticket"`
-	HasSong bool       `json:"hasSong"`
-	Song    songJSON   `json:"song"`
-	Waiting int        `json:"waiting"`
+	Ticket      ticketJSON       `json:"ticket"`
+	HasSong     bool             `json:"hasSong"`
+	Song        songJSON         `json:"song"`
+	Waiting     int              `json:"waiting"`
+	Next        *nextJSON        `json:"next,omitempty"`
+	Leaderboard *leaderboardJSON `json:"leaderboard,omitempty"`
 }
 
 func fromState(s state) stateJSON {
@@ -145,13 +194,25 @@ func fromState(s state) stateJSON {
 		scores[i] = score.Points
 	}
 
+	var next *nextJSON
+	if s.NextID != "" {
+		next = &nextJSON{
+			ID:    s.NextID,
+			Song:  s.NextRequest,
+			Label: s.NextReservation,
+		}
+	}
+
 	return stateJSON{
 		Ticket: ticketJSON{
 			ID:     s.CurrentID,
 			Names:  names,
 			Colors: colors,
 			Scores: scores,
+			Song:   s.Request,
+			Label:  s.Reservation,
 		},
+		Next:    next,
 		HasSong: s.HasSong,
 		Song: songJSON{
 			Title:    s.Song.Title,
@@ -159,15 +220,121 @@ func fromState(s state) stateJSON {
 			CoverURL: s.Song.CoverURL,
 			ElapsedS: int(s.Position / time.Second),
 			TotalS:   int(s.Song.Length / time.Second),
+			Duet:     s.Song.Duet,
 		},
+		Leaderboard: s.Leaderboard,
 	}
 }
 
 type frontend struct {
-	tmpl templates.Template
-	bg   templates.Resource
-	c    client.Client
-	l    log.Logger
+	tmpl  templates.Template
+	bg    templates.Resource
+	c     client.Client
+	songs *client.SongNames
+	hub   *hub
+	board *leaderboardCache
+	l     log.Logger
+}
+
+// leaderboardCache keeps the leaderboard between songs. It only changes when a
+// song has been played, so it is loaded once after each song.
+type leaderboardCache struct {
+	m     *sync.Mutex
+	since time.Duration
+	board *leaderboardJSON
+}
+
+func (l *leaderboardCache) Invalidate() {
+	l.m.Lock()
+	defer l.m.Unlock()
+	l.board = nil
+}
+
+func (l *leaderboardCache) Get(c client.Client, songs *client.SongNames) (*leaderboardJSON, error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.board != nil {
+		return l.board, nil
+	}
+
+	board, err := c.GetLeaderboard(model.LeaderboardFilter{
+		Since: time.Now().Add(-l.since),
+		Limit: 10,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := leaderboardJSON{
+		Singers: make([]rankingJSON, len(board.Singers)),
+		Songs:   make([]rankingJSON, len(board.Songs)),
+	}
+	for i, r := range board.Singers {
+		result.Singers[i] = rankingJSON{Name: r.Name, Score: r.Best}
+	}
+	for i, r := range board.Songs {
+		result.Songs[i] = rankingJSON{Name: r.Name, Score: r.Best}
+		name, err := songs.NameOf(r.Name)
+		if err == nil && name != "" {
+			result.Songs[i].Name = name
+		}
+	}
+
+	l.board = &result
+	return l.board, nil
+}
+
+// hub keeps the most recent state and passes changes on to all connected
+// browsers.
+type hub struct {
+	m     *sync.Mutex
+	state *stateJSON
+	subs  map[chan stateJSON]struct{}
+}
+
+func newHub() *hub {
+	return &hub{
+		m:    new(sync.Mutex),
+		subs: make(map[chan stateJSON]struct{}),
+	}
+}
+
+func (h *hub) Set(s stateJSON) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	h.state = &s
+	for c := range h.subs {
+		select {
+		case c <- s:
+		default:
+			// The browser has not received the previous state yet. Replace
+			// it, only the most recent one matters.
+			select {
+			case <-c:
+			default:
+			}
+			c <- s
+		}
+	}
+}
+
+func (h *hub) Subscribe() (<-chan stateJSON, func()) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	c := make(chan stateJSON, 1)
+	if h.state != nil {
+		c <- *h.state
+	}
+	h.subs[c] = struct{}{}
+
+	return c, func() {
+		h.m.Lock()
+		defer h.m.Unlock()
+		delete(h.subs, c)
+	}
 }
 
 func (f frontend) Index(w http.ResponseWriter, r *http.Request) {
@@ -216,6 +383,50 @@ func (f frontend) State(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (f frontend) Events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	states, cancel := f.hub.Subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(15 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case s := <-states:
+			data, err := json.Marshal(s)
+			if err != nil {
+				f.l.Warn("error encoding state",
+					log.Error(err),
+				)
+				continue
+			}
+			_, err = fmt.Fprintf(w, "event: state\ndata: %s\n\n", data)
+			if err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			if err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
+
 func (f frontend) Background(w http.ResponseWriter, r *http.Request) error {
 	data, etag, err := f.bg.Get()
 	if err != nil {
@@ -230,6 +441,18 @@ func (f frontend) Background(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// reservation returns a label for tickets that were placed ahead of the
+// normal order.
+func reservation(q model.Queue, id model.ID) string {
+	if q.Priorities[id] > 0 {
+		return "Vorrang"
+	}
+	if slot := q.Slots[id]; slot > 0 {
+		return fmt.Sprintf("Reserviert für Platz %d", slot)
+	}
+	return ""
+}
+
 func (f frontend) getState() (state, error) {
 	f.l.Debug("getting queue")
 	queue, err := f.c.GetQueue()
@@ -245,35 +468,31 @@ func (f frontend) getState() (state, error) {
 	)
 
 	var cur state
-	if queue.Position >= len(queue.Queue) {
-		f.l.Debug("pos >= len",
-			log.Int("pos", queue.Position),
-			log.Int("len", len(queue.Queue)),
-		)
-		cur.Empty = true
-		cur.CurrentID = ""
-		cur.Waiting = 0
+	if current, ok := queue.Current(); ok {
+		waiting := queue.WithStatus(model.StatusWaiting)
+		cur.CurrentID = string(current)
+		cur.Request = f.songName(queue.Requests[current])
+		cur.Reservation = reservation(queue, current)
+		cur.Waiting = len(waiting)
+		if len(waiting) > 0 {
+			next := waiting[0]
+			cur.NextID = string(next)
+			cur.NextRequest = f.songName(queue.Requests[next])
+			cur.NextReservation = reservation(queue, next)
+		}
 	} else {
-		f.l.Debug("pos < len",
-			log.Int("pos", queue.Position),
-			log.Int("len", len(queue.Queue)),
-			log.String("current", string(queue.Queue[queue.Position])),
-			log.Int("upcoming", len(queue.Queue)-queue.Position-1),
-		)
-		cur.Empty = false
-		cur.CurrentID = string(queue.Queue[queue.Position])
-		cur.Waiting = len(queue.Queue) - queue.Position - 1
+		cur.Empty = true
 	}
 
 	f.l.Debug("waiting",
 		log.Int("waiting", cur.Waiting),
 		log.Int("queue", len(queue.Queue)),
-		log.Int("pos", queue.Position),
+		log.String("current", cur.CurrentID),
 	)
 
 	var names []string
 	if !cur.Empty {
-		ticket, err := f.c.GetTicket(queue.Queue[queue.Position])
+		ticket, err := f.c.GetTicket(model.ID(cur.CurrentID))
 		if err != nil {
 			f.l.Warn("failed to get current ticket",
 				log.Error(err),
@@ -306,6 +525,7 @@ func (f frontend) getState() (state, error) {
 			if err == nil {
 				cur.Song.Artist = song.Artist
 				cur.Song.Title = song.Title
+				cur.Song.Duet = song.Duet
 			}
 			cur.Song.CoverURL = f.c.GetCoverURL(playbackState.Source)
 			cur.Song.CompletionPerc = int(playbackState.RelPos() * 100)
@@ -349,18 +569,70 @@ func (f frontend) getState() (state, error) {
 		for i, n := range names {
 			cur.Scores[i].Name = n
 		}
+
+		cur.Leaderboard, err = f.board.Get(f.c, f.songs)
+		if err != nil {
+			f.l.Warn("failed to get leaderboard",
+				log.Error(err),
+			)
+		}
+	} else {
+		f.board.Invalidate()
 	}
 
 	return cur, nil
 }
 
-func createServerActor(l log.Logger, listen string, client client.Client) group.Actor {
-	f := frontend{
-		tmpl: templates.Must(templates.CreateWithFuncs("beamer/index.html", map[string]interface{}{"formatDuration": formatDuration})),
-		bg:   templates.MustResource(templates.NewResource("beamer/bg.png")),
-		c:    client,
-		l:    l,
+// songName returns "Artist – Title" for a song ID or an empty string if the
+// song is not known.
+func (f frontend) songName(id string) string {
+	name, err := f.songs.Name(id)
+	if err != nil {
+		f.l.Warn("failed to get requested song",
+			log.String("song", id),
+			log.Error(err),
+		)
 	}
+	return name
+}
+
+func createUpdaterActor(l log.Logger, f frontend) group.Actor {
+	return group.WithChannel(func(done <-chan struct{}) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			<-done
+			cancel()
+		}()
+
+		update := func() {
+			s, err := f.getState()
+			if err != nil {
+				l.Warn("failed to update state",
+					log.Error(err),
+				)
+				return
+			}
+			f.hub.Set(fromState(s))
+		}
+
+		events := f.c.Subscribe(ctx)
+		update()
+		for event := range events {
+			l.Debug("got event",
+				log.Stringer("event", event),
+			)
+			switch event.Type {
+			case model.EventSongsReloaded, model.EventResync:
+				f.songs.Invalidate()
+			}
+			update()
+		}
+		return nil
+	})
+}
+
+func createServerActor(l log.Logger, listen string, f frontend) group.Actor {
 
 	small := templates.MustResource(templates.NewResource("beamer/small.html"))
 	large := templates.MustResource(templates.NewResource("beamer/large.html"))
@@ -371,6 +643,7 @@ func createServerActor(l log.Logger, listen string, client client.Client) group.
 	//handler.HandleFunc("/", f.Index)
 	handler.Handle("/", templates.ServeResource(large))
 	handler.HandleFunc("/state", f.State)
+	handler.HandleFunc("/events", f.Events)
 	handler.Handle("/bg.png", httperr.HandlerFunc(f.Background))
 	handler.Handle("/small", templates.ServeResource(small))
 	handler.Handle("/large", templates.ServeResource(large))
@@ -382,13 +655,13 @@ func createServerActor(l log.Logger, listen string, client client.Client) group.
 		return group.Done(err)
 	}
 
+	// No WriteTimeout, as it would cut off the event streams.
 	server := &http.Server{
-		Addr:         listen,
-		Handler:      handler,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  30 * time.Second,
-		ErrorLog:     stdLog,
+		Addr:        listen,
+		Handler:     handler,
+		ReadTimeout: 15 * time.Second,
+		IdleTimeout: 30 * time.Second,
+		ErrorLog:    stdLog,
 	}
 
 	return group.New(
@@ -104,7 +104,7 @@ func (s server) ticket(w http.ResponseWriter, r *http.Request) {
 	s.l.Debug("creating new ticket")
 	w.Header().Set("Refresh", "5;url=index")
 
-	id, pin, err := s.client.CreateTicket()
+	ticket, pin, err := s.client.CreateTicket()
 	if err != nil {
 		s.l.Error("failed to create ticket",
 			log.Error(err),
@@ -112,12 +112,41 @@ func (s server) ticket(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	id := ticket.ID
 	s.l.Info("created ticket",
 		log.Any("id", id),
 		log.Any("pin", pin),
+		log.String("stage", ticket.Stage),
 	)
 
-	err = s.printer.Print(id, pin, s.webBase, fmt.Sprintf("%s/index#edit/%s/%s", s.webBase, url.PathEscape(string(id)), url.PathEscape(string(pin))))
+	// The stage only matters if there is more than one.
+	stage := ""
+	stages, err := s.client.GetStages()
+	if err != nil {
+		s.l.Warn("failed to get stages",
+			log.Error(err),
+		)
+	}
+	if len(stages) > 1 {
+		stage = ticket.Stage
+	}
+
+	queue, err := s.client.GetStageQueue(ticket.Stage)
+	if err != nil {
+		s.l.Warn("failed to get queue",
+			log.Error(err),
+		)
+	}
+	eta := queue.ETA[id]
+
+	err = s.printer.Print(printer.Ticket{
+		ID:      id,
+		PIN:     pin,
+		Stage:   stage,
+		ETA:     eta,
+		RegBase: s.webBase,
+		RegURL:  fmt.Sprintf("%s/index#edit/%s/%s", s.webBase, url.PathEscape(string(id)), url.PathEscape(string(pin))),
+	})
 	printFailed := false
 	if err != nil {
 		s.l.Error("failed to print ticket",
@@ -132,10 +161,19 @@ func (s server) ticket(w http.ResponseWriter, r *http.Request) {
 	s.ticketTmpl.Execute(w, map[string]interface{}{
 		"ID":          string(id),
 		"PIN":         string(pin),
+		"Stage":       stage,
+		"ETA":         formatETA(eta),
 		"printfailed": printFailed,
 	})
 }
 
+func formatETA(eta time.Time) string {
+	if eta.IsZero() {
+		return ""
+	}
+	return eta.Format("15:04")
+}
+
 func createServerActor(l log.Logger, listen string, client client.Client, printer printer.Printer, webBase string) group.Actor {
 	s := server{
 		indexTmpl:  templates.Must(templates.Create("registration/index.html")),
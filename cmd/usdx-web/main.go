@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/rand"
 	"crypto/sha512"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -52,6 +54,9 @@ type Config struct {
 	Backend *urlDecoder `default:"http://localhost:8080"`
 	Pub     *urlDecoder
 	Token   auth.Token `required:"true"`
+	// TrustProxy takes the address of browsers from X-Forwarded-For, for
+	// running behind a reverse proxy.
+	TrustProxy bool
 }
 
 func main() {
@@ -83,8 +88,13 @@ func main() {
 
 	client := client.NewWithPub(l.Named("client"), (*url.URL)(c.Backend), (*url.URL)(c.Pub), c.Token)
 
+	f := newFrontend(l.Named("server"), client)
+	f.trustProxy = c.TrustProxy
+
 	group.Run(
-		createServerActor(l.Named("server"), c.Listen, client),
+		createServerActor(l.Named("server"), c.Listen, f),
+		createSongsActor(l.Named("songs"), f),
+		createNotifyActor(l.Named("notify"), f),
 		createInterruptActor(l.Named("interrupt")),
 	)
 }
@@ -93,6 +103,8 @@ type queue struct {
 	Past     []model.Ticket
 	Current  model.Ticket
 	Upcoming []model.Ticket
+	// ETA is when upcoming tickets are expected to start singing.
+	ETA map[model.ID]time.Time
 }
 
 type frontend struct {
@@ -105,6 +117,10 @@ type frontend struct {
 	index       templates.Resource
 	client      client.Client
 	cachedSongs *cachedPage
+	songNames   *client.SongNames
+	queues      *queueHub
+	notify      *notifyTokens
+	trustProxy  bool
 	l           log.Logger
 }
 
@@ -275,6 +291,11 @@ type state struct {
 type ticketJSON struct {
 	ID    string   `json:"id"`
 	Names []string `json:"names"`
+	Song  string   `json:"song,omitempty"`
+	// ETA is the time of day the ticket is expected to start singing.
+	ETA      string `json:"eta,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+	Slot     int    `json:"slot,omitempty"`
 }
 
 type queueJSON struct {
@@ -314,19 +335,48 @@ func (f frontend) Admin(w http.ResponseWriter, r *http.Request) error {
 	if r.FormValue("action") != "" {
 		var err error
 		var msg string
+		version := model.DontCare
+		if v, err := strconv.ParseInt(r.FormValue("version"), 10, 64); err == nil {
+			version = model.Version(v)
+		}
 		switch r.FormValue("action") {
 		case "pause":
-			err = f.client.TogglePause()
+			err = f.client.TogglePause(version)
 			if err != nil {
 				msg = "Pause toggled"
 			}
 		case "advance":
-			err = f.client.Advance()
+			err = f.client.Advance(version)
 		case "goback":
-			err = f.client.GoBack()
+			err = f.client.GoBack(version)
+		case "noshow":
+			var ticket model.Ticket
+			var dropped bool
+			ticket, dropped, err = f.client.NoShow(version)
+			switch {
+			case err != nil:
+			case dropped:
+				msg = fmt.Sprintf("#%s removed after %d no-shows", ticket.ID, ticket.NoShows)
+			default:
+				msg = fmt.Sprintf("#%s moved back (%d. no-show)", ticket.ID, ticket.NoShows)
+			}
+		case "confirm", "reject":
+			var n uint64
+			n, err = strconv.ParseUint(r.FormValue("pending"), 10, 64)
+			if err != nil {
+				break
+			}
+			if r.FormValue("action") == "confirm" {
+				err = f.client.ConfirmAdvance(n, version)
+			} else {
+				err = f.client.RejectAdvance(n)
+			}
 		}
 		values := make(url.Values)
-		if err != nil {
+		switch {
+		case err == client.ErrVersionConflict:
+			values.Set("err", "The queue was changed in the meantime, please check and try again.")
+		case err != nil:
 			values.Set("err", err.Error())
 		}
 		values.Set("msg", msg)
@@ -339,14 +389,9 @@ func (f frontend) Admin(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	var curID string
-	if queue.Position < len(queue.Queue) {
-		curID = string(queue.Queue[queue.Position])
-	}
-	upcoming := len(queue.Queue) - queue.Position - 1
-	if upcoming < 0 {
-		upcoming = 0
-	}
+	current, _ := queue.Current()
+	curID := string(current)
+	upcoming := len(queue.WithStatus(model.StatusWaiting))
 
 	tickets, err := f.client.GetTickets()
 	if err != nil {
@@ -358,11 +403,20 @@ func (f frontend) Admin(w http.ResponseWriter, r *http.Request) error {
 		return a < b
 	})
 
+	pending, err := f.client.GetPendingAdvances()
+	if err != nil {
+		f.l.Warn("failed to get pending advances",
+			log.Error(err),
+		)
+	}
+
 	f.adminTmpl.Execute(w, map[string]interface{}{
 		"Paused":   queue.Paused,
+		"Version":  int64(queue.Version),
 		"Current":  curID,
 		"Upcoming": upcoming,
 		"Tickets":  tickets,
+		"Pending":  pending,
 		"Error":    r.FormValue("err"),
 		"Msg":      r.FormValue("msg"),
 	})
@@ -390,15 +444,22 @@ func (f frontend) APIQueue(w http.ResponseWriter, r *http.Request) error {
 	if queue.Current.ID != model.ID("") {
 		j.Upcoming = []ticketJSON{
 			{
-				ID:    string(queue.Current.ID),
-				Names: queue.Current.Names,
+				ID:       string(queue.Current.ID),
+				Names:    queue.Current.Names,
+				Song:     f.songName(queue.Current.Song),
+				Priority: queue.Current.Priority,
+				Slot:     queue.Current.Slot,
 			},
 		}
 	}
 	for _, t := range queue.Upcoming {
 		j.Upcoming = append(j.Upcoming, ticketJSON{
-			ID:    string(t.ID),
-			Names: t.Names,
+			ID:       string(t.ID),
+			Names:    t.Names,
+			Song:     f.songName(t.Song),
+			ETA:      formatETA(queue.ETA[t.ID]),
+			Priority: t.Priority,
+			Slot:     t.Slot,
 		})
 	}
 
@@ -407,26 +468,20 @@ func (f frontend) APIQueue(w http.ResponseWriter, r *http.Request) error {
 }
 
 func (f frontend) APISongs(w http.ResponseWriter, r *http.Request) error {
-	songs, err := f.client.GetSongs()
+	page, err := f.client.SearchSongs(model.SongFilter{
+		Query:    r.FormValue("q"),
+		Language: r.FormValue("language"),
+		Cursor:   r.FormValue("cursor"),
+		Limit:    100,
+	})
 	if err != nil {
-		f.l.Warn("failed to get songs",
+		f.l.Warn("failed to search songs",
 			log.Error(err),
 		)
 		return err
 	}
 
-	sort.Slice(songs, func(i, j int) bool {
-		switch {
-		case songs[i].Artist < songs[j].Artist:
-			return true
-		case songs[i].Artist > songs[j].Artist:
-			return false
-		default:
-			return songs[i].Title < songs[j].Title
-		}
-	})
-
-	json.NewEncoder(w).Encode(songs)
+	json.NewEncoder(w).Encode(page)
 	return nil
 }
 
@@ -441,7 +496,186 @@ func (f frontend) APISave(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	err = f.client.SetNames(model.ID(request.ID), model.PIN(request.PIN), request.Names)
+	err = f.holder(r).SetNames(model.ID(request.ID), model.PIN(request.PIN), request.Names)
+	if e, ok := err.(client.ErrLocked); ok {
+		return locked(w, e)
+	}
+	switch {
+	case err == client.ErrPINInvalid:
+		return httperr.WithCode(err, http.StatusUnauthorized)
+	case err == client.ErrTooManyTickets:
+		return httperr.WithCode(err, http.StatusUnprocessableEntity)
+	case err == client.ErrTicketCancelled:
+		return httperr.WithCode(err, http.StatusGone)
+	case err != nil:
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// APICancel lets the holder of a ticket give it up.
+func (f frontend) APICancel(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		ID  string `json:"id"`
+		PIN string `json:"pin"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return err
+	}
+
+	err = f.holder(r).CancelTicket(model.ID(request.ID), model.PIN(request.PIN))
+	if e, ok := err.(client.ErrLocked); ok {
+		return locked(w, e)
+	}
+	switch {
+	case err == client.ErrPINInvalid:
+		return httperr.WithCode(err, http.StatusUnauthorized)
+	case err == client.ErrTicketSung:
+		return httperr.WithCode(err, http.StatusConflict)
+	case err == client.ErrTicketCancelled:
+		return httperr.WithCode(err, http.StatusGone)
+	case err != nil:
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// APINotifyToken checks the PIN of a ticket, as in APISave, and returns a
+// token for APINotify. EventSource can only send the token in the URL, where
+// the PIN would end up in logs and the browser history.
+func (f frontend) APINotifyToken(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		ID  string `json:"id"`
+		PIN string `json:"pin"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return httperr.WithCode(err, http.StatusBadRequest)
+	}
+
+	ticketID := model.ID(request.ID)
+	err = f.holder(r).VerifyPIN(ticketID, model.PIN(request.PIN))
+	if e, ok := err.(client.ErrLocked); ok {
+		return locked(w, e)
+	}
+	switch {
+	case err == client.ErrPINInvalid:
+		return httperr.WithCode(err, http.StatusUnauthorized)
+	case err != nil:
+		return err
+	}
+
+	token, err := f.notify.Create(ticketID)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(map[string]string{"token": token})
+}
+
+// APINotify streams how many tickets are ahead of the ticket of a token from
+// APINotifyToken, so that the browser can alert the holder when their turn is
+// near.
+func (f frontend) APINotify(w http.ResponseWriter, r *http.Request) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errors.New("streaming not supported")
+	}
+
+	ticketID, ok := f.notify.Get(r.FormValue("token"))
+	if !ok {
+		return httperr.WithCode(errors.New("invalid or expired token"), http.StatusUnauthorized)
+	}
+
+	ticket, err := f.client.GetTicket(ticketID)
+	if err != nil {
+		return err
+	}
+
+	queues, cancel := f.queues.Subscribe(ticket.Stage)
+	defer cancel()
+	if !f.queues.Has(ticket.Stage) {
+		q, err := f.client.GetStageQueue(ticket.Stage)
+		if err != nil {
+			return err
+		}
+		f.queues.Set(q)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(15 * time.Second)
+	defer keepAlive.Stop()
+
+	last := -2
+	for {
+		select {
+		case q := <-queues:
+			ahead := ticketsAhead(q, ticketID)
+			if ahead == last {
+				continue
+			}
+			last = ahead
+
+			_, err := fmt.Fprintf(w, "event: position\ndata: {\"ahead\":%d}\n\n", ahead)
+			if err != nil {
+				return nil
+			}
+		case <-keepAlive.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			if err != nil {
+				return nil
+			}
+		case <-r.Context().Done():
+			return nil
+		}
+		flusher.Flush()
+	}
+}
+
+// ticketsAhead returns the number of tickets before id that have not been sung
+// yet: 0 if it is the current one and -1 if it has been sung or is not queued.
+func ticketsAhead(q model.Queue, id model.ID) int {
+	switch q.Status(id) {
+	case model.StatusOnStage:
+		return 0
+	case model.StatusWaiting:
+		ahead := 0
+		if _, ok := q.Current(); ok {
+			ahead++
+		}
+		for _, waiting := range q.WithStatus(model.StatusWaiting) {
+			if waiting == id {
+				return ahead
+			}
+			ahead++
+		}
+	}
+	return -1
+}
+
+func (f frontend) APIRequest(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		ID   string `json:"id"`
+		PIN  string `json:"pin"`
+		Song string `json:"song"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return err
+	}
+
+	err = f.holder(r).RequestSong(model.ID(request.ID), model.PIN(request.PIN), request.Song)
+	if e, ok := err.(client.ErrLocked); ok {
+		return locked(w, e)
+	}
 	switch {
 	case err == client.ErrPINInvalid:
 		return httperr.WithCode(err, http.StatusUnauthorized)
@@ -453,6 +687,43 @@ func (f frontend) APISave(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// holder returns a client that acts on behalf of the browser that sent r, so
+// that the backend counts wrong PINs per browser.
+func (f frontend) holder(r *http.Request) client.Client {
+	if f.trustProxy {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			// The proxy appends the address it got the request from.
+			parts := strings.Split(fwd, ",")
+			return f.client.WithSource(strings.TrimSpace(parts[len(parts)-1]))
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return f.client.WithSource(host)
+}
+
+// locked tells the browser when it may try again after too many wrong PINs.
+func locked(w http.ResponseWriter, e client.ErrLocked) error {
+	seconds := int64((e.RetryAfter + time.Second - 1) / time.Second)
+	w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+	return httperr.WithCode(e, http.StatusTooManyRequests)
+}
+
+// songName returns "Artist – Title" for a song ID or an empty string if the
+// song is not known.
+func (f frontend) songName(id string) string {
+	name, err := f.songNames.Name(id)
+	if err != nil {
+		f.l.Warn("failed to get requested song",
+			log.String("song", id),
+			log.Error(err),
+		)
+	}
+	return name
+}
+
 func (f frontend) getState() (state, error) {
 	f.l.Debug("getting queue")
 	queue, err := f.client.GetQueue()
@@ -468,24 +739,11 @@ func (f frontend) getState() (state, error) {
 	)
 
 	var cur state
-	if queue.Position >= len(queue.Queue) {
-		f.l.Debug("pos >= len",
-			log.Int("pos", queue.Position),
-			log.Int("len", len(queue.Queue)),
-		)
-		cur.Empty = true
-		cur.CurrentID = ""
-		cur.Waiting = 0
+	if current, ok := queue.Current(); ok {
+		cur.CurrentID = string(current)
+		cur.Waiting = len(queue.WithStatus(model.StatusWaiting))
 	} else {
-		f.l.Debug("pos < len",
-			log.Int("pos", queue.Position),
-			log.Int("len", len(queue.Queue)),
-			log.String("current", string(queue.Queue[queue.Position])),
-			log.Int("upcoming", len(queue.Queue)-queue.Position-1),
-		)
-		cur.Empty = false
-		cur.CurrentID = string(queue.Queue[queue.Position])
-		cur.Waiting = len(queue.Queue) - queue.Position - 1
+		cur.Empty = true
 	}
 
 	playbackState, err := f.client.GetState()
@@ -522,6 +780,13 @@ func (f frontend) getState() (state, error) {
 	return cur, nil
 }
 
+func formatETA(eta time.Time) string {
+	if eta.IsZero() {
+		return ""
+	}
+	return eta.Format("15:04")
+}
+
 func trimEmptyRight(s []string) []string {
 	l := len(s) - 1
 	for l >= 0 && s[l] == "" {
@@ -548,30 +813,28 @@ func (f frontend) getQueue() (queue, error) {
 		byID[t.ID] = t
 	}
 
-	var q queue
-	for i := 0; i < mQueue.Position; i++ {
-		id := mQueue.Queue[i]
+	// Slots are given up when a ticket is moved, only the queue knows
+	// which ones are still held.
+	ticket := func(id model.ID) model.Ticket {
 		t, ok := byID[id]
 		if !ok {
 			t.ID = id
 		}
-		q.Past = append(q.Past, t)
+		t.Slot = mQueue.Slots[id]
+		return t
 	}
-	if mQueue.Position >= 0 && mQueue.Position < len(mQueue.Queue) {
-		id := mQueue.Queue[mQueue.Position]
-		t, ok := byID[id]
-		if !ok {
-			t.ID = id
-		}
-		q.Current = t
+
+	q := queue{
+		ETA: mQueue.ETA,
 	}
-	for i := mQueue.Position + 1; i < len(mQueue.Queue); i++ {
-		id := mQueue.Queue[i]
-		t, ok := byID[id]
-		if !ok {
-			t.ID = id
-		}
-		q.Upcoming = append(q.Upcoming, t)
+	for _, id := range mQueue.WithStatus(model.StatusSung) {
+		q.Past = append(q.Past, ticket(id))
+	}
+	if id, ok := mQueue.Current(); ok {
+		q.Current = ticket(id)
+	}
+	for _, id := range mQueue.WithStatus(model.StatusWaiting) {
+		q.Upcoming = append(q.Upcoming, ticket(id))
 	}
 	return q, nil
 }
@@ -580,20 +843,224 @@ func (f frontend) getUpcoming() ([]model.Ticket, error) {
 	return nil, nil
 }
 
-func createServerActor(l log.Logger, listen string, client client.Client) group.Actor {
+func newFrontend(l log.Logger, c client.Client) frontend {
 	f := frontend{
-		queueTmpl:   templates.Must(templates.Create("web/queue.html")),
+		queueTmpl:   templates.Must(templates.CreateWithFuncs("web/queue.html", map[string]interface{}{"formatETA": formatETA})),
 		editTmpl:    templates.Must(templates.Create("web/edit.html")),
 		songsTmpl:   templates.Must(templates.Create("web/songs.html")),
 		playingTmpl: templates.Must(templates.CreateWithFuncs("web/playing.html", map[string]interface{}{"formatDuration": formatDuration})),
 		adminTmpl:   templates.Must(templates.Create("web/admin.html")),
 		cssTmpl:     templates.Must(templates.Create("web/web.css")),
 		index:       templates.MustResource(templates.NewResource("web/index.html")),
-		client:      client,
+		client:      c,
+		songNames:   client.NewSongNames(c, 10*time.Minute),
+		queues:      newQueueHub(),
+		notify:      newNotifyTokens(time.Minute),
 		l:           l,
 	}
 	f.cachedSongs = newCachedPage(l.Named("songs"), f.renderSongs)
+	return f
+}
+
+// createSongsActor drops the rendered song list and the song names when the
+// backend reloaded its songs.
+func createSongsActor(l log.Logger, f frontend) group.Actor {
+	return group.WithChannel(func(done <-chan struct{}) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			<-done
+			cancel()
+		}()
+
+		for event := range f.client.Subscribe(ctx) {
+			switch event.Type {
+			case model.EventSongsReloaded, model.EventResync:
+				l.Debug("songs changed, invalidating page",
+					log.Stringer("event", event),
+				)
+				f.cachedSongs.Invalidate()
+				f.songNames.Invalidate()
+			}
+		}
+		return nil
+	})
+}
+
+// queueHub keeps the most recent queue of every stage and passes changes on to
+// the browsers waiting for their turn on it.
+type queueHub struct {
+	m      *sync.Mutex
+	queues map[string]model.Queue
+	subs   map[string]map[chan model.Queue]struct{}
+}
+
+func newQueueHub() *queueHub {
+	return &queueHub{
+		m:      new(sync.Mutex),
+		queues: make(map[string]model.Queue),
+		subs:   make(map[string]map[chan model.Queue]struct{}),
+	}
+}
+
+func hubStage(stage string) string {
+	if stage == "" {
+		return model.DefaultStage
+	}
+	return stage
+}
+
+func (h *queueHub) Set(q model.Queue) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	stage := hubStage(q.Stage)
+	h.queues[stage] = q
+	for c := range h.subs[stage] {
+		select {
+		case c <- q:
+		default:
+			// The browser has not received the previous queue yet. Replace
+			// it, only the most recent one matters.
+			select {
+			case <-c:
+			default:
+			}
+			c <- q
+		}
+	}
+}
+
+func (h *queueHub) Has(stage string) bool {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	_, ok := h.queues[hubStage(stage)]
+	return ok
+}
+
+// Stages lists the stages whose queue is known.
+func (h *queueHub) Stages() []string {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	stages := make([]string, 0, len(h.queues))
+	for stage := range h.queues {
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+func (h *queueHub) Subscribe(stage string) (<-chan model.Queue, func()) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	stage = hubStage(stage)
+	c := make(chan model.Queue, 1)
+	if q, ok := h.queues[stage]; ok {
+		c <- q
+	}
+	if h.subs[stage] == nil {
+		h.subs[stage] = make(map[chan model.Queue]struct{})
+	}
+	h.subs[stage][c] = struct{}{}
+
+	return c, func() {
+		h.m.Lock()
+		defer h.m.Unlock()
+		delete(h.subs[stage], c)
+	}
+}
+
+// notifyTokens maps the tokens handed out by APINotifyToken to their tickets.
+// A token can be used to open the stream until it expires, so that the browser
+// can reconnect after short interruptions.
+type notifyTokens struct {
+	m      *sync.Mutex
+	ttl    time.Duration
+	tokens map[string]notifyToken
+}
+
+type notifyToken struct {
+	ticket  model.ID
+	expires time.Time
+}
 
+func newNotifyTokens(ttl time.Duration) *notifyTokens {
+	return &notifyTokens{
+		m:      new(sync.Mutex),
+		ttl:    ttl,
+		tokens: make(map[string]notifyToken),
+	}
+}
+
+func (n *notifyTokens) Create(ticketID model.ID) (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	n.m.Lock()
+	defer n.m.Unlock()
+
+	now := time.Now()
+	for k, t := range n.tokens {
+		if now.After(t.expires) {
+			delete(n.tokens, k)
+		}
+	}
+	n.tokens[token] = notifyToken{ticket: ticketID, expires: now.Add(n.ttl)}
+	return token, nil
+}
+
+// Get returns the ticket of a token that has not expired yet.
+func (n *notifyTokens) Get(token string) (model.ID, bool) {
+	n.m.Lock()
+	defer n.m.Unlock()
+
+	t, ok := n.tokens[token]
+	if !ok || time.Now().After(t.expires) {
+		return "", false
+	}
+	return t.ticket, true
+}
+
+// createNotifyActor keeps the queues of the hub up to date, so that ticket
+// holders are notified as soon as the queue changes.
+func createNotifyActor(l log.Logger, f frontend) group.Actor {
+	return group.WithChannel(func(done <-chan struct{}) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			<-done
+			cancel()
+		}()
+
+		for event := range f.client.Subscribe(ctx) {
+			switch {
+			case event.Queue != nil:
+				f.queues.Set(*event.Queue)
+			case event.Type == model.EventResync:
+				for _, stage := range f.queues.Stages() {
+					q, err := f.client.GetStageQueue(stage)
+					if err != nil {
+						l.Warn("failed to reload queue",
+							log.String("stage", stage),
+							log.Error(err),
+						)
+						continue
+					}
+					f.queues.Set(q)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func createServerActor(l log.Logger, listen string, f frontend) group.Actor {
 	handler := mux.NewRouter()
 	handler.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/index#/", http.StatusFound)
@@ -608,6 +1075,10 @@ func createServerActor(l log.Logger, listen string, client client.Client) group.
 	handler.Handle("/api/queue", httperr.HandlerFunc(f.APIQueue))
 	handler.Handle("/api/songs", httperr.HandlerFunc(f.APISongs))
 	handler.Handle("/api/save", httperr.HandlerFunc(f.APISave))
+	handler.Handle("/api/cancel", httperr.HandlerFunc(f.APICancel))
+	handler.Handle("/api/request", httperr.HandlerFunc(f.APIRequest))
+	handler.Handle("/api/notify", httperr.HandlerFunc(f.APINotifyToken)).Methods("POST")
+	handler.Handle("/api/notify", httperr.HandlerFunc(f.APINotify)).Methods("GET")
 	//handler.Handle("/queue", httperr.HandlerFunc(f.Queue))
 	//handler.Handle("/edit", httperr.HandlerFunc(f.Edit))
 	//handler.HandleFunc("/save", f.Save)
@@ -643,13 +1114,13 @@ func createServerActor(l log.Logger, listen string, client client.Client) group.
 		return group.Done(err)
 	}
 
+	// No WriteTimeout, as it would cut off the notification streams.
 	server := &http.Server{
-		Addr:         listen,
-		Handler:      handler,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  30 * time.Second,
-		ErrorLog:     stdLog,
+		Addr:        listen,
+		Handler:     handler,
+		ReadTimeout: 15 * time.Second,
+		IdleTimeout: 30 * time.Second,
+		ErrorLog:    stdLog,
 	}
 
 	return group.New(
@@ -731,6 +1202,13 @@ func (c *cachedPage) Get() (string, string, string) {
 	}
 }
 
+// Invalidate makes the next Get render the page again.
+func (c *cachedPage) Invalidate() {
+	c.entry.Store(&entry{
+		once: new(sync.Once),
+	})
+}
+
 func (c *cachedPage) update() {
 	var e entry
 	c.l.Debug("calling f")
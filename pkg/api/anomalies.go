@@ -0,0 +1,48 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Patagonicus/usdx-queue/pkg/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/backend"
+	"github.com/Patagonicus/usdx-queue/pkg/httperr"
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	httpauth "github.com/Patagonicus/usdx-queue/pkg/middleware/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/middleware/httpjson"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+type anomaliesAPI struct {
+	back *backend.Backend
+	l    log.Logger
+}
+
+func NewAnomalies(l log.Logger, a auth.Authenticator, back *backend.Backend, router router) {
+	requireReport := httpauth.Require(l, a, auth.PermReportAnomaly)
+
+	an := anomaliesAPI{
+		back: back,
+		l:    l,
+	}
+
+	router.Handle("", requireReport(httperr.HandlerFunc(an.Report))).Methods("POST")
+}
+
+func (an anomaliesAPI) Report(w http.ResponseWriter, r *http.Request) error {
+	_, jr := httpjson.Wrap(w, r)
+
+	var anomaly model.Anomaly
+	err := jr.Decode(&anomaly)
+	if err != nil {
+		return httperr.WithCode(err, http.StatusBadRequest)
+	}
+	if anomaly.Message == "" {
+		return httperr.WithCode(errors.New("message missing"), http.StatusBadRequest)
+	}
+
+	an.back.ReportAnomaly(anomaly)
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -3,25 +3,39 @@ package api
 import (
 	"net/http"
 	"path"
+	"time"
 
 	"github.com/Patagonicus/usdx-queue/pkg/auth"
 	"github.com/Patagonicus/usdx-queue/pkg/backend"
+	"github.com/Patagonicus/usdx-queue/pkg/cover"
+	"github.com/Patagonicus/usdx-queue/pkg/library"
 	"github.com/Patagonicus/usdx-queue/pkg/log"
-	"github.com/Patagonicus/usdx-reader/pkg/storage"
 	"github.com/gorilla/mux"
 )
 
-func New(l log.Logger, authenticator auth.Authenticator, back *backend.Backend, songs storage.Backend, cl CoverLoader) (http.Handler, error) {
+// New creates the handler for the API. changeover is the expected time
+// between two songs, used to estimate when tickets are up.
+func New(l log.Logger, authenticator auth.Authenticator, back *backend.Backend, songs library.Library, cl CoverLoader, thumbs *cover.Cache, changeover time.Duration, noShow backend.NoShowPolicy) (http.Handler, SongLibrary, error) {
 	r := mux.NewRouter()
-	NewClients(l, authenticator, prefixRouter{r, "/clients"})
-	NewTickets(l, authenticator, back, prefixRouter{r, "/tickets"})
-	NewQueue(l, authenticator, back, prefixRouter{r, "/queue"})
-	NewState(l, authenticator, back, prefixRouter{r, "/state"})
-	err := NewSongs(l, authenticator, songs, cl, prefixRouter{r, "/songs"})
+	lib, err := NewSongs(l, authenticator, songs, cl, thumbs, back.SongsReloaded, prefixRouter{r, "/songs"})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return r, nil
+	NewClients(l, authenticator, prefixRouter{r, "/clients"})
+	NewQueue(l, authenticator, back, lib, changeover, noShow, prefixRouter{r, "/queue"})
+	NewState(l, authenticator, back, prefixRouter{r, "/state"})
+	NewEvents(l, authenticator, back, prefixRouter{r, "/events"})
+	NewStages(l, authenticator, back, prefixRouter{r, "/stages"})
+	NewQueue(l, authenticator, back, lib, changeover, noShow, prefixRouter{r, "/stages/{stage}/queue"})
+	NewState(l, authenticator, back, prefixRouter{r, "/stages/{stage}/state"})
+	NewEvents(l, authenticator, back, prefixRouter{r, "/stages/{stage}/events"})
+	NewAnomalies(l, authenticator, back, prefixRouter{r, "/anomalies"})
+	NewHistory(l, authenticator, back, prefixRouter{r, "/history"})
+	NewLeaderboard(l, authenticator, back, prefixRouter{r, "/leaderboard"})
+	NewNights(l, authenticator, back, prefixRouter{r, "/nights"})
+	NewTickets(l, authenticator, back, lib, prefixRouter{r, "/tickets"})
+	NewLockouts(l, authenticator, back, prefixRouter{r, "/lockouts"})
+	return r, lib, nil
 }
 
 func withPrefix(r *mux.Router, prefix string, handler http.Handler) {
@@ -78,8 +78,9 @@ func (c clients) Create(w http.ResponseWriter, r *http.Request) error {
 	jw, jr := httpjson.Wrap(w, r)
 
 	var request struct {
-		Name string         `json:"name"`
-		Type *auth.PermType `json:"type"`
+		Name  string         `json:"name"`
+		Type  *auth.PermType `json:"type"`
+		Stage string         `json:"stage"`
 	}
 	err := jr.Decode(&request)
 	if err != nil {
@@ -93,7 +94,7 @@ func (c clients) Create(w http.ResponseWriter, r *http.Request) error {
 	c.l.Debug("creating new client",
 		log.Any("request", request),
 	)
-	client, err := c.authenticator.CreateClient(request.Name, *request.Type)
+	client, err := c.authenticator.CreateClient(request.Name, *request.Type, request.Stage)
 	if err != nil {
 		return err
 	}
@@ -140,9 +141,11 @@ func (c jsonClient) MarshalJSON() ([]byte, error) {
 		Name  string `json:"name"`
 		Typ   string `json:"type"`
 		Token string `json:"token"`
+		Stage string `json:"stage,omitempty"`
 	}{
 		Name:  c.GetName(),
 		Typ:   c.GetType().Name(),
 		Token: string(c.GetToken()),
+		Stage: c.GetStage(),
 	})
 }
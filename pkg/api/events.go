@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Patagonicus/usdx-queue/pkg/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/backend"
+	"github.com/Patagonicus/usdx-queue/pkg/httperr"
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	httpauth "github.com/Patagonicus/usdx-queue/pkg/middleware/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+const keepAliveInterval = 15 * time.Second
+
+type eventsAPI struct {
+	back *backend.Backend
+	l    log.Logger
+}
+
+func NewEvents(l log.Logger, a auth.Authenticator, back *backend.Backend, router router) {
+	requireList := httpauth.Require(l, a, auth.PermListEvents)
+
+	e := eventsAPI{
+		back: back,
+		l:    l,
+	}
+
+	router.Handle("", requireList(httperr.HandlerFunc(e.Stream))).Methods("GET")
+}
+
+func (e eventsAPI) Stream(w http.ResponseWriter, r *http.Request) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errors.New("streaming not supported")
+	}
+
+	var lastID uint64
+	lastS := r.Header.Get("Last-Event-ID")
+	if lastS == "" {
+		lastS = r.FormValue("last")
+	}
+	if lastS != "" {
+		var err error
+		lastID, err = strconv.ParseUint(lastS, 10, 64)
+		if err != nil {
+			return httperr.WithCode(fmt.Errorf("invalid last event id: %s", lastS), http.StatusBadRequest)
+		}
+	}
+
+	stage, err := stageOf(r)
+	if err != nil {
+		return err
+	}
+
+	events, cancel := e.back.Subscribe(lastID)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	e.l.Debug("client subscribed to events",
+		log.Uint64("last", lastID),
+	)
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				e.l.Debug("subscription closed")
+				return nil
+			}
+			if !inStage(event, stage) {
+				continue
+			}
+			err := writeEvent(w, event)
+			if err != nil {
+				e.l.Debug("failed to write event",
+					log.Error(err),
+				)
+				return nil
+			}
+		case <-keepAlive.C:
+			_, err := fmt.Fprint(w, ": keep-alive\n\n")
+			if err != nil {
+				return nil
+			}
+		case <-r.Context().Done():
+			return nil
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event model.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
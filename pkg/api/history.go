@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Patagonicus/usdx-queue/pkg/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/backend"
+	"github.com/Patagonicus/usdx-queue/pkg/httperr"
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	httpauth "github.com/Patagonicus/usdx-queue/pkg/middleware/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/middleware/httpjson"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+type historyAPI struct {
+	back *backend.Backend
+	l    log.Logger
+}
+
+func NewHistory(l log.Logger, a auth.Authenticator, back *backend.Backend, router router) {
+	requireList := httpauth.Require(l, a, auth.PermListHistory)
+
+	h := historyAPI{
+		back: back,
+		l:    l,
+	}
+
+	router.Handle("", requireList(httperr.HandlerFunc(h.List))).Methods("GET")
+}
+
+func (h historyAPI) List(w http.ResponseWriter, r *http.Request) error {
+	jw, _ := httpjson.Wrap(w, r)
+
+	songs, err := h.back.GetHistory()
+	if err != nil {
+		h.l.Warn("failed to get history",
+			log.Error(err),
+		)
+		return err
+	}
+
+	if songs == nil {
+		songs = []model.PlayedSong{}
+	}
+
+	return jw.Encode(songs)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Patagonicus/usdx-queue/pkg/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/backend"
+	"github.com/Patagonicus/usdx-queue/pkg/httperr"
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	httpauth "github.com/Patagonicus/usdx-queue/pkg/middleware/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/middleware/httpjson"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+type leaderboardAPI struct {
+	back *backend.Backend
+	l    log.Logger
+}
+
+func NewLeaderboard(l log.Logger, a auth.Authenticator, back *backend.Backend, router router) {
+	requireList := httpauth.Require(l, a, auth.PermListLeaderboard)
+
+	b := leaderboardAPI{
+		back: back,
+		l:    l,
+	}
+
+	router.Handle("", requireList(httperr.HandlerFunc(b.Get))).Methods("GET")
+}
+
+func (b leaderboardAPI) Get(w http.ResponseWriter, r *http.Request) error {
+	jw, _ := httpjson.Wrap(w, r)
+
+	f, err := parseLeaderboardFilter(r)
+	if err != nil {
+		return httperr.WithCode(err, http.StatusBadRequest)
+	}
+
+	board, err := b.back.GetLeaderboard(f)
+	if err != nil {
+		b.l.Warn("failed to get leaderboard",
+			log.Error(err),
+		)
+		return err
+	}
+
+	return jw.Encode(board)
+}
+
+func parseLeaderboardFilter(r *http.Request) (model.LeaderboardFilter, error) {
+	var f model.LeaderboardFilter
+	var err error
+
+	if s := r.FormValue("since"); s != "" {
+		f.Since, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return f, fmt.Errorf("invalid since: %s", s)
+		}
+	}
+	if s := r.FormValue("until"); s != "" {
+		f.Until, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return f, fmt.Errorf("invalid until: %s", s)
+		}
+	}
+
+	switch m := model.SingMode(r.FormValue("mode")); m {
+	case model.AnyMode, model.Solo, model.Duet:
+		f.Mode = m
+	default:
+		return f, fmt.Errorf("invalid mode: %s", m)
+	}
+
+	switch by := model.RankBy(r.FormValue("sort")); by {
+	case "", model.RankByBest, model.RankByAverage:
+		f.RankBy = by
+	default:
+		return f, fmt.Errorf("invalid sort: %s", by)
+	}
+
+	if s := r.FormValue("limit"); s != "" {
+		f.Limit, err = strconv.Atoi(s)
+		if err != nil || f.Limit < 0 {
+			return f, fmt.Errorf("invalid limit: %s", s)
+		}
+	}
+
+	return f, nil
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Patagonicus/usdx-queue/pkg/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/backend"
+	"github.com/Patagonicus/usdx-queue/pkg/httperr"
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	httpauth "github.com/Patagonicus/usdx-queue/pkg/middleware/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/middleware/httpjson"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+type lockoutsAPI struct {
+	back *backend.Backend
+	l    log.Logger
+}
+
+func NewLockouts(l log.Logger, a auth.Authenticator, back *backend.Backend, router router) {
+	requireList := httpauth.Require(l, a, auth.PermListLockouts)
+
+	lo := lockoutsAPI{
+		back: back,
+		l:    l,
+	}
+
+	router.Handle("", requireList(httperr.HandlerFunc(lo.List))).Methods("GET")
+}
+
+func (lo lockoutsAPI) List(w http.ResponseWriter, r *http.Request) error {
+	jw, _ := httpjson.Wrap(w, r)
+
+	lockouts := lo.back.Lockouts()
+	if lockouts == nil {
+		lockouts = []model.Lockout{}
+	}
+
+	return jw.Encode(lockouts)
+}
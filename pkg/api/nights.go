@@ -0,0 +1,140 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/Patagonicus/usdx-queue/pkg/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/backend"
+	"github.com/Patagonicus/usdx-queue/pkg/httperr"
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	httpauth "github.com/Patagonicus/usdx-queue/pkg/middleware/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/middleware/httpjson"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+	"github.com/gorilla/mux"
+)
+
+type nightsAPI struct {
+	back *backend.Backend
+	l    log.Logger
+}
+
+func NewNights(l log.Logger, a auth.Authenticator, back *backend.Backend, router router) {
+	requireList := httpauth.Require(l, a, auth.PermListNights)
+	requireStart := httpauth.Require(l, a, auth.PermStartNight)
+	requireChange := httpauth.Require(l, a, auth.PermChangeNight)
+
+	n := nightsAPI{
+		back: back,
+		l:    l,
+	}
+
+	router.Handle("", requireList(httperr.HandlerFunc(n.List))).Methods("GET")
+	router.Handle("", requireStart(httperr.HandlerFunc(n.Start))).Methods("POST")
+	router.Handle("/{id}", requireList(httperr.HandlerFunc(n.Get))).Methods("GET")
+	router.Handle("/{id}", requireChange(httperr.HandlerFunc(n.Update))).Methods("PATCH")
+}
+
+func (n nightsAPI) List(w http.ResponseWriter, r *http.Request) error {
+	jw, _ := httpjson.Wrap(w, r)
+
+	nights, err := n.back.GetNights()
+	if err != nil {
+		n.l.Warn("failed to get nights",
+			log.Error(err),
+		)
+		return err
+	}
+
+	return jw.Encode(nights)
+}
+
+// Start archives the current night. The body may be empty or contain the
+// name of the night that is archived.
+func (n nightsAPI) Start(w http.ResponseWriter, r *http.Request) error {
+	jw, _ := httpjson.Wrap(w, r)
+
+	var request struct {
+		Name string `json:"name"`
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		err = json.Unmarshal(data, &request)
+		if err != nil {
+			return httperr.WithCode(err, http.StatusBadRequest)
+		}
+	}
+
+	archived, err := n.back.StartNight(request.Name)
+	if err != nil {
+		n.l.Warn("failed to start night",
+			log.Error(err),
+		)
+		return err
+	}
+
+	return jw.Encode(archived)
+}
+
+// Update changes the scheduling of the current night, which has ID 0.
+// Archived nights cannot be changed.
+func (n nightsAPI) Update(w http.ResponseWriter, r *http.Request) error {
+	_, jr := httpjson.Wrap(w, r)
+
+	if mux.Vars(r)["id"] != "0" {
+		return httperr.WithCode(errors.New("only the current night can be changed"), http.StatusConflict)
+	}
+
+	var request struct {
+		Scheduling *model.Scheduling `json:"scheduling"`
+	}
+	err := jr.Decode(&request)
+	if err != nil {
+		return httperr.WithCode(err, http.StatusBadRequest)
+	}
+
+	if request.Scheduling != nil {
+		err = n.back.SetScheduling(*request.Scheduling)
+		switch err.(type) {
+		case nil:
+		case backend.ErrInvalidScheduling:
+			return httperr.WithCode(err, http.StatusBadRequest)
+		default:
+			return err
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (n nightsAPI) Get(w http.ResponseWriter, r *http.Request) error {
+	jw, _ := httpjson.Wrap(w, r)
+
+	s, ok := mux.Vars(r)["id"]
+	if !ok {
+		return httperr.WithCode(errors.New("night id missing"), http.StatusBadRequest)
+	}
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return httperr.WithCode(err, http.StatusBadRequest)
+	}
+
+	night, err := n.back.GetNight(id)
+	switch err.(type) {
+	case nil:
+	case backend.ErrNightDoesNotExist:
+		return httperr.WithCode(err, http.StatusNotFound)
+	default:
+		return err
+	}
+
+	return jw.Encode(night)
+}
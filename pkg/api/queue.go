@@ -1,7 +1,13 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/Patagonicus/usdx-queue/pkg/auth"
 	"github.com/Patagonicus/usdx-queue/pkg/backend"
@@ -9,71 +15,357 @@ import (
 	"github.com/Patagonicus/usdx-queue/pkg/log"
 	httpauth "github.com/Patagonicus/usdx-queue/pkg/middleware/auth"
 	"github.com/Patagonicus/usdx-queue/pkg/middleware/httpjson"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+	"github.com/gorilla/mux"
 )
 
 type queueAPI struct {
-	back *backend.Backend
-	l    log.Logger
+	back       *backend.Backend
+	songs      SongIndex
+	changeover time.Duration
+	noShow     backend.NoShowPolicy
+	l          log.Logger
 }
 
-func NewQueue(l log.Logger, a auth.Authenticator, back *backend.Backend, router router) {
+func NewQueue(l log.Logger, a auth.Authenticator, back *backend.Backend, songs SongIndex, changeover time.Duration, noShow backend.NoShowPolicy, router router) {
 	requireList := httpauth.Require(l, a, auth.PermListQueue)
 	requireAdvance := httpauth.Require(l, a, auth.PermAdvanceQueue)
 	requireGoBack := httpauth.Require(l, a, auth.PermGoBackQueue)
 	requirePause := httpauth.Require(l, a, auth.PermPauseQueue)
+	requireMove := httpauth.Require(l, a, auth.PermMoveTicket)
+	requireRemove := httpauth.Require(l, a, auth.PermRemoveTicket)
+	requireDefer := httpauth.Require(l, a, auth.PermDeferTicket)
+	requireNoShow := httpauth.Require(l, a, auth.PermNoShow)
+	requireListPending := httpauth.Require(l, a, auth.PermListPendingAdvances)
+	requirePropose := httpauth.Require(l, a, auth.PermProposeAdvance)
+	requireResolve := httpauth.Require(l, a, auth.PermResolveAdvance)
 
 	q := queueAPI{
-		back: back,
-		l:    l,
+		back:       back,
+		songs:      songs,
+		changeover: changeover,
+		noShow:     noShow,
+		l:          l,
 	}
 
 	router.Handle("", requireList(httperr.HandlerFunc(q.List))).Methods("GET")
 	router.Handle("actions/advance", requireAdvance(httperr.HandlerFunc(q.Advance))).Methods("POST")
 	router.Handle("actions/goback", requireGoBack(httperr.HandlerFunc(q.GoBack))).Methods("POST")
 	router.Handle("actions/pause", requirePause(httperr.HandlerFunc(q.Pause))).Methods("POST")
+	router.Handle("actions/noshow", requireNoShow(httperr.HandlerFunc(q.NoShow))).Methods("POST")
+	router.Handle("pending", requireListPending(httperr.HandlerFunc(q.ListPending))).Methods("GET")
+	router.Handle("pending", requirePropose(httperr.HandlerFunc(q.Propose))).Methods("POST")
+	router.Handle("pending/{pending}/actions/confirm", requireResolve(httperr.HandlerFunc(q.Confirm))).Methods("POST")
+	router.Handle("pending/{pending}/actions/reject", requireResolve(httperr.HandlerFunc(q.Reject))).Methods("POST")
+	router.Handle("{id}", requireRemove(httperr.HandlerFunc(q.Remove))).Methods("DELETE")
+	router.Handle("{id}/actions/move", requireMove(httperr.HandlerFunc(q.Move))).Methods("POST")
+	router.Handle("{id}/actions/defer", requireDefer(httperr.HandlerFunc(q.Defer))).Methods("POST")
 }
 
 func (q queueAPI) List(w http.ResponseWriter, r *http.Request) error {
 	jw, _ := httpjson.Wrap(w, r)
 
-	queue, err := q.back.GetQueue()
+	stage, err := stageOf(r)
 	if err != nil {
 		return err
 	}
 
+	queue, err := q.back.EstimateQueue(stage, q.changeover, q.songs.SongLength)
+	if _, ok := err.(backend.ErrStageDoesNotExist); ok {
+		return httperr.WithCode(err, http.StatusNotFound)
+	}
+	if err != nil {
+		return err
+	}
+
+	jw.Header().Set("ETag", etag(queue.Version))
 	jw.Encode(queue)
 	return nil
 }
 
 func (q queueAPI) Advance(w http.ResponseWriter, r *http.Request) error {
-	err := q.back.Advance()
-	switch {
-	case err == backend.ErrInvalidQueueMovement:
-		return httperr.WithCode(err, http.StatusConflict)
-	case err != nil:
+	stage, err := stageOf(r)
+	if err != nil {
 		return err
 	}
-	w.WriteHeader(http.StatusNoContent)
-	return nil
+
+	v, err := q.decodeAction(r, nil)
+	if err != nil {
+		return err
+	}
+
+	return q.handleMovement(w, q.back.Advance(stage, v))
 }
 
 func (q queueAPI) GoBack(w http.ResponseWriter, r *http.Request) error {
-	err := q.back.GoBack()
-	switch {
-	case err == backend.ErrInvalidQueueMovement:
-		return httperr.WithCode(err, http.StatusConflict)
-	case err != nil:
+	stage, err := stageOf(r)
+	if err != nil {
 		return err
 	}
-	w.WriteHeader(http.StatusNoContent)
-	return nil
+
+	v, err := q.decodeAction(r, nil)
+	if err != nil {
+		return err
+	}
+
+	return q.handleMovement(w, q.back.GoBack(stage, v))
 }
 
 func (q queueAPI) Pause(w http.ResponseWriter, r *http.Request) error {
-	err := q.back.Pause()
+	stage, err := stageOf(r)
+	if err != nil {
+		return err
+	}
+
+	v, err := q.decodeAction(r, nil)
+	if err != nil {
+		return err
+	}
+
+	return q.handleMovement(w, q.back.Pause(stage, v))
+}
+
+// NoShow moves the current ticket back or, after too many no-shows, removes it
+// from the queue.
+func (q queueAPI) NoShow(w http.ResponseWriter, r *http.Request) error {
+	jw, _ := httpjson.Wrap(w, r)
+
+	stage, err := stageOf(r)
+	if err != nil {
+		return err
+	}
+
+	v, err := q.decodeAction(r, nil)
+	if err != nil {
+		return err
+	}
+
+	ticket, err := q.back.NoShow(stage, q.noShow, v)
+	if err != nil {
+		if _, ok := err.(backend.ErrTicketDoesNotExist); ok {
+			return httperr.WithCode(err, http.StatusNotFound)
+		}
+		return q.handleMovement(w, err)
+	}
+
+	return jw.Encode(struct {
+		Ticket  model.Ticket `json:"ticket"`
+		Dropped bool         `json:"dropped"`
+	}{
+		ticket,
+		q.noShow.Drops(ticket.NoShows),
+	})
+}
+
+func (q queueAPI) Move(w http.ResponseWriter, r *http.Request) error {
+	idS, ok := mux.Vars(r)["id"]
+	if !ok {
+		return httperr.WithCode(errors.New("ticket id missing"), http.StatusBadRequest)
+	}
+
+	stage, err := stageOf(r)
+	if err != nil {
+		return err
+	}
+
+	var request struct {
+		Position *int `json:"position"`
+	}
+	v, err := q.decodeAction(r, &request)
+	if err != nil {
+		return err
+	}
+	if request.Position == nil {
+		return httperr.WithCode(errors.New("missing position"), http.StatusBadRequest)
+	}
+
+	return q.handleMovement(w, q.back.MoveTicket(stage, model.ID(idS), *request.Position, v))
+}
+
+func (q queueAPI) Remove(w http.ResponseWriter, r *http.Request) error {
+	idS, ok := mux.Vars(r)["id"]
+	if !ok {
+		return httperr.WithCode(errors.New("ticket id missing"), http.StatusBadRequest)
+	}
+
+	stage, err := stageOf(r)
+	if err != nil {
+		return err
+	}
+
+	v, err := q.decodeAction(r, nil)
+	if err != nil {
+		return err
+	}
+
+	return q.handleMovement(w, q.back.RemoveTicket(stage, model.ID(idS), v))
+}
+
+func (q queueAPI) Defer(w http.ResponseWriter, r *http.Request) error {
+	idS, ok := mux.Vars(r)["id"]
+	if !ok {
+		return httperr.WithCode(errors.New("ticket id missing"), http.StatusBadRequest)
+	}
+
+	stage, err := stageOf(r)
+	if err != nil {
+		return err
+	}
+
+	var request struct {
+		By int `json:"by"`
+	}
+	v, err := q.decodeAction(r, &request)
+	if err != nil {
+		return err
+	}
+
+	return q.handleMovement(w, q.back.Defer(stage, model.ID(idS), request.By, v))
+}
+
+func (q queueAPI) ListPending(w http.ResponseWriter, r *http.Request) error {
+	jw, _ := httpjson.Wrap(w, r)
+
+	stage, err := stageOf(r)
+	if err != nil {
+		return err
+	}
+
+	pending, err := q.back.GetPendingAdvances()
+	if err != nil {
+		return err
+	}
+
+	result := []model.PendingAdvance{}
+	for _, p := range pending {
+		if stage == "" || p.Stage == stage {
+			result = append(result, p)
+		}
+	}
+
+	return jw.Encode(result)
+}
+
+func (q queueAPI) Propose(w http.ResponseWriter, r *http.Request) error {
+	jw, jr := httpjson.Wrap(w, r)
+
+	var request model.PendingAdvance
+	err := jr.Decode(&request)
+	if err != nil {
+		return httperr.WithCode(err, http.StatusBadRequest)
+	}
+	if request.Ticket == "" {
+		return httperr.WithCode(errors.New("ticket missing"), http.StatusBadRequest)
+	}
+
+	stage, err := stageOf(r)
+	if err != nil {
+		return err
+	}
+	if stage != "" {
+		request.Stage = stage
+	}
+
+	pending, err := q.back.ProposeAdvance(request)
+	if _, ok := err.(backend.ErrStageDoesNotExist); ok {
+		return httperr.WithCode(err, http.StatusNotFound)
+	}
+	if err != nil {
+		return err
+	}
+
+	jw.Header().Set("Location", strconv.FormatUint(pending.ID, 10))
+	jw.WriteHeader(http.StatusCreated)
+	return jw.Encode(pending)
+}
+
+func (q queueAPI) Confirm(w http.ResponseWriter, r *http.Request) error {
+	n, err := pendingID(r)
 	if err != nil {
 		return err
 	}
+
+	stage, err := stageOf(r)
+	if err != nil {
+		return err
+	}
+
+	v, err := q.decodeAction(r, nil)
+	if err != nil {
+		return err
+	}
+
+	return q.handleMovement(w, q.back.ConfirmAdvance(stage, n, v))
+}
+
+func (q queueAPI) Reject(w http.ResponseWriter, r *http.Request) error {
+	n, err := pendingID(r)
+	if err != nil {
+		return err
+	}
+
+	stage, err := stageOf(r)
+	if err != nil {
+		return err
+	}
+
+	return q.handleMovement(w, q.back.RejectAdvance(stage, n))
+}
+
+func pendingID(r *http.Request) (uint64, error) {
+	s, ok := mux.Vars(r)["pending"]
+	if !ok {
+		return 0, httperr.WithCode(errors.New("pending advance id missing"), http.StatusBadRequest)
+	}
+
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, httperr.WithCode(err, http.StatusBadRequest)
+	}
+	return n, nil
+}
+
+// decodeAction decodes the optional body of an action into request and
+// returns the queue version the client expects. The body may be empty.
+func (q queueAPI) decodeAction(r *http.Request, request interface{}) (model.Version, error) {
+	var body struct {
+		Version *model.Version `json:"version"`
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return model.DontCare, err
+	}
+
+	if len(bytes.TrimSpace(data)) > 0 {
+		err = json.Unmarshal(data, &body)
+		if err != nil {
+			return model.DontCare, httperr.WithCode(err, http.StatusBadRequest)
+		}
+		if request != nil {
+			err = json.Unmarshal(data, request)
+			if err != nil {
+				return model.DontCare, httperr.WithCode(err, http.StatusBadRequest)
+			}
+		}
+	}
+
+	return expectedVersion(r, body.Version)
+}
+
+func (q queueAPI) handleMovement(w http.ResponseWriter, err error) error {
+	switch err.(type) {
+	case nil:
+	case backend.ErrTicketNotQueued, backend.ErrPendingAdvanceDoesNotExist, backend.ErrStageDoesNotExist:
+		return httperr.WithCode(err, http.StatusNotFound)
+	case backend.ErrVersionConflict:
+		return conflict(w, err)
+	default:
+		if err == backend.ErrInvalidQueueMovement {
+			return httperr.WithCode(err, http.StatusConflict)
+		}
+		return err
+	}
 	w.WriteHeader(http.StatusNoContent)
 	return nil
 }
@@ -4,56 +4,151 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Patagonicus/usdx-queue/pkg/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/cover"
 	"github.com/Patagonicus/usdx-queue/pkg/httperr"
+	"github.com/Patagonicus/usdx-queue/pkg/library"
 	"github.com/Patagonicus/usdx-queue/pkg/log"
 	httpauth "github.com/Patagonicus/usdx-queue/pkg/middleware/auth"
 	"github.com/Patagonicus/usdx-queue/pkg/middleware/httpjson"
 	"github.com/Patagonicus/usdx-queue/pkg/model"
-	"github.com/Patagonicus/usdx-reader/pkg/storage"
 	"github.com/gorilla/mux"
 )
 
 var enc = base64.StdEncoding
 
+const placeholderSize = 512
+
+// coverSizes are the sizes thumbnails are made in, smallest first. Other sizes
+// are rounded up, so that only a few thumbnails are kept per song.
+var coverSizes = []int{64, 128, 256, 512}
+
+// coverSize returns the smallest thumbnail size that is at least n, or the
+// largest one.
+func coverSize(n int) int {
+	for _, size := range coverSizes {
+		if size >= n {
+			return size
+		}
+	}
+	return coverSizes[len(coverSizes)-1]
+}
+
 type CoverLoader interface {
 	Get(path string) ([]byte, []byte, error)
 }
 
 type songsAPI struct {
+	lib      library.Library
+	index    *atomic.Value
+	reload   *sync.Mutex
+	reloaded func()
+	cl       CoverLoader
+	thumbs   *cover.Cache
+	l        log.Logger
+}
+
+// songIndex is replaced as a whole on reload, so requests always see a
+// consistent set of songs.
+type songIndex struct {
 	allSongs string
 	songs    map[string]model.Song
 	covers   map[string]string
-	cl       CoverLoader
-	l        log.Logger
+	sorted   map[model.SongSort][]sortedSong
+}
+
+// SongIndex tells whether a song ID is known and how long the song is.
+type SongIndex interface {
+	HasSong(id string) bool
+	// SongLength returns 0 for unknown songs and songs of unknown length.
+	SongLength(id string) time.Duration
 }
 
-func NewSongs(l log.Logger, a auth.Authenticator, b storage.Backend, coverLoader CoverLoader, router router) error {
+// SongLibrary is a SongIndex that can be rebuilt from the library.
+type SongLibrary interface {
+	SongIndex
+	Reload() error
+}
+
+// NewSongs reads all songs from lib. reloaded is called after every
+// successful reload.
+func NewSongs(l log.Logger, a auth.Authenticator, lib library.Library, coverLoader CoverLoader, thumbs *cover.Cache, reloaded func(), router router) (SongLibrary, error) {
 	requireGet := httpauth.Require(l, a, auth.PermGetSong)
 	requireList := httpauth.Require(l, a, auth.PermListSong)
+	requireReload := httpauth.Require(l, a, auth.PermReloadSongs)
 	s := &songsAPI{
-		songs:  make(map[string]model.Song),
-		covers: make(map[string]string),
-		cl:     coverLoader,
-		l:      l,
+		lib:      lib,
+		index:    new(atomic.Value),
+		reload:   new(sync.Mutex),
+		reloaded: reloaded,
+		cl:       coverLoader,
+		thumbs:   thumbs,
+		l:        l,
 	}
 
 	router.Handle("/", requireList(httperr.HandlerFunc(s.List))).Methods("GET")
+	router.Handle("/actions/reload", requireReload(httperr.HandlerFunc(s.ReloadAction))).Methods("POST")
 	router.Handle("/{id}", requireGet(httperr.HandlerFunc(s.Get))).Methods("GET")
 	router.Handle("/{id}/cover", httperr.HandlerFunc(s.GetCover)).Methods("GET")
-	return s.fill(b)
+
+	index, err := s.build()
+	if err != nil {
+		return nil, err
+	}
+	s.index.Store(index)
+	return s, nil
+}
+
+func (s *songsAPI) current() *songIndex {
+	return s.index.Load().(*songIndex)
 }
 
-func (s *songsAPI) fill(b storage.Backend) error {
-	r := b.GetAll()
+// Reload rebuilds the index from the library. The old index keeps serving
+// requests until the new one is complete. If reading the library fails, the
+// old index is kept.
+func (s *songsAPI) Reload() error {
+	s.reload.Lock()
+	defer s.reload.Unlock()
+
+	index, err := s.build()
+	if err != nil {
+		s.l.Warn("failed to reload songs",
+			log.Error(err),
+		)
+		return err
+	}
+	s.index.Store(index)
+	s.l.Info("reloaded songs",
+		log.Int("songs", len(index.songs)),
+	)
+	if s.reloaded != nil {
+		s.reloaded()
+	}
+	return nil
+}
+
+func (s *songsAPI) build() (*songIndex, error) {
+	songs, err := s.lib.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	index := &songIndex{
+		songs:  make(map[string]model.Song),
+		covers: make(map[string]string),
+	}
 	var all []model.Song
-	for r.Next() {
-		song := r.Song()
-		sourceS := filepath.Join(song.Dir, song.SourceFile)
+	var entries []*songEntry
+	for _, song := range songs {
+		sourceS := song.Source()
 		if song.Artist == "" && song.Title == "" {
 			s.l.Warn("skipping song without title and artist",
 				log.String("source", sourceS),
@@ -63,37 +158,89 @@ func (s *songsAPI) fill(b storage.Backend) error {
 		source := enc.EncodeToString([]byte(sourceS))
 
 		msong := model.Song{
-			Title:  song.Title,
-			Artist: song.Artist,
-			Year:   song.Year,
+			ID:         source,
+			Title:      song.Title,
+			Artist:     song.Artist,
+			Year:       song.Year,
+			Language:   song.Language,
+			Genre:      song.Genre,
+			Edition:    song.Edition,
+			Duet:       song.Duet,
+			Length:     song.Length,
+			Video:      song.VideoPath != "",
+			Background: song.BackgroundPath != "",
 		}
-		s.songs[source] = msong
-		s.covers[source] = filepath.Join(song.Dir, song.CoverPath)
+		index.songs[source] = msong
+		index.covers[source] = filepath.Join(song.Dir, song.CoverPath)
 		all = append(all, msong)
+		entries = append(entries, &songEntry{
+			song: msong,
+			text: fold(song.Artist + " " + song.Title),
+		})
 	}
-	if r.Err() != nil {
-		return r.Err()
-	}
+	index.sorted = sortSongs(entries)
 	data, err := json.Marshal(all)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	s.allSongs = string(data)
+	index.allSongs = string(data)
 	s.l.Debug("serialized songs",
 		log.Int("songs", len(all)),
-		log.Int("length", len(s.allSongs)),
+		log.Int("length", len(index.allSongs)),
 	)
+	return index, nil
+}
+
+func (s *songsAPI) ReloadAction(w http.ResponseWriter, r *http.Request) error {
+	err := s.Reload()
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 	return nil
 }
 
+func (s *songsAPI) HasSong(id string) bool {
+	_, ok := s.current().songs[id]
+	return ok
+}
+
+func (s *songsAPI) SongLength(id string) time.Duration {
+	return s.current().songs[id].Length
+}
+
+// List returns all songs, unless there are query parameters. Then it returns a
+// page of the songs that match them.
 func (s *songsAPI) List(w http.ResponseWriter, r *http.Request) error {
+	if len(r.URL.Query()) > 0 {
+		return s.search(w, r)
+	}
+
+	allSongs := s.current().allSongs
 	s.l.Debug("writing songs",
-		log.Int("length", len(s.allSongs)),
+		log.Int("length", len(allSongs)),
 	)
-	io.WriteString(w, s.allSongs)
+	io.WriteString(w, allSongs)
 	return nil
 }
 
+func (s *songsAPI) search(w http.ResponseWriter, r *http.Request) error {
+	jw, _ := httpjson.Wrap(w, r)
+
+	f, err := parseSongFilter(r)
+	if err != nil {
+		return httperr.WithCode(err, http.StatusBadRequest)
+	}
+
+	page, err := s.current().search(f)
+	if err != nil {
+		return httperr.WithCode(err, http.StatusBadRequest)
+	}
+
+	return jw.Encode(page)
+}
+
 func (s *songsAPI) Get(w http.ResponseWriter, r *http.Request) error {
 	jw, _ := httpjson.Wrap(w, r)
 
@@ -102,7 +249,7 @@ func (s *songsAPI) Get(w http.ResponseWriter, r *http.Request) error {
 		return httperr.WithCode(errors.New("id missing"), http.StatusBadRequest)
 	}
 
-	song, ok := s.songs[source]
+	song, ok := s.current().songs[source]
 	if !ok {
 		return httperr.WithCode(errors.New("song not found"), http.StatusNotFound)
 	}
@@ -117,17 +264,44 @@ func (s *songsAPI) GetCover(w http.ResponseWriter, r *http.Request) error {
 		return httperr.WithCode(errors.New("id missing"), http.StatusBadRequest)
 	}
 
-	coverP, ok := s.covers[source]
+	coverP, ok := s.current().covers[source]
 	if !ok {
 		return httperr.WithCode(errors.New("song not found"), http.StatusNotFound)
 	}
 
-	data, etag, err := s.cl.Get(coverP)
-	if err != nil {
-		return err
+	size := 0
+	if sizeS := r.FormValue("size"); sizeS != "" {
+		var err error
+		size, err = strconv.Atoi(sizeS)
+		if err != nil || size <= 0 {
+			return httperr.WithCode(fmt.Errorf("invalid size: %s", sizeS), http.StatusBadRequest)
+		}
+		size = coverSize(size)
 	}
 
+	data, etag, err := s.cl.Get(coverP)
 	etagS := enc.EncodeToString(etag)
+	if err == nil && size > 0 {
+		orig := data
+		data, err = s.thumbs.Get(fmt.Sprintf("%d-%x", size, etag), func() ([]byte, error) {
+			return cover.Thumbnail(orig, size)
+		})
+		etagS = fmt.Sprintf("%s-%d", etagS, size)
+	}
+	if err != nil {
+		s.l.Debug("using placeholder cover",
+			log.String("path", coverP),
+			log.Error(err),
+		)
+		if size == 0 {
+			size = placeholderSize
+		}
+		data, _ = s.thumbs.Get(fmt.Sprintf("placeholder-%d", size), func() ([]byte, error) {
+			return cover.Placeholder(size), nil
+		})
+		etag = []byte("placeholder")
+		etagS = fmt.Sprintf("placeholder-%d", size)
+	}
 
 	if cEtag := r.Header.Get("If-None-Match"); cEtag == etagS {
 		w.WriteHeader(http.StatusNotModified)
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+const (
+	defaultSongLimit = 50
+	maxSongLimit     = 1000
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// songEntry is a song together with everything needed to search for it.
+type songEntry struct {
+	song model.Song
+	// text is the folded artist and title that queries are matched against.
+	text string
+}
+
+// sortedSong is a song in one of the sort orders. key is unique, so it can be
+// used as cursor even if the index was reloaded in between.
+type sortedSong struct {
+	key   string
+	entry *songEntry
+}
+
+var songSorts = []model.SongSort{model.SortByArtist, model.SortByTitle, model.SortByYear}
+
+func sortSongs(entries []*songEntry) map[model.SongSort][]sortedSong {
+	result := make(map[model.SongSort][]sortedSong, len(songSorts))
+	for _, by := range songSorts {
+		sorted := make([]sortedSong, len(entries))
+		for i, e := range entries {
+			sorted[i] = sortedSong{
+				key:   sortKey(by, e.song) + "\x00" + e.song.ID,
+				entry: e,
+			}
+		}
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].key < sorted[j].key
+		})
+		result[by] = sorted
+	}
+	return result
+}
+
+func sortKey(by model.SongSort, s model.Song) string {
+	switch by {
+	case model.SortByTitle:
+		return fold(s.Title) + "\x01" + fold(s.Artist)
+	case model.SortByYear:
+		return fmt.Sprintf("%04d", s.Year) + "\x01" + fold(s.Artist) + "\x01" + fold(s.Title)
+	default:
+		return fold(s.Artist) + "\x01" + fold(s.Title)
+	}
+}
+
+func (index *songIndex) search(f model.SongFilter) (model.SongPage, error) {
+	page := model.SongPage{
+		Songs: []model.Song{},
+	}
+
+	sorted := index.sorted[f.Sort]
+	if f.Sort == "" {
+		sorted = index.sorted[model.SortByArtist]
+	}
+
+	start := 0
+	if f.Cursor != "" {
+		after, err := base64.RawURLEncoding.DecodeString(f.Cursor)
+		if err != nil {
+			return page, errInvalidCursor
+		}
+		start = sort.Search(len(sorted), func(i int) bool {
+			return sorted[i].key > string(after)
+		})
+	}
+
+	limit := f.Limit
+	if limit == 0 {
+		limit = defaultSongLimit
+	}
+
+	var last string
+	words := strings.Fields(fold(f.Query))
+	language := fold(f.Language)
+	genre := fold(f.Genre)
+	for i := start; i < len(sorted); i++ {
+		e := sorted[i].entry
+		if !e.matches(words, language, genre, f.MinYear, f.MaxYear) {
+			continue
+		}
+		if len(page.Songs) == limit {
+			page.Next = base64.RawURLEncoding.EncodeToString([]byte(last))
+			break
+		}
+		page.Songs = append(page.Songs, e.song)
+		last = sorted[i].key
+	}
+	return page, nil
+}
+
+func (e *songEntry) matches(words []string, language, genre string, minYear, maxYear int) bool {
+	switch {
+	case language != "" && fold(e.song.Language) != language:
+		return false
+	case genre != "" && fold(e.song.Genre) != genre:
+		return false
+	case minYear > 0 && e.song.Year < minYear:
+		return false
+	case maxYear > 0 && e.song.Year > maxYear:
+		return false
+	}
+	for _, w := range words {
+		if !strings.Contains(e.text, w) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseSongFilter(r *http.Request) (model.SongFilter, error) {
+	f := model.SongFilter{
+		Query:    r.FormValue("q"),
+		Language: r.FormValue("language"),
+		Genre:    r.FormValue("genre"),
+		Cursor:   r.FormValue("cursor"),
+	}
+	var err error
+
+	for _, p := range []struct {
+		name string
+		v    *int
+		max  int
+	}{
+		{"min_year", &f.MinYear, 0},
+		{"max_year", &f.MaxYear, 0},
+		{"limit", &f.Limit, maxSongLimit},
+	} {
+		s := r.FormValue(p.name)
+		if s == "" {
+			continue
+		}
+		*p.v, err = strconv.Atoi(s)
+		if err != nil || *p.v < 0 || (p.max > 0 && *p.v > p.max) {
+			return f, fmt.Errorf("invalid %s: %s", p.name, s)
+		}
+	}
+
+	switch by := model.SongSort(r.FormValue("sort")); by {
+	case "", model.SortByArtist, model.SortByTitle, model.SortByYear:
+		f.Sort = by
+	default:
+		return f, fmt.Errorf("invalid sort: %s", by)
+	}
+
+	return f, nil
+}
+
+// fold lowercases s and removes accents, so that "Beyoncé" matches "beyonce".
+func fold(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		r = unicode.ToLower(r)
+		if base, ok := foldTable[r]; ok {
+			b.WriteString(base)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var foldTable = func() map[rune]string {
+	table := make(map[rune]string)
+	for base, accented := range map[string]string{
+		"a":  "àáâãäåāăą",
+		"ae": "æ",
+		"c":  "çćĉċč",
+		"d":  "ďđð",
+		"e":  "èéêëēĕėęě",
+		"g":  "ĝğġģ",
+		"h":  "ĥħ",
+		"i":  "ìíîïĩīĭįı",
+		"j":  "ĵ",
+		"k":  "ķ",
+		"l":  "ĺļľŀł",
+		"n":  "ñńņňŉ",
+		"o":  "òóôõöøōŏő",
+		"oe": "œ",
+		"r":  "ŕŗř",
+		"s":  "śŝşš",
+		"ss": "ß",
+		"t":  "ţťŧ",
+		"th": "þ",
+		"u":  "ùúûüũūŭůűų",
+		"w":  "ŵ",
+		"y":  "ýÿŷ",
+		"z":  "źżž",
+	} {
+		for _, r := range accented {
+			table[r] = base
+		}
+	}
+	return table
+}()
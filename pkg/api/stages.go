@@ -0,0 +1,97 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Patagonicus/usdx-queue/pkg/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/backend"
+	"github.com/Patagonicus/usdx-queue/pkg/httperr"
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	httpauth "github.com/Patagonicus/usdx-queue/pkg/middleware/auth"
+	"github.com/Patagonicus/usdx-queue/pkg/middleware/httpjson"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+	"github.com/gorilla/mux"
+)
+
+type stagesAPI struct {
+	back *backend.Backend
+	l    log.Logger
+}
+
+func NewStages(l log.Logger, a auth.Authenticator, back *backend.Backend, router router) {
+	requireList := httpauth.Require(l, a, auth.PermListStages)
+	requireCreate := httpauth.Require(l, a, auth.PermCreateStage)
+
+	s := stagesAPI{
+		back: back,
+		l:    l,
+	}
+
+	router.Handle("", requireList(httperr.HandlerFunc(s.List))).Methods("GET")
+	router.Handle("", requireCreate(httperr.HandlerFunc(s.Create))).Methods("POST")
+}
+
+func (s stagesAPI) List(w http.ResponseWriter, r *http.Request) error {
+	jw, _ := httpjson.Wrap(w, r)
+
+	stages, err := s.back.GetStages()
+	if err != nil {
+		return err
+	}
+
+	return jw.Encode(stages)
+}
+
+func (s stagesAPI) Create(w http.ResponseWriter, r *http.Request) error {
+	jw, jr := httpjson.Wrap(w, r)
+
+	var request struct {
+		Name string `json:"name"`
+	}
+	err := jr.Decode(&request)
+	if err != nil {
+		return httperr.WithCode(err, http.StatusBadRequest)
+	}
+
+	stage, err := s.back.CreateStage(request.Name)
+	switch err.(type) {
+	case nil:
+	case backend.ErrInvalidStageName:
+		return httperr.WithCode(err, http.StatusBadRequest)
+	case backend.ErrStageExists:
+		return httperr.WithCode(err, http.StatusConflict)
+	default:
+		return err
+	}
+
+	jw.Header().Set("Location", stage.Name)
+	jw.WriteHeader(http.StatusCreated)
+	return jw.Encode(stage)
+}
+
+// stageOf returns the stage a request is for: the one in the path or else the
+// one the client is bound to. An empty result leaves the choice to the
+// backend. Clients bound to a stage cannot access other stages.
+func stageOf(r *http.Request) (string, error) {
+	stage := mux.Vars(r)["stage"]
+	client, _ := httpauth.GetClient(r.Context())
+	bound := client.GetStage()
+
+	switch {
+	case bound == "":
+		return stage, nil
+	case stage == "":
+		return bound, nil
+	case stage != bound:
+		return "", httperr.WithCode(fmt.Errorf("client is bound to stage %s", bound), http.StatusForbidden)
+	default:
+		return stage, nil
+	}
+}
+
+// inStage tells whether an event concerns stage. Events that do not belong to
+// any stage concern all of them.
+func inStage(e model.Event, stage string) bool {
+	return stage == "" || e.Stage == "" || e.Stage == stage
+}
@@ -33,7 +33,15 @@ func NewState(l log.Logger, a auth.Authenticator, back *backend.Backend, router
 func (s stateAPI) List(w http.ResponseWriter, r *http.Request) error {
 	jw, _ := httpjson.Wrap(w, r)
 
-	state, err := s.back.GetState()
+	stage, err := stageOf(r)
+	if err != nil {
+		return err
+	}
+
+	state, err := s.back.GetState(stage)
+	if _, ok := err.(backend.ErrStageDoesNotExist); ok {
+		return httperr.WithCode(err, http.StatusNotFound)
+	}
 	if err != nil {
 		s.l.Warn("error getting state",
 			log.Error(err),
@@ -48,16 +56,24 @@ func (s stateAPI) List(w http.ResponseWriter, r *http.Request) error {
 func (s stateAPI) Set(w http.ResponseWriter, r *http.Request) error {
 	jw, jr := httpjson.Wrap(w, r)
 
+	stage, err := stageOf(r)
+	if err != nil {
+		return err
+	}
+
 	var state model.State
 	s.l.Debug("decoding state")
-	err := jr.Decode(&state)
+	err = jr.Decode(&state)
 	if err != nil {
 		s.l.Debug("failed")
 		return httperr.WithCode(err, http.StatusBadRequest)
 	}
 
 	s.l.Debug("setting state")
-	err = s.back.UpdateState(state)
+	err = s.back.UpdateState(stage, state)
+	if _, ok := err.(backend.ErrStageDoesNotExist); ok {
+		return httperr.WithCode(err, http.StatusNotFound)
+	}
 	if err != nil {
 		s.l.Debug("failed")
 		return err
@@ -1,9 +1,14 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/Patagonicus/usdx-queue/pkg/auth"
 	"github.com/Patagonicus/usdx-queue/pkg/backend"
@@ -16,26 +21,36 @@ import (
 )
 
 type tickets struct {
-	back *backend.Backend
-	l    log.Logger
+	back  *backend.Backend
+	songs SongIndex
+	l     log.Logger
 }
 
-func NewTickets(l log.Logger, a auth.Authenticator, back *backend.Backend, router router) {
+func NewTickets(l log.Logger, a auth.Authenticator, back *backend.Backend, songs SongIndex, router router) {
 	requireList := httpauth.Require(l, a, auth.PermListTickets)
 	requireCreate := httpauth.Require(l, a, auth.PermCreateTicket)
+	requireVerify := httpauth.Require(l, a, auth.PermVerifyPIN)
+	requireUnlock := httpauth.Require(l, a, auth.PermUnlockTicket)
 
 	t := tickets{
-		back: back,
-		l:    l,
+		back:  back,
+		songs: songs,
+		l:     l,
 	}
 
 	router.Handle("/", requireList(httperr.HandlerFunc(t.List))).Methods("GET")
 	router.Handle("/{id}", requireList(httperr.HandlerFunc(t.Get))).Methods("GET")
 	router.Handle("/{id}", httpauth.RequireOne(l, a,
-		httpauth.New(httperr.HandlerFunc(t.SetNames), auth.PermSetNames),
-		httpauth.New(httperr.HandlerFunc(t.SetNamesWithPIN), auth.PermSetNamesWithPIN),
+		httpauth.New(httperr.HandlerFunc(t.Update), auth.PermSetNames),
+		httpauth.New(httperr.HandlerFunc(t.UpdateWithPIN), auth.PermSetNamesWithPIN),
 	)).Methods("PATCH")
+	router.Handle("/{id}", httpauth.RequireOne(l, a,
+		httpauth.New(httperr.HandlerFunc(t.Cancel), auth.PermCancelTicket),
+		httpauth.New(httperr.HandlerFunc(t.CancelWithPIN), auth.PermCancelTicketWithPIN),
+	)).Methods("DELETE")
 	router.Handle("/", requireCreate(httperr.HandlerFunc(t.Create))).Methods("POST")
+	router.Handle("/{id}/actions/verify", requireVerify(httperr.HandlerFunc(t.Verify))).Methods("POST")
+	router.Handle("/{id}/actions/unlock", requireUnlock(httperr.HandlerFunc(t.Unlock))).Methods("POST")
 }
 
 func (t tickets) List(w http.ResponseWriter, r *http.Request) error {
@@ -72,28 +87,103 @@ func (t tickets) Get(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
+	jw.Header().Set("ETag", etag(ticket.Version))
 	return jw.Encode(ticket)
 }
 
+// Create creates a ticket. The body may name the stage, otherwise the ticket
+// is added to the stage the client is bound to or to the shortest queue. It
+// may also contain a reservation, which requires PermReserveTicket.
 func (t tickets) Create(w http.ResponseWriter, r *http.Request) error {
 	jw, _ := httpjson.Wrap(w, r)
 
-	ticket, pin, err := t.back.CreateTicket()
+	var request struct {
+		Stage string `json:"stage"`
+		model.Reservation
+	}
+	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		return err
 	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		err = json.Unmarshal(data, &request)
+		if err != nil {
+			return httperr.WithCode(err, http.StatusBadRequest)
+		}
+	}
+
+	stage, err := stageOf(r)
+	if err != nil {
+		return err
+	}
+	if stage == "" {
+		stage = request.Stage
+	} else if request.Stage != "" && request.Stage != stage {
+		return httperr.WithCode(fmt.Errorf("client is bound to stage %s", stage), http.StatusForbidden)
+	}
+
+	if request.Reservation != (model.Reservation{}) {
+		client, _ := httpauth.GetClient(r.Context())
+		if !auth.PermReserveTicket.HasPermission(client) {
+			return httperr.WithCode(errors.New("client may not reserve tickets"), http.StatusForbidden)
+		}
+	}
+
+	ticket, pin, err := t.back.CreateReservedTicket(stage, request.Reservation)
+	switch err.(type) {
+	case nil:
+	case backend.ErrStageDoesNotExist:
+		return httperr.WithCode(err, http.StatusNotFound)
+	case backend.ErrInvalidReservation:
+		return httperr.WithCode(err, http.StatusBadRequest)
+	default:
+		return err
+	}
 
 	jw.Header().Set("Location", string(ticket.ID))
 	jw.WriteHeader(http.StatusCreated)
 	jw.Encode(struct {
-		PIN model.PIN `json:"pin"`
+		PIN   model.PIN `json:"pin"`
+		Stage string    `json:"stage"`
 	}{
 		pin,
+		ticket.Stage,
 	})
 	return nil
 }
 
-func (t tickets) SetNamesWithPIN(w http.ResponseWriter, r *http.Request) error {
+// Verify checks the PIN of a ticket, so that other services can act on behalf
+// of its holder.
+func (t tickets) Verify(w http.ResponseWriter, r *http.Request) error {
+	jw, jr := httpjson.Wrap(w, r)
+
+	var request struct {
+		PIN model.PIN `json:"pin"`
+	}
+	err := jr.Decode(&request)
+	if err != nil {
+		return httperr.WithCode(err, http.StatusBadRequest)
+	}
+
+	err = t.back.CheckPIN(model.ID(mux.Vars(r)["id"]), request.PIN, source(r))
+	switch err.(type) {
+	case backend.ErrTicketDoesNotExist:
+		return httperr.WithCode(err, http.StatusNotFound)
+	case backend.ErrLocked:
+		return locked(w, err)
+	}
+	if err != nil && err != backend.ErrUnauthorized {
+		return err
+	}
+
+	return jw.Encode(struct {
+		Success bool `json:"success"`
+	}{
+		err == nil,
+	})
+}
+
+func (t tickets) UpdateWithPIN(w http.ResponseWriter, r *http.Request) error {
 	jw, jr := httpjson.Wrap(w, r)
 
 	idS, ok := mux.Vars(r)["id"]
@@ -103,24 +193,44 @@ func (t tickets) SetNamesWithPIN(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	var request struct {
-		Names []string  `json:"names"`
-		PIN   model.PIN `json:"pin"`
+		Names   []string       `json:"names"`
+		Song    *string        `json:"song"`
+		PIN     model.PIN      `json:"pin"`
+		Version *model.Version `json:"version"`
 	}
 	err := jr.Decode(&request)
 	if err != nil {
 		return httperr.WithCode(err, http.StatusBadRequest)
 	}
 
-	request.Names = trimEmptyRight(request.Names)
+	u, err := t.ticketUpdate(request.Names, request.Song)
+	if err != nil {
+		return err
+	}
+
+	v, err := expectedVersion(r, request.Version)
+	if err != nil {
+		return err
+	}
 
 	var success bool
-	err = t.back.SetNamesWithPIN(model.ID(idS), request.Names, request.PIN)
+	err = t.back.UpdateTicketWithPIN(model.ID(idS), u, request.PIN, source(r), v)
+	switch err.(type) {
+	case backend.ErrVersionConflict:
+		return conflict(w, err)
+	case backend.ErrLocked:
+		return locked(w, err)
+	case backend.ErrTooManyTickets:
+		return httperr.WithCode(err, http.StatusUnprocessableEntity)
+	case backend.ErrTicketCancelled:
+		return httperr.WithCode(err, http.StatusGone)
+	}
 	switch err {
 	case nil:
-		t.l.Debug("set names",
+		t.l.Debug("updated ticket",
 			log.String("id", idS),
 			log.String("pin", string(request.PIN)),
-			log.Strings("names", request.Names),
+			log.Strings("names", u.Names),
 		)
 		success = true
 	case backend.ErrUnauthorized:
@@ -143,9 +253,9 @@ func (t tickets) SetNamesWithPIN(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-func (t tickets) SetNames(w http.ResponseWriter, r *http.Request) error {
+func (t tickets) Update(w http.ResponseWriter, r *http.Request) error {
 	jw, jr := httpjson.Wrap(w, r)
-	t.l.Debug("running SetNames")
+	t.l.Debug("running Update")
 
 	idS, ok := mux.Vars(r)["id"]
 	if !ok {
@@ -154,23 +264,146 @@ func (t tickets) SetNames(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	var request struct {
-		Names []string `json:"names"`
+		Names   []string       `json:"names"`
+		Song    *string        `json:"song"`
+		Version *model.Version `json:"version"`
 	}
 	err := jr.Decode(&request)
 	if err != nil {
 		return httperr.WithCode(err, http.StatusBadRequest)
 	}
-	request.Names = trimEmptyRight(request.Names)
 
-	err = t.back.SetNames(model.ID(idS), request.Names)
+	u, err := t.ticketUpdate(request.Names, request.Song)
 	if err != nil {
 		return err
 	}
 
+	v, err := expectedVersion(r, request.Version)
+	if err != nil {
+		return err
+	}
+
+	err = t.back.UpdateTicket(model.ID(idS), u, v)
+	switch err.(type) {
+	case nil:
+	case backend.ErrTicketDoesNotExist:
+		return httperr.WithCode(err, http.StatusNotFound)
+	case backend.ErrVersionConflict:
+		return conflict(w, err)
+	case backend.ErrTooManyTickets:
+		return httperr.WithCode(err, http.StatusUnprocessableEntity)
+	case backend.ErrTicketCancelled:
+		return httperr.WithCode(err, http.StatusGone)
+	default:
+		return err
+	}
+
 	jw.WriteHeader(http.StatusNoContent)
 	return nil
 }
 
+// CancelWithPIN cancels a ticket on behalf of its holder. Like
+// UpdateWithPIN, it reports a wrong PIN in the body.
+func (t tickets) CancelWithPIN(w http.ResponseWriter, r *http.Request) error {
+	jw, jr := httpjson.Wrap(w, r)
+
+	var request struct {
+		PIN     model.PIN      `json:"pin"`
+		Version *model.Version `json:"version"`
+	}
+	err := jr.Decode(&request)
+	if err != nil {
+		return httperr.WithCode(err, http.StatusBadRequest)
+	}
+
+	v, err := expectedVersion(r, request.Version)
+	if err != nil {
+		return err
+	}
+
+	err = t.back.CancelTicketWithPIN(model.ID(mux.Vars(r)["id"]), request.PIN, source(r), v)
+	if err == backend.ErrUnauthorized {
+		t.l.Debug("unauthorized")
+	} else if err != nil {
+		return cancelError(w, err)
+	}
+
+	return jw.Encode(struct {
+		Success bool `json:"success"`
+	}{
+		err == nil,
+	})
+}
+
+func (t tickets) Cancel(w http.ResponseWriter, r *http.Request) error {
+	v, err := expectedVersion(r, nil)
+	if err != nil {
+		return err
+	}
+
+	err = t.back.CancelTicket(model.ID(mux.Vars(r)["id"]), v)
+	if err != nil {
+		return cancelError(w, err)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func cancelError(w http.ResponseWriter, err error) error {
+	switch err.(type) {
+	case backend.ErrTicketDoesNotExist:
+		return httperr.WithCode(err, http.StatusNotFound)
+	case backend.ErrTicketCancelled:
+		return httperr.WithCode(err, http.StatusGone)
+	case backend.ErrVersionConflict:
+		return conflict(w, err)
+	case backend.ErrLocked:
+		return locked(w, err)
+	}
+	if err == backend.ErrInvalidQueueMovement {
+		return httperr.WithCode(errors.New("ticket has already been sung"), http.StatusConflict)
+	}
+	return err
+}
+
+// Unlock forgets the failed PIN attempts for a ticket.
+func (t tickets) Unlock(w http.ResponseWriter, r *http.Request) error {
+	t.back.Unlock(model.ID(mux.Vars(r)["id"]))
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// source returns where a request with a PIN came from. usdx-web passes on the
+// address of its client in X-Forwarded-For, which is only trusted from
+// clients with PermForwardSource. Like usdx-web, the last entry is used, as
+// earlier ones can be made up by whoever sent the request.
+func source(r *http.Request) string {
+	client, _ := httpauth.GetClient(r.Context())
+	if f := r.Header.Get("X-Forwarded-For"); f != "" && auth.PermForwardSource.HasPermission(client) {
+		parts := strings.Split(f, ",")
+		return strings.TrimSpace(parts[len(parts)-1])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ticketUpdate builds the update for a PATCH request. Names that are not sent
+// are left unchanged, an empty song removes the request.
+func (t tickets) ticketUpdate(names []string, song *string) (model.TicketUpdate, error) {
+	if song != nil && *song != "" && !t.songs.HasSong(*song) {
+		return model.TicketUpdate{}, httperr.WithCode(fmt.Errorf("unknown song: %s", *song), http.StatusBadRequest)
+	}
+
+	return model.TicketUpdate{
+		Names: trimEmptyRight(names),
+		Song:  song,
+	}, nil
+}
+
 func trimEmptyRight(s []string) []string {
 	l := len(s) - 1
 	for l >= 0 && s[l] == "" {
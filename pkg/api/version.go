@@ -0,0 +1,69 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Patagonicus/usdx-queue/pkg/backend"
+	"github.com/Patagonicus/usdx-queue/pkg/httperr"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+func etag(v model.Version) string {
+	return strconv.Quote(strconv.FormatInt(int64(v), 10))
+}
+
+func parseETag(s string) (model.Version, error) {
+	s = strings.TrimSpace(s)
+	if s == "*" {
+		return model.DontCare, nil
+	}
+	s = strings.TrimPrefix(s, "W/")
+	unquoted, err := strconv.Unquote(s)
+	if err == nil {
+		s = unquoted
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || v < 0 {
+		return model.DontCare, fmt.Errorf("invalid version: %s", s)
+	}
+	return model.Version(v), nil
+}
+
+// expectedVersion returns the version a client based its request on. The
+// If-Match header takes precedence over a version sent in the body. If neither
+// is present, the version is not checked.
+func expectedVersion(r *http.Request, body *model.Version) (model.Version, error) {
+	if h := r.Header.Get("If-Match"); h != "" {
+		v, err := parseETag(h)
+		if err != nil {
+			return model.DontCare, httperr.WithCode(err, http.StatusBadRequest)
+		}
+		return v, nil
+	}
+	if body != nil {
+		return *body, nil
+	}
+	return model.DontCare, nil
+}
+
+func conflict(w http.ResponseWriter, err error) error {
+	if e, ok := err.(backend.ErrVersionConflict); ok {
+		w.Header().Set("ETag", etag(e.Current))
+		return httperr.WithCode(err, http.StatusConflict)
+	}
+	return err
+}
+
+// locked sets the Retry-After header for ErrLocked.
+func locked(w http.ResponseWriter, err error) error {
+	if e, ok := err.(backend.ErrLocked); ok {
+		seconds := int64((e.RetryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+		return httperr.WithCode(err, http.StatusTooManyRequests)
+	}
+	return err
+}
@@ -90,6 +90,12 @@ var (
 		name:    "create ticket",
 		allowed: []PermType{TypeAdmin, TypeRegistration},
 	}
+	// PermReserveTicket allows creating tickets that are placed ahead of the
+	// normal order.
+	PermReserveTicket Permission = permission{
+		name:    "reserve ticket",
+		allowed: []PermType{TypeAdmin},
+	}
 	PermSetNamesWithPIN Permission = permission{
 		name:    "set names with PIN",
 		allowed: []PermType{TypeAdmin, TypeWeb},
@@ -98,6 +104,18 @@ var (
 		name:    "set names",
 		allowed: []PermType{TypeAdmin},
 	}
+	PermCancelTicketWithPIN Permission = permission{
+		name:    "cancel ticket with PIN",
+		allowed: []PermType{TypeAdmin, TypeWeb},
+	}
+	PermCancelTicket Permission = permission{
+		name:    "cancel ticket",
+		allowed: []PermType{TypeAdmin},
+	}
+	PermVerifyPIN Permission = permission{
+		name:    "verify PIN",
+		allowed: []PermType{TypeAdmin, TypeWeb},
+	}
 
 	PermListClients Permission = permission{
 		name:    "list clients",
@@ -114,7 +132,7 @@ var (
 
 	PermListQueue Permission = permission{
 		name:    "list queue",
-		allowed: []PermType{TypeAdmin, TypeWeb, TypeBeamer},
+		allowed: []PermType{TypeAdmin, TypeWeb, TypeBeamer, TypeRegistration},
 	}
 	PermAdvanceQueue Permission = permission{
 		name:    "advance queue",
@@ -128,6 +146,34 @@ var (
 		name:    "pause",
 		allowed: []PermType{TypeAdmin, TypeWeb},
 	}
+	PermMoveTicket Permission = permission{
+		name:    "move ticket",
+		allowed: []PermType{TypeAdmin},
+	}
+	PermRemoveTicket Permission = permission{
+		name:    "remove ticket",
+		allowed: []PermType{TypeAdmin},
+	}
+	PermNoShow Permission = permission{
+		name:    "report no-show",
+		allowed: []PermType{TypeAdmin, TypeWeb},
+	}
+	PermDeferTicket Permission = permission{
+		name:    "defer ticket",
+		allowed: []PermType{TypeAdmin},
+	}
+	PermListPendingAdvances Permission = permission{
+		name:    "list pending advances",
+		allowed: []PermType{TypeAdmin, TypeWeb},
+	}
+	PermProposeAdvance Permission = permission{
+		name:    "propose advance",
+		allowed: []PermType{TypeAdmin, TypeAdvancer},
+	}
+	PermResolveAdvance Permission = permission{
+		name:    "resolve advance",
+		allowed: []PermType{TypeAdmin, TypeWeb},
+	}
 
 	PermListState Permission = permission{
 		name:    "list state",
@@ -138,10 +184,65 @@ var (
 		allowed: []PermType{TypeAdmin, TypeAdvancer},
 	}
 
+	PermListEvents Permission = permission{
+		name:    "list events",
+		allowed: []PermType{TypeAdmin, TypeWeb, TypeBeamer},
+	}
+	PermReportAnomaly Permission = permission{
+		name:    "report anomaly",
+		allowed: []PermType{TypeAdmin, TypeAdvancer},
+	}
+
+	PermListStages Permission = permission{
+		name:    "list stages",
+		allowed: []PermType{TypeAdmin, TypeWeb, TypeBeamer, TypeRegistration},
+	}
+	PermCreateStage Permission = permission{
+		name:    "create stage",
+		allowed: []PermType{TypeAdmin},
+	}
+
+	PermListHistory Permission = permission{
+		name:    "list history",
+		allowed: []PermType{TypeAdmin, TypeWeb, TypeBeamer},
+	}
+	PermListNights Permission = permission{
+		name:    "list nights",
+		allowed: []PermType{TypeAdmin},
+	}
+	PermStartNight Permission = permission{
+		name:    "start night",
+		allowed: []PermType{TypeAdmin},
+	}
+	PermChangeNight Permission = permission{
+		name:    "change night",
+		allowed: []PermType{TypeAdmin},
+	}
+	PermListLockouts Permission = permission{
+		name:    "list lockouts",
+		allowed: []PermType{TypeAdmin},
+	}
+	PermUnlockTicket Permission = permission{
+		name:    "unlock ticket",
+		allowed: []PermType{TypeAdmin},
+	}
+	PermForwardSource Permission = permission{
+		name:    "forward source",
+		allowed: []PermType{TypeWeb},
+	}
+	PermListLeaderboard Permission = permission{
+		name:    "list leaderboard",
+		allowed: []PermType{TypeAdmin, TypeWeb, TypeBeamer},
+	}
+
 	PermGetSong Permission = permission{
 		name:    "get song",
 		allowed: []PermType{TypeAdmin, TypeBeamer, TypeWeb},
 	}
+	PermReloadSongs Permission = permission{
+		name:    "reload songs",
+		allowed: []PermType{TypeAdmin},
+	}
 	PermListSong Permission = permission{
 		name:    "list song",
 		allowed: []PermType{TypeAdmin, TypeWeb},
@@ -160,7 +261,9 @@ func New(boltDB *bolt.DB) (Authenticator, error) {
 	}, err
 }
 
-func (a Authenticator) CreateClient(name string, typ PermType) (Client, error) {
+// CreateClient creates a client with a new token. If stage is not empty, the
+// client can only access that stage.
+func (a Authenticator) CreateClient(name string, typ PermType, stage string) (Client, error) {
 	if !typ.IsValid() {
 		return Client{}, fmt.Errorf("invalid permission type: %s", typ)
 	}
@@ -174,6 +277,7 @@ func (a Authenticator) CreateClient(name string, typ PermType) (Client, error) {
 		name:  name,
 		typ:   typ,
 		token: token,
+		stage: stage,
 	}
 
 	dbC := fromClient(c)
@@ -269,6 +373,7 @@ type Client struct {
 	name  string
 	typ   PermType
 	token Token
+	stage string
 }
 
 func (c Client) GetName() string {
@@ -283,6 +388,12 @@ func (c Client) GetToken() Token {
 	return c.token
 }
 
+// GetStage returns the stage the client is bound to, or an empty string if it
+// may access all stages.
+func (c Client) GetStage() string {
+	return c.stage
+}
+
 func (c Client) String() string {
 	return fmt.Sprintf("Client{%v %s %s}", c.name, c.typ, c.token)
 }
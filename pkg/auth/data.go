@@ -22,6 +22,7 @@ type client struct {
 	Name  string
 	Typ   PermType
 	Token token
+	Stage string
 }
 
 func fromClient(c Client) client {
@@ -29,6 +30,7 @@ func fromClient(c Client) client {
 		c.name,
 		c.typ,
 		fromToken(c.token),
+		c.stage,
 	}
 }
 
@@ -37,5 +39,6 @@ func (c client) Client() Client {
 		c.Name,
 		c.Typ,
 		c.Token.Token(),
+		c.Stage,
 	}
 }
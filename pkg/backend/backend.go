@@ -1,24 +1,21 @@
 package backend
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
-	"math/big"
 	"strconv"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/Patagonicus/usdx-queue/pkg/log"
 	"github.com/Patagonicus/usdx-queue/pkg/model"
-	bolt "github.com/coreos/bbolt"
 )
 
 type ErrTicketDoesNotExist struct {
 	ID model.ID
 }
 
-var maxPIN = big.NewInt(10000)
-
 var (
 	ErrUnauthorized         = errors.New("unauthorized")
 	ErrInvalidQueueMovement = errors.New("invalid queue movement")
@@ -28,25 +25,189 @@ func (e ErrTicketDoesNotExist) Error() string {
 	return fmt.Sprintf("ticket %s does not exist", e.ID)
 }
 
-type Backend struct {
-	currentState atomic.Value
-	db           db
-	l            log.Logger
+type ErrTicketNotQueued struct {
+	ID model.ID
+}
+
+func (e ErrTicketNotQueued) Error() string {
+	return fmt.Sprintf("ticket %s is not in the queue", e.ID)
+}
+
+type ErrTicketCancelled struct {
+	ID model.ID
+}
+
+func (e ErrTicketCancelled) Error() string {
+	return fmt.Sprintf("ticket %s has been cancelled", e.ID)
+}
+
+type ErrPendingAdvanceDoesNotExist struct {
+	ID uint64
+}
+
+func (e ErrPendingAdvanceDoesNotExist) Error() string {
+	return fmt.Sprintf("pending advance %d does not exist", e.ID)
+}
+
+type ErrStageDoesNotExist struct {
+	Name string
+}
+
+func (e ErrStageDoesNotExist) Error() string {
+	return fmt.Sprintf("stage %s does not exist", e.Name)
 }
 
-func New(l log.Logger, boltDB *bolt.DB) (*Backend, error) {
-	d := db{boltDB}
+type ErrStageExists struct {
+	Name string
+}
+
+func (e ErrStageExists) Error() string {
+	return fmt.Sprintf("stage %s already exists", e.Name)
+}
+
+// ErrInvalidStageName is returned for stage names that are empty or contain
+// a slash.
+type ErrInvalidStageName struct {
+	Name string
+}
+
+func (e ErrInvalidStageName) Error() string {
+	return fmt.Sprintf("invalid stage name: %q", e.Name)
+}
+
+type ErrInvalidScheduling struct {
+	Policy model.SchedulingPolicy
+}
 
-	err := d.Init()
+func (e ErrInvalidScheduling) Error() string {
+	return fmt.Sprintf("invalid scheduling policy: %q", e.Policy)
+}
+
+// ErrTooManyTickets is returned if a singer already has as many waiting
+// tickets as the scheduling allows.
+type ErrTooManyTickets struct {
+	Name string
+	Max  int
+}
+
+func (e ErrTooManyTickets) Error() string {
+	return fmt.Sprintf("%s already has %d tickets in the queue", e.Name, e.Max)
+}
+
+type ErrInvalidReservation struct {
+	Reservation model.Reservation
+}
+
+func (e ErrInvalidReservation) Error() string {
+	return fmt.Sprintf("invalid reservation: priority %d, slot %d", e.Reservation.Priority, e.Reservation.Slot)
+}
+
+type ErrInvalidPINPolicy struct {
+	Policy PINPolicy
+}
+
+func (e ErrInvalidPINPolicy) Error() string {
+	return fmt.Sprintf("invalid PIN policy: %+v", e.Policy)
+}
+
+// ErrLocked is returned if a PIN cannot be checked because of too many failed
+// attempts for the ticket or from the same source. Source is only set if the
+// source is locked.
+type ErrLocked struct {
+	ID         model.ID
+	Source     string
+	RetryAfter time.Duration
+}
+
+func (e ErrLocked) Error() string {
+	if e.Source != "" {
+		return fmt.Sprintf("%s is locked for %s", e.Source, e.RetryAfter)
+	}
+	return fmt.Sprintf("ticket %s is locked for %s", e.ID, e.RetryAfter)
+}
+
+type ErrNightDoesNotExist struct {
+	ID uint64
+}
+
+func (e ErrNightDoesNotExist) Error() string {
+	return fmt.Sprintf("night %d does not exist", e.ID)
+}
+
+type ErrVersionConflict struct {
+	Expected model.Version
+	Current  model.Version
+}
+
+func (e ErrVersionConflict) Error() string {
+	return fmt.Sprintf("version conflict: expected %s, but current is %s", e.Expected, e.Current)
+}
+
+func checkVersion(expected, current version) error {
+	if expected.Conflict(current) {
+		return ErrVersionConflict{expected, current}
+	}
+	return nil
+}
+
+type Backend struct {
+	stateVersion int64
+	// states holds the current playback state of each stage.
+	states map[string]model.State
+	stateM *sync.RWMutex
+	// updateM is held from the start of a write until its events have been
+	// published, so that subscribers get them in the order of their
+	// versions.
+	updateM  *sync.Mutex
+	store    Store
+	events   *broker
+	attempts *attempts
+	l        log.Logger
+}
+
+func New(l log.Logger, store Store) (*Backend, error) {
+	err := store.Init()
 	if err != nil {
 		return nil, err
 	}
 
 	b := &Backend{
-		db: d,
-		l:  l,
+		states:   make(map[string]model.State),
+		stateM:   new(sync.RWMutex),
+		updateM:  new(sync.Mutex),
+		store:    store,
+		events:   newBroker(),
+		attempts: newAttempts(),
+		l:        l,
 	}
-	b.setState(model.State{})
+
+	err = b.initNight()
+	if err != nil {
+		return nil, err
+	}
+
+	err = store.View(func(t Tx) error {
+		stages, err := t.GetStages()
+		if err != nil {
+			return err
+		}
+
+		for _, stage := range stages {
+			p, err := t.GetPlayback(stage)
+			switch err.(type) {
+			case nil:
+			case errKeyNotFound:
+			default:
+				return err
+			}
+			b.setState(stage, p.State)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return b, nil
 }
 
@@ -54,42 +215,247 @@ func (b *Backend) Close() error {
 	return nil
 }
 
-func (b *Backend) setState(s model.State) {
-	b.currentState.Store(s)
+func (b *Backend) setState(stage string, s model.State) {
+	b.stateM.Lock()
+	defer b.stateM.Unlock()
+	b.states[stage] = s
 }
 
-func (b *Backend) getState() model.State {
-	return b.currentState.Load().(model.State)
+func (b *Backend) getState(stage string) (model.State, bool) {
+	b.stateM.RLock()
+	defer b.stateM.RUnlock()
+	s, ok := b.states[stage]
+	return s, ok
 }
 
-func (b *Backend) UpdateState(s model.State) error {
+func (b *Backend) UpdateState(stage string, s model.State) error {
+	stage = stageName(stage)
 	b.l.Debug("setting state",
+		log.String("stage", stage),
 		log.Any("state", s),
 	)
-	b.setState(s)
+
+	var finished *playedSong
+	b.updateM.Lock()
+	defer b.updateM.Unlock()
+	err := b.store.Update(func(t Tx) error {
+		queue, err := getQueue(t, stage)
+		if err != nil {
+			return err
+		}
+
+		p, err := t.GetPlayback(stage)
+		if _, ok := err.(errKeyNotFound); !ok && err != nil {
+			return err
+		}
+
+		now := time.Now()
+		wasPlaying := p.State.Playback != model.Stopped
+		isPlaying := s.Playback != model.Stopped
+
+		// Songs that are stopped early are not recorded.
+		if wasPlaying && (!isPlaying || s.Source != p.State.Source) && completed(p.State) {
+			song, err := finishSong(t, stage, p, now)
+			if err != nil {
+				return err
+			}
+			finished = &song
+		}
+
+		if isPlaying && (!wasPlaying || s.Source != p.State.Source) {
+			p.Ticket = ""
+			if queue.Pos >= 0 && queue.Pos < len(queue.Queue) {
+				p.Ticket = queue.Queue[queue.Pos]
+			}
+			p.Started = now
+		}
+
+		p.State = s
+		return t.PutPlayback(stage, p)
+	})
+	if err != nil {
+		return err
+	}
+
+	if finished != nil {
+		b.l.Info("song finished",
+			log.Stringer("song", finished.PlayedSong()),
+		)
+	}
+
+	b.setState(stage, s)
+	b.events.Publish(model.Event{
+		Type:    model.EventStateUpdated,
+		Version: model.Version(atomic.AddInt64(&b.stateVersion, 1)),
+		Stage:   stage,
+		State:   &s,
+	})
 	return nil
 }
 
-func (b *Backend) GetState() (model.State, error) {
-	return b.getState(), nil
+// completedAt is the part of a song that has to be played for it to count as
+// completed. The last position reported before a song stops is usually a
+// little before its end.
+const completedAt = 0.9
+
+// completed tells whether s has been played to the end. Songs of unknown
+// length count as completed.
+func completed(s model.State) bool {
+	return s.Length <= 0 || s.Position >= time.Duration(float64(s.Length)*completedAt)
+}
+
+// finishSong records the song described by p as played on stage.
+func finishSong(t Tx, stage string, p playback, now time.Time) (playedSong, error) {
+	song := playedSong{
+		Stage:    stage,
+		Ticket:   p.Ticket.ID(),
+		Source:   p.State.Source,
+		Length:   p.State.Length,
+		Position: p.State.Position,
+		Scores:   p.State.Scores,
+		Started:  p.Started,
+		Finished: now,
+	}
+
+	if p.Ticket != "" {
+		ticket, err := t.GetTicket(p.Ticket)
+		switch err.(type) {
+		case nil:
+			song.Names = ticket.Names
+		case errKeyNotFound:
+		default:
+			return playedSong{}, err
+		}
+	}
+
+	return song, t.AddPlayedSong(song)
+}
+
+func (b *Backend) GetHistory() ([]model.PlayedSong, error) {
+	var songs []playedSong
+	err := b.store.View(func(t Tx) error {
+		var err error
+		songs, err = t.GetPlayedSongs()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.PlayedSong, len(songs))
+	for i, s := range songs {
+		result[i] = s.PlayedSong()
+	}
+	return result, nil
+}
+
+// ReportAnomaly passes an anomaly noticed by a client on to all subscribers.
+func (b *Backend) ReportAnomaly(a model.Anomaly) {
+	b.l.Warn("anomaly reported",
+		log.Stringer("anomaly", a),
+	)
+	b.events.Publish(model.Event{
+		Type:    model.EventAnomaly,
+		Version: model.DontCare,
+		Anomaly: &a,
+	})
+}
+
+// SongsReloaded notifies subscribers that the song library changed.
+func (b *Backend) SongsReloaded() {
+	b.events.Publish(model.Event{
+		Type:    model.EventSongsReloaded,
+		Version: model.DontCare,
+	})
+}
+
+func (b *Backend) Subscribe(lastID uint64) (<-chan model.Event, func()) {
+	return b.events.Subscribe(lastID)
 }
 
-func (b *Backend) CreateTicket() (model.Ticket, model.PIN, error) {
+func (b *Backend) publishQueue(typ model.EventType, q queue) {
+	mq := q.ModelQueue()
+	b.events.Publish(model.Event{
+		Type:    typ,
+		Version: q.Version,
+		Stage:   q.Stage,
+		Queue:   &mq,
+	})
+}
+
+func (b *Backend) publishTicket(typ model.EventType, t ticket) {
+	mt := t.Ticket()
+	b.events.Publish(model.Event{
+		Type:    typ,
+		Version: t.Version,
+		Stage:   mt.Stage,
+		Ticket:  &mt,
+	})
+}
+
+func (b *Backend) GetState(stage string) (model.State, error) {
+	stage = stageName(stage)
+	s, ok := b.getState(stage)
+	if !ok {
+		return model.State{}, ErrStageDoesNotExist{stage}
+	}
+	return s, nil
+}
+
+// CreateTicket creates a ticket and appends it to the queue of stage. If stage
+// is empty, the stage with the fewest waiting tickets is used.
+func (b *Backend) CreateTicket(stage string) (model.Ticket, model.PIN, error) {
+	return b.CreateReservedTicket(stage, model.Reservation{})
+}
+
+// CreateReservedTicket creates a ticket like CreateTicket, but places it in the
+// queue according to r.
+func (b *Backend) CreateReservedTicket(stage string, r model.Reservation) (model.Ticket, model.PIN, error) {
+	if r.Priority < 0 || r.Slot < 0 {
+		return model.Ticket{}, model.PIN(""), ErrInvalidReservation{r}
+	}
+
 	var ticket ticket
-	p, err := rand.Int(rand.Reader, maxPIN)
+	var queue queue
+	pinS, err := b.attempts.generate()
 	if err != nil {
 		return model.Ticket{}, model.PIN(""), err
 	}
-	var pinS = pin(fmt.Sprintf("%04d", p))
 
-	err = b.db.Update(func(t tx) error {
+	now := time.Now()
+	b.updateM.Lock()
+	defer b.updateM.Unlock()
+	err = b.store.Update(func(t Tx) error {
+		var err error
+		if stage == "" {
+			stage, err = shortestStage(t)
+			if err != nil {
+				return err
+			}
+		}
+
+		queue, err = getQueue(t, stage)
+		if err != nil {
+			return err
+		}
+
 		idNum, err := t.NextTicketSequence()
 		if err != nil {
 			return err
 		}
 
 		ticketID := id(strconv.FormatUint(idNum, 10))
+		queue.insert(ticketID, r)
+		queue.Version++
+
 		ticket.ID = ticketID.ID()
+		ticket.Stage = stage
+		ticket.Priority = r.Priority
+		ticket.Slot = r.Slot
+		ticket.setStatus(model.StatusChange{
+			Status: queue.statusAt(queue.IndexOf(ticketID)),
+			Time:   now,
+		})
 
 		err = t.PutTicket(ticket)
 		if err != nil {
@@ -101,24 +467,21 @@ func (b *Backend) CreateTicket() (model.Ticket, model.PIN, error) {
 			return err
 		}
 
-		queue, err := t.GetQueue()
-		if err != nil {
-			return err
-		}
-
-		queue.Queue = append(queue.Queue, ticketID)
-		queue.Version++
-
-		return t.PutQueue(queue)
+		return putQueue(t, &queue, now)
 	})
+	if err != nil {
+		return model.Ticket{}, model.PIN(""), err
+	}
 
-	return ticket.Ticket(), model.PIN(pinS), err
+	b.publishTicket(model.EventTicketCreated, ticket)
+	b.publishQueue(model.EventQueueChanged, queue)
+	return ticket.Ticket(), model.PIN(pinS), nil
 }
 
 func (b *Backend) GetTicket(ticketID model.ID) (model.Ticket, error) {
 	var ticket ticket
 
-	err := b.db.View(func(t tx) error {
+	err := b.store.View(func(t Tx) error {
 		var err error
 		ticket, err = t.GetTicket(id(ticketID))
 		return err
@@ -133,11 +496,23 @@ func (b *Backend) GetTicket(ticketID model.ID) (model.Ticket, error) {
 	return ticket.Ticket(), nil
 }
 
+// CheckPIN returns ErrUnauthorized if p is not the PIN of the ticket. source
+// identifies where the attempt came from, see verifyPIN.
+func (b *Backend) CheckPIN(ticketID model.ID, p model.PIN, source string) error {
+	err := b.store.View(func(t Tx) error {
+		return b.verifyPIN(t, id(ticketID), p, source)
+	})
+	if _, ok := err.(errKeyNotFound); ok {
+		return ErrTicketDoesNotExist{ticketID}
+	}
+	return err
+}
+
 func (b *Backend) GetTickets() (map[model.ID]model.Ticket, error) {
 	result := make(map[model.ID]model.Ticket)
 	var tickets map[id]ticket
 
-	err := b.db.View(func(t tx) error {
+	err := b.store.View(func(t Tx) error {
 		var err error
 		tickets, err = t.GetTickets()
 		return err
@@ -153,53 +528,237 @@ func (b *Backend) GetTickets() (map[model.ID]model.Ticket, error) {
 	return result, nil
 }
 
-func (b *Backend) SetNames(ticketID model.ID, names []string) error {
-	err := b.db.Update(func(t tx) error {
-		ticket, err := t.GetTicket(id(ticketID))
+func (b *Backend) SetNames(ticketID model.ID, names []string, v model.Version) error {
+	return b.UpdateTicket(ticketID, model.TicketUpdate{Names: nonNil(names)}, v)
+}
+
+func (b *Backend) SetNamesWithPIN(ticketID model.ID, names []string, p model.PIN, source string, v model.Version) error {
+	return b.UpdateTicketWithPIN(ticketID, model.TicketUpdate{Names: nonNil(names)}, p, source, v)
+}
+
+func (b *Backend) UpdateTicket(ticketID model.ID, u model.TicketUpdate, v model.Version) error {
+	return b.updateTicket(ticketID, nil, "", u, v)
+}
+
+func (b *Backend) UpdateTicketWithPIN(ticketID model.ID, u model.TicketUpdate, p model.PIN, source string, v model.Version) error {
+	return b.updateTicket(ticketID, &p, source, u, v)
+}
+
+// updateTicket applies u to a ticket. If p is not nil, it has to match the
+// ticket's PIN.
+func (b *Backend) updateTicket(ticketID model.ID, p *model.PIN, source string, u model.TicketUpdate, v model.Version) error {
+	var ticket ticket
+	var queue *queue
+	var rejected error
+	b.updateM.Lock()
+	defer b.updateM.Unlock()
+	err := b.store.Update(func(t Tx) error {
+		var err error
+		ticket, err = t.GetTicket(id(ticketID))
+		if err != nil {
+			return err
+		}
+
+		if p != nil {
+			err = b.verifyPIN(t, id(ticketID), *p, source)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = checkVersion(v, ticket.Version)
+		if err != nil {
+			return err
+		}
+		if ticket.Status == model.StatusCancelled {
+			return ErrTicketCancelled{ticketID}
+		}
+
+		old := ticket
+		if u.Names != nil {
+			ticket.Names = u.Names
+		}
+		if u.Song != nil {
+			ticket.Song = *u.Song
+		}
+		ticket.Version++
+
+		err = t.PutTicket(ticket)
 		if err != nil {
 			return err
 		}
 
-		ticket.Names = names
+		q, err := t.GetQueue(ticket.stage())
+		if err != nil {
+			return err
+		}
+		i := q.IndexOf(id(ticketID))
+		if i < 0 {
+			return nil
+		}
 
-		return t.PutTicket(ticket)
+		// Waiting tickets are placed according to the scheduling once the
+		// names of their singers are known, unless they have a reservation.
+		moved := false
+		if u.Names != nil && i > q.Pos && !q.reserved(id(ticketID)) {
+			to, err := schedule(t, q, i)
+			if _, ok := err.(ErrTooManyTickets); ok && len(old.Names) == 0 {
+				// Without names the ticket would still take a turn, so
+				// it is held until it gets names that are accepted.
+				rejected = err
+				err = t.PutTicket(old)
+				if err != nil {
+					return err
+				}
+				if q.Held[id(ticketID)] {
+					return nil
+				}
+				q.hold(id(ticketID))
+				q.Version++
+				queue = &q
+				return putQueue(t, &q, time.Now())
+			}
+			if err != nil {
+				return err
+			}
+			if to != i {
+				q.Move(i, to)
+				moved = true
+			}
+		}
+		if len(u.Names) > 0 && q.Held[id(ticketID)] {
+			q.unhold(id(ticketID))
+			moved = true
+		}
+		if moved {
+			q.Version++
+		}
+
+		// The queue keeps the requests, so that listing it does not have to
+		// load every ticket. Its version is not changed for them, as the
+		// order stays the same.
+		if u.Song != nil {
+			q.Request(id(ticketID), *u.Song)
+		} else if !moved {
+			return nil
+		}
+		queue = &q
+		return putQueue(t, &q, time.Now())
 	})
 	if _, ok := err.(errKeyNotFound); ok {
 		return ErrTicketDoesNotExist{ticketID}
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	if rejected != nil {
+		if queue != nil {
+			b.publishQueue(model.EventQueueChanged, *queue)
+		}
+		return rejected
+	}
+
+	if u.Song == nil {
+		b.publishTicket(model.EventTicketRenamed, ticket)
+	} else {
+		b.publishTicket(model.EventSongRequested, ticket)
+	}
+	if queue != nil {
+		b.publishQueue(model.EventQueueChanged, *queue)
+	}
+	return nil
+}
+
+func (b *Backend) CancelTicket(ticketID model.ID, v model.Version) error {
+	return b.cancelTicket(ticketID, nil, "", v)
+}
+
+func (b *Backend) CancelTicketWithPIN(ticketID model.ID, p model.PIN, source string, v model.Version) error {
+	return b.cancelTicket(ticketID, &p, source, v)
 }
 
-func (b *Backend) SetNamesWithPIN(ticketID model.ID, names []string, p model.PIN) error {
-	err := b.db.Update(func(t tx) error {
-		ticket, err := t.GetTicket(id(ticketID))
+// cancelTicket gives up a ticket. It is removed from the queue, so that the
+// queue continues with the next ticket, but kept in the list of tickets.
+// Tickets that have already been sung cannot be cancelled. If p is not nil, it
+// has to match the ticket's PIN.
+func (b *Backend) cancelTicket(ticketID model.ID, p *model.PIN, source string, v model.Version) error {
+	var ticket ticket
+	var queue *queue
+	b.updateM.Lock()
+	defer b.updateM.Unlock()
+	err := b.store.Update(func(t Tx) error {
+		var err error
+		ticket, err = t.GetTicket(id(ticketID))
+		if err != nil {
+			return err
+		}
+
+		if p != nil {
+			err = b.verifyPIN(t, id(ticketID), *p, source)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = checkVersion(v, ticket.Version)
 		if err != nil {
 			return err
 		}
+		if ticket.Status == model.StatusCancelled {
+			return ErrTicketCancelled{ticketID}
+		}
 
-		storedPIN, err := t.GetPIN(id(ticketID))
+		q, err := t.GetQueue(ticket.stage())
 		if err != nil {
 			return err
 		}
+		i := q.IndexOf(id(ticketID))
+		if i >= 0 && i < q.Pos {
+			return ErrInvalidQueueMovement
+		}
 
-		if storedPIN != p {
-			return ErrUnauthorized
+		now := time.Now()
+		ticket.setStatus(model.StatusChange{Status: model.StatusCancelled, Time: now})
+		ticket.Version++
+		err = t.PutTicket(ticket)
+		if err != nil {
+			return err
 		}
 
-		ticket.Names = names
-		return t.PutTicket(ticket)
+		if i < 0 {
+			return nil
+		}
+		q.Remove(i)
+		q.Version++
+		queue = &q
+		return putQueue(t, &q, now)
 	})
 	if _, ok := err.(errKeyNotFound); ok {
 		return ErrTicketDoesNotExist{ticketID}
 	}
-	return err
+	if err != nil {
+		return err
+	}
+
+	b.publishTicket(model.EventTicketCancelled, ticket)
+	if queue != nil {
+		b.publishQueue(model.EventQueueChanged, *queue)
+	}
+	return nil
+}
+
+func nonNil(names []string) []string {
+	if names == nil {
+		return []string{}
+	}
+	return names
 }
 
-func (b *Backend) GetQueue() (model.Queue, error) {
+func (b *Backend) GetQueue(stage string) (model.Queue, error) {
 	var queue queue
-	err := b.db.View(func(t tx) error {
+	err := b.store.View(func(t Tx) error {
 		var err error
-		queue, err = t.GetQueue()
+		queue, err = getQueue(t, stage)
 		return err
 	})
 	if err != nil {
@@ -208,46 +767,113 @@ func (b *Backend) GetQueue() (model.Queue, error) {
 	return queue.ModelQueue(), err
 }
 
-func (b *Backend) Advance() error {
-	return b.db.Update(func(t tx) error {
-		queue, err := t.GetQueue()
-		if err != nil {
-			return err
+func (b *Backend) Advance(stage string, v model.Version) error {
+	return b.updateQueue(stage, v, model.EventQueueChanged, func(q *queue) error {
+		if q.Paused || q.Pos >= len(q.Queue)-1 {
+			return ErrInvalidQueueMovement
 		}
 
-		if queue.Paused || queue.Pos >= len(queue.Queue)-1 {
+		q.Pos++
+		return nil
+	})
+}
+
+func (b *Backend) GoBack(stage string, v model.Version) error {
+	return b.updateQueue(stage, v, model.EventQueueChanged, func(q *queue) error {
+		if q.Paused || q.Pos <= 0 {
 			return ErrInvalidQueueMovement
 		}
 
-		queue.Pos++
-		return t.PutQueue(queue)
+		q.Pos--
+		return nil
 	})
 }
 
-func (b *Backend) GoBack() error {
-	return b.db.Update(func(t tx) error {
-		queue, err := t.GetQueue()
-		if err != nil {
-			return err
+func (b *Backend) Pause(stage string, v model.Version) error {
+	return b.updateQueue(stage, v, model.EventPauseToggled, func(q *queue) error {
+		q.Paused = !q.Paused
+		return nil
+	})
+}
+
+func (b *Backend) MoveTicket(stage string, ticketID model.ID, position int, v model.Version) error {
+	return b.updateQueued(stage, ticketID, v, func(q *queue, i int) error {
+		if position < q.Pos || position >= len(q.Queue) {
+			return ErrInvalidQueueMovement
 		}
 
-		if queue.Paused || queue.Pos <= 0 {
+		q.release(q.Queue[i])
+		q.Move(i, position)
+		return nil
+	})
+}
+
+func (b *Backend) RemoveTicket(stage string, ticketID model.ID, v model.Version) error {
+	return b.updateQueued(stage, ticketID, v, func(q *queue, i int) error {
+		q.Remove(i)
+		return nil
+	})
+}
+
+func (b *Backend) Defer(stage string, ticketID model.ID, n int, v model.Version) error {
+	return b.updateQueued(stage, ticketID, v, func(q *queue, i int) error {
+		if n <= 0 {
 			return ErrInvalidQueueMovement
 		}
 
-		queue.Pos--
-		return t.PutQueue(queue)
+		position := i + n
+		if position >= len(q.Queue) {
+			position = len(q.Queue) - 1
+		}
+
+		q.release(q.Queue[i])
+		q.Move(i, position)
+		return nil
 	})
 }
 
-func (b *Backend) Pause() error {
-	return b.db.Update(func(t tx) error {
-		queue, err := t.GetQueue()
+func (b *Backend) updateQueued(stage string, ticketID model.ID, v model.Version, f func(q *queue, i int) error) error {
+	return b.updateQueue(stage, v, model.EventQueueChanged, func(q *queue) error {
+		i := q.IndexOf(id(ticketID))
+		if i < 0 {
+			return ErrTicketNotQueued{ticketID}
+		}
+		if i < q.Pos {
+			return ErrInvalidQueueMovement
+		}
+
+		return f(q, i)
+	})
+}
+
+func (b *Backend) updateQueue(stage string, v model.Version, typ model.EventType, f func(q *queue) error) error {
+	var queue queue
+	b.updateM.Lock()
+	defer b.updateM.Unlock()
+	err := b.store.Update(func(t Tx) error {
+		var err error
+		queue, err = getQueue(t, stage)
 		if err != nil {
 			return err
 		}
 
-		queue.Paused = !queue.Paused
-		return t.PutQueue(queue)
+		err = checkVersion(v, queue.Version)
+		if err != nil {
+			return err
+		}
+
+		err = f(&queue)
+		if err != nil {
+			return err
+		}
+
+		queue.Version++
+		return putQueue(t, &queue, time.Now())
 	})
+	if err != nil {
+		return err
+	}
+
+	b.publishQueue(typ, queue)
+	return nil
 }
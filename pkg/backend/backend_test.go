@@ -1,12 +1,16 @@
 package backend_test
 
 import (
+	"bytes"
+	"encoding/gob"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,7 +24,7 @@ func TestGetTicket(t *testing.T) {
 	b, teardown := setupDB(t)
 	defer teardown()
 
-	ticket, _, err := b.CreateTicket()
+	ticket, _, err := b.CreateTicket(model.DefaultStage)
 	if err != nil {
 		t.Fatalf("failed to create ticket: %s", err)
 	}
@@ -34,45 +38,1668 @@ func TestGetTicket(t *testing.T) {
 	}
 }
 
+func TestCheckPIN(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ticket, pin, err := b.CreateTicket(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to create ticket: %s", err)
+	}
+
+	err = b.CheckPIN(ticket.ID, pin, "")
+	if err != nil {
+		t.Fatalf("expected PIN %s to be valid, but got %s", pin, err)
+	}
+
+	err = b.CheckPIN(ticket.ID, model.PIN("wrong"), "")
+	if err != backend.ErrUnauthorized {
+		t.Fatalf("expected %s, but got %v", backend.ErrUnauthorized, err)
+	}
+
+	err = b.CheckPIN(model.ID("999"), pin, "")
+	if _, ok := err.(backend.ErrTicketDoesNotExist); !ok {
+		t.Fatalf("expected ErrTicketDoesNotExist, but got %v", err)
+	}
+}
+
+func TestPINLockout(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	err := b.SetPINPolicy(backend.PINPolicy{Length: 6, Alphabet: "aa", FreeAttempts: 2, Lockout: time.Minute, MaxLockout: time.Hour})
+	if _, ok := err.(backend.ErrInvalidPINPolicy); !ok {
+		t.Fatalf("expected ErrInvalidPINPolicy, but got %v", err)
+	}
+	err = b.SetPINPolicy(backend.PINPolicy{Length: 6, Alphabet: "ab", FreeAttempts: 2, Lockout: time.Minute, MaxLockout: time.Hour})
+	if err != nil {
+		t.Fatalf("failed to set PIN policy: %s", err)
+	}
+
+	ticket, pin, err := b.CreateTicket(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to create ticket: %s", err)
+	}
+	if len(pin) != 6 || strings.Trim(string(pin), "ab") != "" {
+		t.Fatalf("expected a PIN of 6 a or b, but got %s", pin)
+	}
+	other, otherPIN, err := b.CreateTicket(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to create ticket: %s", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		err = b.CheckPIN(ticket.ID, model.PIN("wrong"), "10.0.0.1")
+		if err != backend.ErrUnauthorized {
+			t.Fatalf("expected %s, but got %v", backend.ErrUnauthorized, err)
+		}
+	}
+
+	// The ticket is locked for everyone, even with the right PIN.
+	err = b.CheckPIN(ticket.ID, pin, "10.0.0.2")
+	if e, ok := err.(backend.ErrLocked); !ok || e.Source != "" || e.RetryAfter <= 0 || e.RetryAfter > time.Minute {
+		t.Fatalf("expected ErrLocked for the ticket for a minute, but got %v", err)
+	}
+	// The source is locked for all tickets.
+	err = b.CheckPIN(other.ID, otherPIN, "10.0.0.1")
+	if e, ok := err.(backend.ErrLocked); !ok || e.Source != "10.0.0.1" {
+		t.Fatalf("expected ErrLocked for the source, but got %v", err)
+	}
+	err = b.CheckPIN(other.ID, otherPIN, "10.0.0.2")
+	if err != nil {
+		t.Fatalf("expected PIN %s to be valid, but got %s", otherPIN, err)
+	}
+
+	lockouts := b.Lockouts()
+	if len(lockouts) != 2 {
+		t.Fatalf("expected the ticket and the source to be locked, but got %+v", lockouts)
+	}
+	for _, l := range lockouts {
+		if (l.Ticket != ticket.ID && l.Source != "10.0.0.1") || l.Failures != 3 {
+			t.Fatalf("unexpected lockout %+v", l)
+		}
+	}
+
+	b.Unlock(ticket.ID)
+	err = b.CheckPIN(ticket.ID, pin, "10.0.0.2")
+	if err != nil {
+		t.Fatalf("expected PIN %s to be valid after unlocking, but got %s", pin, err)
+	}
+}
+
+func TestPINLockoutNewNight(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ticket, _, err := b.CreateTicket(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to create ticket: %s", err)
+	}
+	for i := 0; i <= backend.DefaultPINPolicy.FreeAttempts; i++ {
+		err = b.CheckPIN(ticket.ID, model.PIN("wrong"), "")
+		if err != backend.ErrUnauthorized {
+			t.Fatalf("expected %s, but got %v", backend.ErrUnauthorized, err)
+		}
+	}
+	if len(b.Lockouts()) != 1 {
+		t.Fatalf("expected the ticket to be locked, but got %+v", b.Lockouts())
+	}
+
+	_, err = b.StartNight("")
+	if err != nil {
+		t.Fatalf("failed to start night: %s", err)
+	}
+
+	// Ids start over, so the new ticket gets the id of the locked one.
+	next, pin, err := b.CreateTicket(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to create ticket: %s", err)
+	}
+	if next.ID != ticket.ID {
+		t.Fatalf("expected id %s to be reused, but got %s", ticket.ID, next.ID)
+	}
+	err = b.CheckPIN(next.ID, pin, "")
+	if err != nil {
+		t.Fatalf("expected PIN %s to be valid, but got %s", pin, err)
+	}
+}
+
+func TestPINLockoutConcurrent(t *testing.T) {
+	// Unlike the memory store, bolt runs reads in parallel.
+	db, logger, teardown := setupBolt(t)
+	defer teardown()
+	b, err := backend.New(logger, backend.NewBoltStore(db))
+	if err != nil {
+		t.Fatalf("failed to create backend: %s", err)
+	}
+
+	err = b.SetPINPolicy(backend.PINPolicy{Length: 4, Alphabet: "0123456789", FreeAttempts: 2, Lockout: time.Minute, MaxLockout: time.Hour})
+	if err != nil {
+		t.Fatalf("failed to set PIN policy: %s", err)
+	}
+	ticket, _, err := b.CreateTicket(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to create ticket: %s", err)
+	}
+
+	errs := make(chan error, 50)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs <- b.CheckPIN(ticket.ID, model.PIN("wrong"), fmt.Sprintf("10.0.0.%d", i))
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	// Only the free attempts and the one that locks the ticket are checked.
+	checked := 0
+	for err := range errs {
+		switch err.(type) {
+		case backend.ErrLocked:
+		default:
+			if err != backend.ErrUnauthorized {
+				t.Fatalf("expected %s or ErrLocked, but got %v", backend.ErrUnauthorized, err)
+			}
+			checked++
+		}
+	}
+	if checked != 3 {
+		t.Fatalf("expected 3 PINs to be checked, but got %d", checked)
+	}
+}
+
+func TestCancelTicket(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ids := createTickets(t, b, 3)
+	ticket, pin, err := b.CreateTicket(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to create ticket: %s", err)
+	}
+	ids = append(ids, ticket.ID)
+
+	err = b.CancelTicketWithPIN(ids[3], model.PIN("wrong"), "", model.DontCare)
+	if err != backend.ErrUnauthorized {
+		t.Fatalf("expected %s, but got %v", backend.ErrUnauthorized, err)
+	}
+	err = b.CancelTicketWithPIN(ids[3], pin, "", model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to cancel ticket: %s", err)
+	}
+	expectQueue(t, b, ids[:3])
+
+	ticket, err = b.GetTicket(ids[3])
+	if err != nil {
+		t.Fatalf("failed to get ticket: %s", err)
+	}
+	if !ticket.Cancelled() {
+		t.Fatalf("expected ticket %s to be cancelled", ticket.ID)
+	}
+	err = b.CancelTicket(ids[3], model.DontCare)
+	if _, ok := err.(backend.ErrTicketCancelled); !ok {
+		t.Fatalf("expected ErrTicketCancelled, but got %v", err)
+	}
+	err = b.SetNames(ids[3], []string{"Anna"}, model.DontCare)
+	if _, ok := err.(backend.ErrTicketCancelled); !ok {
+		t.Fatalf("expected ErrTicketCancelled, but got %v", err)
+	}
+
+	// Cancelling the current ticket skips to the next one.
+	err = b.Advance(model.DefaultStage, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to advance: %s", err)
+	}
+	err = b.CancelTicket(ids[1], model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to cancel ticket: %s", err)
+	}
+	queue, err := b.GetQueue(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get queue: %s", err)
+	}
+	if queue.Queue[queue.Position] != ids[2] {
+		t.Fatalf("expected %s to be current, but got %s", ids[2], queue.Queue[queue.Position])
+	}
+
+	err = b.CancelTicket(ids[0], model.DontCare)
+	if err != backend.ErrInvalidQueueMovement {
+		t.Fatalf("expected %s, but got %v", backend.ErrInvalidQueueMovement, err)
+	}
+}
+
+func TestTicketStatus(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ids := createTickets(t, b, 5)
+	for _, step := range []struct {
+		f        func() error
+		expected []model.TicketStatus
+	}{
+		{func() error { return nil }, []model.TicketStatus{model.StatusOnStage, model.StatusWaiting, model.StatusWaiting, model.StatusWaiting, model.StatusWaiting}},
+		{func() error { return b.Advance(model.DefaultStage, model.DontCare) }, []model.TicketStatus{model.StatusSung, model.StatusOnStage, model.StatusWaiting, model.StatusWaiting, model.StatusWaiting}},
+		{func() error { return b.RemoveTicket(model.DefaultStage, ids[2], model.DontCare) }, []model.TicketStatus{model.StatusSung, model.StatusOnStage, model.StatusSkipped, model.StatusWaiting, model.StatusWaiting}},
+		{func() error { return b.CancelTicket(ids[3], model.DontCare) }, []model.TicketStatus{model.StatusSung, model.StatusOnStage, model.StatusSkipped, model.StatusCancelled, model.StatusWaiting}},
+		{func() error {
+			_, err := b.NoShow(model.DefaultStage, backend.NoShowPolicy{Defer: 1, Max: 1}, model.DontCare)
+			return err
+		}, []model.TicketStatus{model.StatusSung, model.StatusNoShow, model.StatusSkipped, model.StatusCancelled, model.StatusOnStage}},
+	} {
+		err := step.f()
+		if err != nil {
+			t.Fatalf("failed to change queue: %s", err)
+		}
+
+		queue, err := b.GetQueue(model.DefaultStage)
+		if err != nil {
+			t.Fatalf("failed to get queue: %s", err)
+		}
+		for i, ticketID := range ids {
+			ticket, err := b.GetTicket(ticketID)
+			if err != nil {
+				t.Fatalf("failed to get ticket: %s", err)
+			}
+			if ticket.Status != step.expected[i] {
+				t.Fatalf("expected ticket %s to be %s, but got %s", ticketID, step.expected[i], ticket.Status)
+			}
+			if s := queue.Status(ticketID); s != "" && s != ticket.Status {
+				t.Fatalf("expected queue and ticket %s to have the same status, but got %s and %s", ticketID, s, ticket.Status)
+			}
+		}
+	}
+
+	// Deferred no-shows are waiting again.
+	more := createTickets(t, b, 1)
+	_, err := b.NoShow(model.DefaultStage, backend.NoShowPolicy{Defer: 1}, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to record no-show: %s", err)
+	}
+	ticket, err := b.GetTicket(ids[4])
+	if err != nil {
+		t.Fatalf("failed to get ticket: %s", err)
+	}
+	var history []model.TicketStatus
+	for _, c := range ticket.StatusHistory {
+		if c.Time.IsZero() {
+			t.Fatalf("expected status changes to have a time, but got %+v", ticket.StatusHistory)
+		}
+		history = append(history, c.Status)
+	}
+	expected := []model.TicketStatus{model.StatusWaiting, model.StatusOnStage, model.StatusNoShow, model.StatusWaiting}
+	if !reflect.DeepEqual(history, expected) {
+		t.Fatalf("expected history %v, but got %v", expected, history)
+	}
+	queue, err := b.GetQueue(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get queue: %s", err)
+	}
+	if current, _ := queue.Current(); current != more[0] {
+		t.Fatalf("expected %s to be on stage, but got %s", more[0], current)
+	}
+}
+
 func TestGetMultipleTickets(t *testing.T) {
 	b, teardown := setupDB(t)
 	defer teardown()
 
-	var err error
+	var err error
+
+	var tickets [5]model.Ticket
+	for i := 0; i < len(tickets); i++ {
+		tickets[i], _, err = b.CreateTicket(model.DefaultStage)
+		if err != nil {
+			t.Fatalf("failed to create ticket %d: %s", i, err)
+		}
+	}
+
+	for i, ticket := range tickets {
+		result, err := b.GetTicket(ticket.ID)
+		if err != nil {
+			t.Fatalf("error getting ticket %d: %s", i, err)
+		}
+		if !reflect.DeepEqual(ticket, result) {
+			t.Fatalf("wrong result for ticket %d: expected %s, but got %s", i, ticket, result)
+		}
+	}
+}
+
+func TestIDsDifferent(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ids := make(map[model.ID]struct{})
+	for i := 0; i < 1000; i++ {
+		ticket, _, err := b.CreateTicket(model.DefaultStage)
+		if err != nil {
+			t.Fatalf("error creating ticket: %s", err)
+		}
+		if _, ok := ids[ticket.ID]; ok {
+			t.Fatalf("duplicate id: %s", ticket.ID)
+		}
+		ids[ticket.ID] = struct{}{}
+	}
+}
+
+func TestMoveTicket(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ids := createTickets(t, b, 5)
+
+	err := b.MoveTicket(model.DefaultStage, ids[3], 1, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to move ticket: %s", err)
+	}
+	expectQueue(t, b, []model.ID{ids[0], ids[3], ids[1], ids[2], ids[4]})
+
+	err = b.MoveTicket(model.DefaultStage, ids[3], 4, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to move ticket: %s", err)
+	}
+	expectQueue(t, b, []model.ID{ids[0], ids[1], ids[2], ids[4], ids[3]})
+
+	err = b.MoveTicket(model.DefaultStage, ids[3], 5, model.DontCare)
+	if err != backend.ErrInvalidQueueMovement {
+		t.Fatalf("expected %s when moving past the end, but got %v", backend.ErrInvalidQueueMovement, err)
+	}
+
+	err = b.Advance(model.DefaultStage, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to advance: %s", err)
+	}
+
+	err = b.MoveTicket(model.DefaultStage, ids[3], 0, model.DontCare)
+	if err != backend.ErrInvalidQueueMovement {
+		t.Fatalf("expected %s when moving before the current position, but got %v", backend.ErrInvalidQueueMovement, err)
+	}
+
+	err = b.MoveTicket(model.DefaultStage, ids[0], 3, model.DontCare)
+	if err != backend.ErrInvalidQueueMovement {
+		t.Fatalf("expected %s when moving a past ticket, but got %v", backend.ErrInvalidQueueMovement, err)
+	}
+}
+
+func TestRemoveTicket(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ids := createTickets(t, b, 3)
+
+	err := b.RemoveTicket(model.DefaultStage, ids[0], model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to remove ticket: %s", err)
+	}
+	expectQueue(t, b, []model.ID{ids[1], ids[2]})
+
+	_, err = b.GetTicket(ids[0])
+	if err != nil {
+		t.Fatalf("removed ticket should still exist, but got: %s", err)
+	}
+
+	err = b.RemoveTicket(model.DefaultStage, ids[0], model.DontCare)
+	if _, ok := err.(backend.ErrTicketNotQueued); !ok {
+		t.Fatalf("expected ErrTicketNotQueued, but got %v", err)
+	}
+}
+
+func TestDefer(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ids := createTickets(t, b, 4)
+
+	err := b.Defer(model.DefaultStage, ids[0], 2, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to defer ticket: %s", err)
+	}
+	expectQueue(t, b, []model.ID{ids[1], ids[2], ids[0], ids[3]})
+
+	err = b.Defer(model.DefaultStage, ids[1], 10, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to defer ticket: %s", err)
+	}
+	expectQueue(t, b, []model.ID{ids[2], ids[0], ids[3], ids[1]})
+
+	err = b.Defer(model.DefaultStage, ids[2], 0, model.DontCare)
+	if err != backend.ErrInvalidQueueMovement {
+		t.Fatalf("expected %s, but got %v", backend.ErrInvalidQueueMovement, err)
+	}
+}
+
+func TestNoShow(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ids := createTickets(t, b, 4)
+	policy := backend.NoShowPolicy{Defer: 2, Max: 2}
+
+	ticket, err := b.NoShow(model.DefaultStage, policy, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to report no-show: %s", err)
+	}
+	if ticket.ID != ids[0] || ticket.NoShows != 1 {
+		t.Fatalf("expected ticket %s with 1 no-show, but got %s with %d", ids[0], ticket.ID, ticket.NoShows)
+	}
+	expectQueue(t, b, []model.ID{ids[1], ids[2], ids[0], ids[3]})
+
+	for i := 0; i < 2; i++ {
+		err = b.Advance(model.DefaultStage, model.DontCare)
+		if err != nil {
+			t.Fatalf("failed to advance: %s", err)
+		}
+	}
+
+	ticket, err = b.NoShow(model.DefaultStage, policy, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to report no-show: %s", err)
+	}
+	if ticket.ID != ids[0] || ticket.NoShows != 2 || !policy.Drops(ticket.NoShows) {
+		t.Fatalf("expected ticket %s to be dropped after 2 no-shows, but got %s with %d", ids[0], ticket.ID, ticket.NoShows)
+	}
+	expectQueue(t, b, []model.ID{ids[1], ids[2], ids[3]})
+
+	stored, err := b.GetTicket(ids[0])
+	if err != nil {
+		t.Fatalf("failed to get ticket: %s", err)
+	}
+	if stored.NoShows != 2 {
+		t.Fatalf("expected 2 no-shows to be stored, but got %d", stored.NoShows)
+	}
+
+	// Nobody is waiting after the last ticket, so it cannot be moved back.
+	_, err = b.NoShow(model.DefaultStage, policy, model.DontCare)
+	if err != backend.ErrInvalidQueueMovement {
+		t.Fatalf("expected %s, but got %v", backend.ErrInvalidQueueMovement, err)
+	}
+	stored, err = b.GetTicket(ids[3])
+	if err != nil {
+		t.Fatalf("failed to get ticket: %s", err)
+	}
+	if stored.NoShows != 0 {
+		t.Fatalf("expected no no-shows after failing, but got %d", stored.NoShows)
+	}
+}
+
+func TestScheduling(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	err := b.SetScheduling(model.Scheduling{Policy: "lottery"})
+	if _, ok := err.(backend.ErrInvalidScheduling); !ok {
+		t.Fatalf("expected ErrInvalidScheduling, but got %v", err)
+	}
+	err = b.SetScheduling(model.Scheduling{Policy: model.RoundRobin})
+	if err != nil {
+		t.Fatalf("failed to set scheduling: %s", err)
+	}
+
+	ids := createTickets(t, b, 4)
+	for _, step := range []struct {
+		ticket   int
+		names    []string
+		expected []int
+	}{
+		// The current ticket is never moved.
+		{0, []string{"Anna"}, []int{0, 1, 2, 3}},
+		{1, []string{"anna "}, []int{0, 2, 3, 1}},
+		// Tickets stay ahead of later tickets of the same round.
+		{2, []string{"Bert"}, []int{0, 2, 3, 1}},
+		// Bert's second ticket goes behind Anna's, which is older.
+		{3, []string{"Carl", "Bert"}, []int{0, 2, 1, 3}},
+	} {
+		err = b.SetNames(ids[step.ticket], step.names, model.DontCare)
+		if err != nil {
+			t.Fatalf("failed to set names of %s: %s", ids[step.ticket], err)
+		}
+
+		expected := make([]model.ID, len(step.expected))
+		for i, n := range step.expected {
+			expected[i] = ids[n]
+		}
+		expectQueue(t, b, expected)
+	}
+
+	err = b.SetScheduling(model.Scheduling{Policy: model.MaxTickets, MaxTickets: 1})
+	if err != nil {
+		t.Fatalf("failed to set scheduling: %s", err)
+	}
+	more := createTickets(t, b, 2)
+	err = b.SetNames(more[0], []string{"Dora"}, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to set names: %s", err)
+	}
+	// Tickets with names keep them and their place.
+	err = b.SetNames(more[0], []string{"Dora", "Anna"}, model.DontCare)
+	if _, ok := err.(backend.ErrTooManyTickets); !ok {
+		t.Fatalf("expected ErrTooManyTickets, but got %v", err)
+	}
+	ticket, err := b.GetTicket(more[0])
+	if err != nil {
+		t.Fatalf("failed to get ticket: %s", err)
+	}
+	if !reflect.DeepEqual(ticket.Names, []string{"Dora"}) || ticket.Status != model.StatusWaiting {
+		t.Fatalf("expected Dora to keep waiting, but got %+v", ticket)
+	}
+	// Tickets without names would still take a turn, so they are held.
+	err = b.SetNames(more[1], []string{"Erik", "BERT"}, model.DontCare)
+	if _, ok := err.(backend.ErrTooManyTickets); !ok {
+		t.Fatalf("expected ErrTooManyTickets, but got %v", err)
+	}
+	ticket, err = b.GetTicket(more[1])
+	if err != nil {
+		t.Fatalf("failed to get ticket: %s", err)
+	}
+	if len(ticket.Names) != 0 || ticket.Status != model.StatusWaiting {
+		t.Fatalf("expected the ticket to keep waiting without names, but got %+v", ticket)
+	}
+	late := createTickets(t, b, 1)
+	expectQueue(t, b, []model.ID{ids[0], ids[2], ids[1], ids[3], more[0], more[1], late[0]})
+
+	// Held tickets are passed over when they would be next.
+	for i := 0; i < 4; i++ {
+		err = b.Advance(model.DefaultStage, model.DontCare)
+		if err != nil {
+			t.Fatalf("failed to advance: %s", err)
+		}
+	}
+	expectQueue(t, b, []model.ID{ids[0], ids[2], ids[1], ids[3], more[0], late[0], more[1]})
+	queue, err := b.GetQueue(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get queue: %s", err)
+	}
+	if !queue.Held[more[1]] {
+		t.Fatalf("expected %s to be held, but got %v", more[1], queue.Held)
+	}
+
+	// Names that are accepted release the ticket.
+	err = b.SetNames(more[1], []string{"Erik"}, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to set names: %s", err)
+	}
+	queue, err = b.GetQueue(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get queue: %s", err)
+	}
+	if len(queue.Held) != 0 {
+		t.Fatalf("expected no held tickets, but got %v", queue.Held)
+	}
+	for i := 0; i < 2; i++ {
+		err = b.Advance(model.DefaultStage, model.DontCare)
+		if err != nil {
+			t.Fatalf("failed to advance: %s", err)
+		}
+	}
+	expectQueue(t, b, []model.ID{ids[0], ids[2], ids[1], ids[3], more[0], late[0], more[1]})
+	queue, err = b.GetQueue(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get queue: %s", err)
+	}
+	if current, _ := queue.Current(); current != more[1] {
+		t.Fatalf("expected %s to be current, but got %s", more[1], current)
+	}
+
+	_, err = b.StartNight("")
+	if err != nil {
+		t.Fatalf("failed to start night: %s", err)
+	}
+	nights, err := b.GetNights()
+	if err != nil {
+		t.Fatalf("failed to get nights: %s", err)
+	}
+	for _, n := range nights {
+		if n.Scheduling.Policy != model.MaxTickets || n.Scheduling.MaxTickets != 1 {
+			t.Fatalf("expected the scheduling to be kept, but got %+v", n)
+		}
+	}
+}
+
+func TestReservations(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	_, _, err := b.CreateReservedTicket(model.DefaultStage, model.Reservation{Slot: -1})
+	if _, ok := err.(backend.ErrInvalidReservation); !ok {
+		t.Fatalf("expected ErrInvalidReservation, but got %v", err)
+	}
+
+	reserve := func(r model.Reservation) model.ID {
+		ticket, _, err := b.CreateReservedTicket(model.DefaultStage, r)
+		if err != nil {
+			t.Fatalf("failed to create ticket: %s", err)
+		}
+		return ticket.ID
+	}
+
+	ids := createTickets(t, b, 2)
+	low := reserve(model.Reservation{Priority: 1})
+	expectQueue(t, b, []model.ID{ids[0], low, ids[1]})
+
+	// Slot 5 does not exist yet, so the ticket waits at the end.
+	slot := reserve(model.Reservation{Slot: 5})
+	expectQueue(t, b, []model.ID{ids[0], low, ids[1], slot})
+	ids = append(ids, createTickets(t, b, 2)...)
+	expectQueue(t, b, []model.ID{ids[0], low, ids[1], ids[2], slot, ids[3]})
+
+	// Higher priorities go first, the slot is kept.
+	high := reserve(model.Reservation{Priority: 2})
+	expectQueue(t, b, []model.ID{ids[0], high, low, ids[1], slot, ids[2], ids[3]})
+
+	ticket, err := b.GetTicket(high)
+	if err != nil {
+		t.Fatalf("failed to get ticket: %s", err)
+	}
+	if ticket.Priority != 2 {
+		t.Fatalf("expected priority 2, but got %d", ticket.Priority)
+	}
+
+	// Reserved tickets are not moved by the scheduling.
+	err = b.SetScheduling(model.Scheduling{Policy: model.RoundRobin})
+	if err != nil {
+		t.Fatalf("failed to set scheduling: %s", err)
+	}
+	for _, id := range []model.ID{ids[0], high, low} {
+		err = b.SetNames(id, []string{"Anna"}, model.DontCare)
+		if err != nil {
+			t.Fatalf("failed to set names of %s: %s", id, err)
+		}
+	}
+	expectQueue(t, b, []model.ID{ids[0], high, low, ids[1], slot, ids[2], ids[3]})
+}
+
+func TestReservedSlot(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ids := createTickets(t, b, 4)
+	ticket, _, err := b.CreateReservedTicket(model.DefaultStage, model.Reservation{Slot: 5})
+	if err != nil {
+		t.Fatalf("failed to create ticket: %s", err)
+	}
+	slot := ticket.ID
+	ids = append(ids, createTickets(t, b, 2)...)
+	expectQueue(t, b, []model.ID{ids[0], ids[1], ids[2], ids[3], slot, ids[4], ids[5]})
+
+	// The slot is kept whatever happens to the tickets in front of it.
+	for _, step := range []struct {
+		name     string
+		f        func() error
+		expected []model.ID
+	}{
+		{"cancel", func() error {
+			return b.CancelTicket(ids[1], model.DontCare)
+		}, []model.ID{ids[0], ids[2], ids[3], ids[4], slot, ids[5]}},
+		{"remove", func() error {
+			return b.RemoveTicket(model.DefaultStage, ids[2], model.DontCare)
+		}, []model.ID{ids[0], ids[3], ids[4], ids[5], slot}},
+		{"move", func() error {
+			return b.MoveTicket(model.DefaultStage, ids[5], 1, model.DontCare)
+		}, []model.ID{ids[0], ids[5], ids[3], ids[4], slot}},
+		{"defer", func() error {
+			return b.Defer(model.DefaultStage, ids[3], 10, model.DontCare)
+		}, []model.ID{ids[0], ids[5], ids[4], ids[3], slot}},
+		{"no-show", func() error {
+			_, err := b.NoShow(model.DefaultStage, backend.NoShowPolicy{Defer: 1}, model.DontCare)
+			return err
+		}, []model.ID{ids[5], ids[0], ids[4], ids[3], slot}},
+	} {
+		err = step.f()
+		if err != nil {
+			t.Fatalf("%s failed: %s", step.name, err)
+		}
+		expectQueue(t, b, step.expected)
+	}
+
+	// Moving the ticket itself gives up the slot.
+	err = b.MoveTicket(model.DefaultStage, slot, 1, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to move ticket: %s", err)
+	}
+	err = b.RemoveTicket(model.DefaultStage, ids[0], model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to remove ticket: %s", err)
+	}
+	expectQueue(t, b, []model.ID{ids[5], slot, ids[4], ids[3]})
+
+	queue, err := b.GetQueue(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get queue: %s", err)
+	}
+	if len(queue.Slots) != 0 {
+		t.Fatalf("expected no slots, but got %v", queue.Slots)
+	}
+}
+
+func TestQueueVersion(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ids := createTickets(t, b, 3)
+
+	before, err := b.GetQueue(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get queue: %s", err)
+	}
+
+	for i, f := range []func() error{
+		func() error { return b.MoveTicket(model.DefaultStage, ids[2], 0, model.DontCare) },
+		func() error { return b.Defer(model.DefaultStage, ids[2], 1, model.DontCare) },
+		func() error { return b.RemoveTicket(model.DefaultStage, ids[1], model.DontCare) },
+	} {
+		err := f()
+		if err != nil {
+			t.Fatalf("operation %d failed: %s", i, err)
+		}
+
+		after, err := b.GetQueue(model.DefaultStage)
+		if err != nil {
+			t.Fatalf("failed to get queue: %s", err)
+		}
+		if after.Version <= before.Version {
+			t.Fatalf("operation %d did not increase version: %s -> %s", i, before.Version, after.Version)
+		}
+		before = after
+	}
+}
+
+func TestAdvanceVersionConflict(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	createTickets(t, b, 3)
+
+	queue, err := b.GetQueue(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get queue: %s", err)
+	}
+
+	err = b.Advance(model.DefaultStage, queue.Version)
+	if err != nil {
+		t.Fatalf("failed to advance: %s", err)
+	}
+
+	err = b.Advance(model.DefaultStage, queue.Version)
+	conflict, ok := err.(backend.ErrVersionConflict)
+	if !ok {
+		t.Fatalf("expected ErrVersionConflict, but got %v", err)
+	}
+	if conflict.Current != queue.Version+1 {
+		t.Fatalf("expected current version %s, but got %s", queue.Version+1, conflict.Current)
+	}
+
+	after, err := b.GetQueue(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get queue: %s", err)
+	}
+	if after.Position != 1 {
+		t.Fatalf("expected position 1 after conflicting advance, but got %d", after.Position)
+	}
+}
+
+func TestSetNamesVersion(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ticket, pin, err := b.CreateTicket(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to create ticket: %s", err)
+	}
+
+	err = b.SetNamesWithPIN(ticket.ID, []string{"foo"}, pin, "", ticket.Version)
+	if err != nil {
+		t.Fatalf("failed to set names: %s", err)
+	}
+
+	err = b.SetNames(ticket.ID, []string{"bar"}, ticket.Version)
+	if _, ok := err.(backend.ErrVersionConflict); !ok {
+		t.Fatalf("expected ErrVersionConflict, but got %v", err)
+	}
+
+	result, err := b.GetTicket(ticket.ID)
+	if err != nil {
+		t.Fatalf("failed to get ticket: %s", err)
+	}
+	if !reflect.DeepEqual(result.Names, []string{"foo"}) {
+		t.Fatalf("expected names to be [foo], but got %s", result.Names)
+	}
+	if result.Version != ticket.Version+1 {
+		t.Fatalf("expected version %s, but got %s", ticket.Version+1, result.Version)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	events, cancel := b.Subscribe(0)
+	defer cancel()
+
+	ticket, _, err := b.CreateTicket(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to create ticket: %s", err)
+	}
+
+	created := expectEvent(t, events, model.EventTicketCreated)
+	if created.Ticket == nil || created.Ticket.ID != ticket.ID {
+		t.Fatalf("expected event for ticket %s, but got %v", ticket.ID, created.Ticket)
+	}
+	changed := expectEvent(t, events, model.EventQueueChanged)
+	if changed.Queue == nil || changed.Version != changed.Queue.Version {
+		t.Fatalf("expected queue with version %s, but got %v", changed.Version, changed.Queue)
+	}
+
+	err = b.Pause(model.DefaultStage, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to pause: %s", err)
+	}
+	paused := expectEvent(t, events, model.EventPauseToggled)
+
+	resumed, cancelResumed := b.Subscribe(created.ID)
+	defer cancelResumed()
+	expectEvent(t, resumed, model.EventQueueChanged)
+	expectEvent(t, resumed, model.EventPauseToggled)
+
+	unknown, cancelUnknown := b.Subscribe(created.ID + 100)
+	defer cancelUnknown()
+	expectEvent(t, unknown, model.EventResync)
+
+	// After a restart, ids from before are not mistaken for current ones,
+	// even if as many events have been published since.
+	restarted, teardownRestarted := setupDB(t)
+	defer teardownRestarted()
+	createTickets(t, restarted, 1)
+	err = restarted.Pause(model.DefaultStage, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to pause: %s", err)
+	}
+	stale, cancelStale := restarted.Subscribe(paused.ID)
+	defer cancelStale()
+	expectEvent(t, stale, model.EventResync)
+
+	// Concurrent changes are published in the order of their versions.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := b.Pause(model.DefaultStage, model.DontCare)
+			if err != nil {
+				t.Errorf("failed to pause: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+	for i := 0; i < 10; i++ {
+		e := expectEvent(t, events, model.EventPauseToggled)
+		if e.Version <= paused.Version {
+			t.Fatalf("expected a version after %s, but got %s", paused.Version, e.Version)
+		}
+		paused = e
+	}
+}
+
+func TestHistory(t *testing.T) {
+	db, logger, teardown := setupBolt(t)
+	defer teardown()
+
+	b, err := backend.New(logger, backend.NewBoltStore(db))
+	if err != nil {
+		t.Fatalf("failed to create backend: %s", err)
+	}
+
+	ids := createTickets(t, b, 2)
+	err = b.SetNames(ids[0], []string{"foo", "bar"}, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to set names: %s", err)
+	}
+
+	playing := model.State{
+		Playback: model.Playing,
+		Source:   "Artist - Title/song.txt",
+		Position: 170 * time.Second,
+		Length:   180 * time.Second,
+		Scores:   []model.Score{{Base: 5000, Line: 800, Golden: 200}, {Base: 3000}},
+	}
+	for _, s := range []model.State{playing, {Playback: model.Stopped}} {
+		err = b.UpdateState(model.DefaultStage, s)
+		if err != nil {
+			t.Fatalf("failed to update state: %s", err)
+		}
+	}
+
+	history, err := b.GetHistory()
+	if err != nil {
+		t.Fatalf("failed to get history: %s", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("expected one played song, but got %d", len(history))
+	}
+	song := history[0]
+	if song.Ticket != ids[0] || song.Source != playing.Source || song.Length != playing.Length {
+		t.Fatalf("wrong played song: %s", song)
+	}
+	if !reflect.DeepEqual(song.Names, []string{"foo", "bar"}) {
+		t.Fatalf("expected names [foo bar], but got %s", song.Names)
+	}
+	if !reflect.DeepEqual(song.Scores, playing.Scores) {
+		t.Fatalf("expected scores %v, but got %v", playing.Scores, song.Scores)
+	}
+
+	// Songs that are stopped early are not recorded.
+	stopped := playing
+	stopped.Position = time.Minute
+	for _, s := range []model.State{stopped, {Playback: model.Stopped}} {
+		err = b.UpdateState(model.DefaultStage, s)
+		if err != nil {
+			t.Fatalf("failed to update state: %s", err)
+		}
+	}
+	history, err = b.GetHistory()
+	if err != nil {
+		t.Fatalf("failed to get history: %s", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("expected a song stopped early not to be recorded, but got %d songs", len(history))
+	}
+
+	err = b.UpdateState(model.DefaultStage, playing)
+	if err != nil {
+		t.Fatalf("failed to update state: %s", err)
+	}
+
+	restarted, err := backend.New(logger, backend.NewBoltStore(db))
+	if err != nil {
+		t.Fatalf("failed to recreate backend: %s", err)
+	}
+
+	state, err := restarted.GetState(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get state: %s", err)
+	}
+	if !reflect.DeepEqual(state, playing) {
+		t.Fatalf("expected state %v after restart, but got %v", playing, state)
+	}
+
+	history, err = restarted.GetHistory()
+	if err != nil {
+		t.Fatalf("failed to get history: %s", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("expected one played song after restart, but got %d", len(history))
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ids := createTickets(t, b, 3)
+	for i, names := range [][]string{{"Alice"}, {"alice", "Bob"}, {"Carol"}} {
+		err := b.SetNames(ids[i], names, model.DontCare)
+		if err != nil {
+			t.Fatalf("failed to set names: %s", err)
+		}
+	}
+
+	playSong(t, b, "a", model.Score{Base: 4000})
+	err := b.Advance(model.DefaultStage, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to advance: %s", err)
+	}
+	playSong(t, b, "b", model.Score{Base: 8000}, model.Score{Base: 6000})
+	err = b.Advance(model.DefaultStage, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to advance: %s", err)
+	}
+	playSong(t, b, "a", model.Score{Base: 7000}, model.Score{})
+
+	board, err := b.GetLeaderboard(model.LeaderboardFilter{})
+	if err != nil {
+		t.Fatalf("failed to get leaderboard: %s", err)
+	}
+	expectRankings(t, board.Singers, []model.Ranking{
+		{Name: "Alice", Best: 8000, Average: 6000, Count: 2},
+		{Name: "Carol", Best: 7000, Average: 7000, Count: 1},
+		{Name: "Bob", Best: 6000, Average: 6000, Count: 1},
+	})
+	expectRankings(t, board.Songs, []model.Ranking{
+		{Name: "b", Best: 8000, Average: 7000, Count: 2},
+		{Name: "a", Best: 7000, Average: 5500, Count: 2},
+	})
+
+	board, err = b.GetLeaderboard(model.LeaderboardFilter{Mode: model.Solo, RankBy: model.RankByAverage, Limit: 1})
+	if err != nil {
+		t.Fatalf("failed to get leaderboard: %s", err)
+	}
+	expectRankings(t, board.Singers, []model.Ranking{
+		{Name: "Carol", Best: 7000, Average: 7000, Count: 1},
+	})
+
+	board, err = b.GetLeaderboard(model.LeaderboardFilter{Since: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("failed to get leaderboard: %s", err)
+	}
+	if len(board.Singers) != 0 || len(board.Songs) != 0 {
+		t.Fatalf("expected empty leaderboard, but got %v", board)
+	}
+}
+
+func TestRequestSong(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ticket, pin, err := b.CreateTicket(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to create ticket: %s", err)
+	}
+	err = b.SetNames(ticket.ID, []string{"foo"}, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to set names: %s", err)
+	}
+
+	song := "c29uZy50eHQ="
+	err = b.UpdateTicketWithPIN(ticket.ID, model.TicketUpdate{Song: &song}, model.PIN("wrong"), "", model.DontCare)
+	if err != backend.ErrUnauthorized {
+		t.Fatalf("expected %s, but got %v", backend.ErrUnauthorized, err)
+	}
+
+	err = b.UpdateTicketWithPIN(ticket.ID, model.TicketUpdate{Song: &song}, pin, "", model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to request song: %s", err)
+	}
+
+	result, err := b.GetTicket(ticket.ID)
+	if err != nil {
+		t.Fatalf("failed to get ticket: %s", err)
+	}
+	if result.Song != song || !reflect.DeepEqual(result.Names, []string{"foo"}) {
+		t.Fatalf("expected song %s and names [foo], but got %s", song, result)
+	}
+
+	queue, err := b.GetQueue(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get queue: %s", err)
+	}
+	if queue.Requests[ticket.ID] != song {
+		t.Fatalf("expected request %s in queue, but got %v", song, queue.Requests)
+	}
+
+	empty := ""
+	err = b.UpdateTicket(ticket.ID, model.TicketUpdate{Song: &empty}, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to remove request: %s", err)
+	}
+
+	queue, err = b.GetQueue(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get queue: %s", err)
+	}
+	if len(queue.Requests) != 0 {
+		t.Fatalf("expected no requests, but got %v", queue.Requests)
+	}
+}
+
+func TestReportAnomaly(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	events, cancel := b.Subscribe(0)
+	defer cancel()
+
+	anomaly := model.Anomaly{Message: "wrong song", Ticket: model.ID("1"), Source: "foo.txt"}
+	b.ReportAnomaly(anomaly)
+
+	event := expectEvent(t, events, model.EventAnomaly)
+	if event.Anomaly == nil || *event.Anomaly != anomaly {
+		t.Fatalf("expected %s, but got %v", anomaly, event.Anomaly)
+	}
+}
+
+func TestPendingAdvance(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	ids := createTickets(t, b, 3)
+
+	first, err := b.ProposeAdvance(model.PendingAdvance{Ticket: ids[0], Reason: "too short"})
+	if err != nil {
+		t.Fatalf("failed to propose advance: %s", err)
+	}
+	second, err := b.ProposeAdvance(model.PendingAdvance{Ticket: ids[0], Reason: "too short"})
+	if err != nil {
+		t.Fatalf("failed to propose advance: %s", err)
+	}
+
+	pending, err := b.GetPendingAdvances()
+	if err != nil {
+		t.Fatalf("failed to get pending advances: %s", err)
+	}
+	if len(pending) != 2 || pending[0].ID != first.ID || pending[1].ID != second.ID {
+		t.Fatalf("expected pending advances %d and %d, but got %v", first.ID, second.ID, pending)
+	}
+
+	err = b.ConfirmAdvance("", first.ID, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to confirm advance: %s", err)
+	}
+
+	queue, err := b.GetQueue(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get queue: %s", err)
+	}
+	if queue.Position != 1 {
+		t.Fatalf("expected position 1, but got %d", queue.Position)
+	}
+
+	err = b.ConfirmAdvance("", second.ID, model.DontCare)
+	if err != backend.ErrInvalidQueueMovement {
+		t.Fatalf("expected %s for an outdated advance, but got %v", backend.ErrInvalidQueueMovement, err)
+	}
+
+	err = b.RejectAdvance("", second.ID)
+	if err != nil {
+		t.Fatalf("failed to reject advance: %s", err)
+	}
+
+	err = b.RejectAdvance("", second.ID)
+	if _, ok := err.(backend.ErrPendingAdvanceDoesNotExist); !ok {
+		t.Fatalf("expected ErrPendingAdvanceDoesNotExist, but got %v", err)
+	}
+
+	pending, err = b.GetPendingAdvances()
+	if err != nil {
+		t.Fatalf("failed to get pending advances: %s", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected no pending advances, but got %v", pending)
+	}
+}
+
+func TestPendingAdvanceStage(t *testing.T) {
+	b, teardown := setupDB(t)
+	defer teardown()
+
+	_, err := b.CreateStage("keller")
+	if err != nil {
+		t.Fatalf("failed to create stage: %s", err)
+	}
+	ids := createTickets(t, b, 2)
 
-	var tickets [5]model.Ticket
-	for i := 0; i < len(tickets); i++ {
-		tickets[i], _, err = b.CreateTicket()
+	pending, err := b.ProposeAdvance(model.PendingAdvance{Ticket: ids[0], Reason: "too short"})
+	if err != nil {
+		t.Fatalf("failed to propose advance: %s", err)
+	}
+
+	// A client bound to keller must not resolve advances of the default stage.
+	err = b.ConfirmAdvance("keller", pending.ID, model.DontCare)
+	if _, ok := err.(backend.ErrPendingAdvanceDoesNotExist); !ok {
+		t.Fatalf("expected ErrPendingAdvanceDoesNotExist, but got %v", err)
+	}
+	err = b.RejectAdvance("keller", pending.ID)
+	if _, ok := err.(backend.ErrPendingAdvanceDoesNotExist); !ok {
+		t.Fatalf("expected ErrPendingAdvanceDoesNotExist, but got %v", err)
+	}
+
+	err = b.ConfirmAdvance(model.DefaultStage, pending.ID, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to confirm advance: %s", err)
+	}
+}
+
+func TestStores(t *testing.T) {
+	db, logger, teardown := setupBolt(t)
+	defer teardown()
+
+	type snapshot struct {
+		Tickets map[model.ID]model.Ticket
+		Queue   model.Queue
+		Pending []model.PendingAdvance
+		Played  int
+	}
+
+	run := func(store backend.Store) snapshot {
+		b, err := backend.New(logger, store)
 		if err != nil {
-			t.Fatalf("failed to create ticket %d: %s", i, err)
+			t.Fatalf("failed to create backend: %s", err)
+		}
+
+		ids := createTickets(t, b, 4)
+		song := "c29uZy50eHQ="
+		for _, err := range []error{
+			b.SetNames(ids[0], []string{"foo", "bar"}, model.DontCare),
+			b.SetNames(ids[1], []string{}, model.DontCare),
+			b.UpdateTicket(ids[2], model.TicketUpdate{Song: &song}, model.DontCare),
+			b.MoveTicket(model.DefaultStage, ids[3], 1, model.DontCare),
+			b.RemoveTicket(model.DefaultStage, ids[1], model.DontCare),
+		} {
+			if err != nil {
+				t.Fatalf("failed to change tickets: %s", err)
+			}
+		}
+		playSong(t, b, "song.txt", model.Score{Base: 1000})
+		_, err = b.ProposeAdvance(model.PendingAdvance{Ticket: ids[0], Reason: "too short"})
+		if err != nil {
+			t.Fatalf("failed to propose advance: %s", err)
+		}
+
+		var result snapshot
+		result.Tickets, err = b.GetTickets()
+		if err != nil {
+			t.Fatalf("failed to get tickets: %s", err)
+		}
+		for _, ticket := range result.Tickets {
+			for i := range ticket.StatusHistory {
+				ticket.StatusHistory[i].Time = time.Time{}
+			}
+		}
+		result.Queue, err = b.GetQueue(model.DefaultStage)
+		if err != nil {
+			t.Fatalf("failed to get queue: %s", err)
 		}
+		for ticketID, status := range result.Queue.Statuses {
+			status.Time = time.Time{}
+			result.Queue.Statuses[ticketID] = status
+		}
+		result.Pending, err = b.GetPendingAdvances()
+		if err != nil {
+			t.Fatalf("failed to get pending advances: %s", err)
+		}
+		for i := range result.Pending {
+			result.Pending[i].Created = time.Time{}
+		}
+		history, err := b.GetHistory()
+		if err != nil {
+			t.Fatalf("failed to get history: %s", err)
+		}
+		result.Played = len(history)
+		return result
 	}
 
-	for i, ticket := range tickets {
-		result, err := b.GetTicket(ticket.ID)
+	inBolt := run(backend.NewBoltStore(db))
+	inMemory := run(backend.NewMemoryStore())
+	if !reflect.DeepEqual(inBolt, inMemory) {
+		t.Fatalf("stores differ:\nbolt:   %+v\nmemory: %+v", inBolt, inMemory)
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	db, logger, teardown := setupBolt(t)
+	defer teardown()
+
+	// A database from before schema versions, with some of the buckets.
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range []string{"queue", "tickets", "pins"} {
+			_, err := tx.CreateBucket([]byte(name))
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Bucket([]byte("tickets")).Put([]byte("1"), []byte("garbage"))
+	})
+	if err != nil {
+		t.Fatalf("failed to prepare database: %s", err)
+	}
+
+	status, err := backend.CheckSchema(db)
+	if err != nil {
+		t.Fatalf("failed to check schema: %s", err)
+	}
+	if status.Version != 0 || len(status.Pending) != status.Latest {
+		t.Fatalf("expected all migrations to be pending, but got %+v", status)
+	}
+	if errs := backend.Verify(db); len(errs) != 1 {
+		t.Fatalf("expected one unreadable record, but got %v", errs)
+	}
+
+	_, err = backend.New(logger, backend.NewBoltStore(db))
+	if err != nil {
+		t.Fatalf("failed to create backend: %s", err)
+	}
+
+	status, err = backend.CheckSchema(db)
+	if err != nil {
+		t.Fatalf("failed to check schema: %s", err)
+	}
+	if status.Version != status.Latest || len(status.Pending) != 0 {
+		t.Fatalf("expected database to be up to date, but got %+v", status)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte("meta")).Put([]byte("schema_version"), []byte{0, 0, 0, 0, 0, 0, 1, 0})
+	})
+	if err != nil {
+		t.Fatalf("failed to set schema version: %s", err)
+	}
+	_, err = backend.New(logger, backend.NewBoltStore(db))
+	if _, ok := err.(backend.ErrSchemaTooNew); !ok {
+		t.Fatalf("expected ErrSchemaTooNew, but got %v", err)
+	}
+}
+
+func TestMigrateStatuses(t *testing.T) {
+	db, logger, teardown := setupBolt(t)
+	defer teardown()
+
+	// Tickets and the queue as stored before statuses were introduced.
+	type oldTicket struct {
+		ID        model.ID
+		NoShows   int
+		Cancelled bool
+	}
+	type oldQueue struct {
+		Queue []model.ID
+		Pos   int
+	}
+	encode := func(v interface{}) []byte {
+		buf := new(bytes.Buffer)
+		err := gob.NewEncoder(buf).Encode(v)
 		if err != nil {
-			t.Fatalf("error getting ticket %d: %s", i, err)
+			t.Fatalf("failed to encode %v: %s", v, err)
 		}
-		if !reflect.DeepEqual(ticket, result) {
-			t.Fatalf("wrong result for ticket %d: expected %s, but got %s", i, ticket, result)
+		return buf.Bytes()
+	}
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range []string{"queue", "tickets", "pins", "state", "songs_played", "pending_advances", "nights", "meta"} {
+			_, err := tx.CreateBucket([]byte(name))
+			if err != nil {
+				return err
+			}
+		}
+		err := tx.Bucket([]byte("meta")).Put([]byte("schema_version"), []byte{0, 0, 0, 0, 0, 0, 0, 4})
+		if err != nil {
+			return err
 		}
+		err = tx.Bucket([]byte("queue")).Put([]byte("queue"), encode(oldQueue{Queue: []model.ID{"1", "2", "3"}, Pos: 1}))
+		if err != nil {
+			return err
+		}
+		for _, ticket := range []oldTicket{
+			{ID: "1"}, {ID: "2"}, {ID: "3"},
+			{ID: "4", Cancelled: true},
+			{ID: "5", NoShows: 2},
+			{ID: "6"},
+		} {
+			err = tx.Bucket([]byte("tickets")).Put([]byte(ticket.ID), encode(ticket))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to prepare database: %s", err)
+	}
+
+	b, err := backend.New(logger, backend.NewBoltStore(db))
+	if err != nil {
+		t.Fatalf("failed to create backend: %s", err)
+	}
+
+	expected := map[model.ID]model.TicketStatus{
+		"1": model.StatusSung,
+		"2": model.StatusOnStage,
+		"3": model.StatusWaiting,
+		"4": model.StatusCancelled,
+		"5": model.StatusNoShow,
+		"6": model.StatusSkipped,
+	}
+	queue, err := b.GetQueue(model.DefaultStage)
+	if err != nil {
+		t.Fatalf("failed to get queue: %s", err)
+	}
+	for ticketID, status := range expected {
+		ticket, err := b.GetTicket(ticketID)
+		if err != nil {
+			t.Fatalf("failed to get ticket %s: %s", ticketID, err)
+		}
+		if ticket.Status != status || len(ticket.StatusHistory) != 1 {
+			t.Fatalf("expected ticket %s to be %s, but got %s with history %v", ticketID, status, ticket.Status, ticket.StatusHistory)
+		}
+		if c, ok := queue.Statuses[ticketID]; ok && c.Status != status {
+			t.Fatalf("expected ticket %s to be %s in the queue, but got %s", ticketID, status, c.Status)
+		}
+	}
+	if len(queue.Statuses) != 3 {
+		t.Fatalf("expected statuses for 3 queued tickets, but got %v", queue.Statuses)
+	}
+
+	err = b.SetNames("4", []string{"Anna"}, model.DontCare)
+	if _, ok := err.(backend.ErrTicketCancelled); !ok {
+		t.Fatalf("expected ErrTicketCancelled, but got %v", err)
 	}
 }
 
-func TestIDsDifferent(t *testing.T) {
+func TestNights(t *testing.T) {
+	db, logger, teardown := setupBolt(t)
+	defer teardown()
+
+	for name, store := range map[string]backend.Store{
+		"bolt":   backend.NewBoltStore(db),
+		"memory": backend.NewMemoryStore(),
+	} {
+		b, err := backend.New(logger, store)
+		if err != nil {
+			t.Fatalf("%s: failed to create backend: %s", name, err)
+		}
+
+		ids := createTickets(t, b, 3)
+		err = b.SetNames(ids[1], []string{"foo"}, model.DontCare)
+		if err != nil {
+			t.Fatalf("%s: failed to set names: %s", name, err)
+		}
+		playSong(t, b, "song.txt", model.Score{Base: 1000})
+
+		events, cancel := b.Subscribe(0)
+		defer cancel()
+
+		night, err := b.StartNight("Sommerfest")
+		if err != nil {
+			t.Fatalf("%s: failed to start night: %s", name, err)
+		}
+		if night.ID != 1 || night.Name != "Sommerfest" || night.TicketCount != 3 || night.SongCount != 1 {
+			t.Fatalf("%s: wrong archived night: %+v", name, night)
+		}
+		expectEvent(t, events, model.EventNightStarted)
+		expectEvent(t, events, model.EventQueueChanged)
+
+		expectQueue(t, b, []model.ID{})
+		history, err := b.GetHistory()
+		if err != nil || len(history) != 0 {
+			t.Fatalf("%s: expected empty history, but got %v, %v", name, history, err)
+		}
+		ticket, _, err := b.CreateTicket(model.DefaultStage)
+		if err != nil {
+			t.Fatalf("%s: failed to create ticket: %s", name, err)
+		}
+		if ticket.ID != "1" {
+			t.Fatalf("%s: expected ticket IDs to start at 1, but got %s", name, ticket.ID)
+		}
+
+		nights, err := b.GetNights()
+		if err != nil {
+			t.Fatalf("%s: failed to get nights: %s", name, err)
+		}
+		if len(nights) != 2 || nights[0].ID != 1 || nights[1].ID != 0 || nights[1].TicketCount != 1 {
+			t.Fatalf("%s: wrong nights: %v", name, nights)
+		}
+
+		archived, err := b.GetNight(1)
+		if err != nil {
+			t.Fatalf("%s: failed to get night: %s", name, err)
+		}
+		if len(archived.Tickets) != 3 || archived.Tickets[1].ID != ids[1] || len(archived.Tickets[1].Names) != 1 {
+			t.Fatalf("%s: wrong archived tickets: %v", name, archived.Tickets)
+		}
+		if len(archived.History) != 1 || len(archived.Queue.Queue) != 3 {
+			t.Fatalf("%s: wrong archived night: %+v", name, archived)
+		}
+
+		_, err = b.GetNight(2)
+		if _, ok := err.(backend.ErrNightDoesNotExist); !ok {
+			t.Fatalf("%s: expected ErrNightDoesNotExist, but got %v", name, err)
+		}
+	}
+}
+
+func TestEstimateQueue(t *testing.T) {
 	b, teardown := setupDB(t)
 	defer teardown()
 
-	ids := make(map[model.ID]struct{})
-	for i := 0; i < 1000; i++ {
-		ticket, _, err := b.CreateTicket()
+	ids := createTickets(t, b, 3)
+	song := "c29uZy50eHQ="
+	err := b.UpdateTicket(ids[1], model.TicketUpdate{Song: &song}, model.DontCare)
+	if err != nil {
+		t.Fatalf("failed to request song: %s", err)
+	}
+	length := func(s string) time.Duration {
+		if s == song {
+			return 2 * time.Minute
+		}
+		return 0
+	}
+
+	before := time.Now()
+	queue, err := b.EstimateQueue(model.DefaultStage, time.Minute, length)
+	if err != nil {
+		t.Fatalf("failed to estimate queue: %s", err)
+	}
+	after := time.Now()
+
+	// Nothing has been played yet, so unknown songs take 4 minutes.
+	eta := queue.ETA[ids[1]]
+	if len(queue.ETA) != 2 || eta.Before(before.Add(6*time.Minute)) || eta.After(after.Add(6*time.Minute)) {
+		t.Fatalf("wrong ETA: %v", queue.ETA)
+	}
+	if d := queue.ETA[ids[2]].Sub(eta); d != 3*time.Minute {
+		t.Fatalf("expected ticket %s to be up 3 minutes after %s, but got %s", ids[2], ids[1], d)
+	}
+
+	playSong(t, b, "song.txt")
+	err = b.UpdateState(model.DefaultStage, model.State{Playback: model.Playing, Source: "other.txt", Length: time.Minute, Position: 20 * time.Second})
+	if err != nil {
+		t.Fatalf("failed to update state: %s", err)
+	}
+
+	before = time.Now()
+	queue, err = b.EstimateQueue(model.DefaultStage, time.Minute, length)
+	if err != nil {
+		t.Fatalf("failed to estimate queue: %s", err)
+	}
+	after = time.Now()
+
+	// The current song has 40 seconds left.
+	eta = queue.ETA[ids[1]]
+	if eta.Before(before.Add(100*time.Second)) || eta.After(after.Add(100*time.Second)) {
+		t.Fatalf("wrong ETA: %v", queue.ETA)
+	}
+}
+
+func TestStages(t *testing.T) {
+	db, logger, teardown := setupBolt(t)
+	defer teardown()
+
+	for name, store := range map[string]backend.Store{
+		"bolt":   backend.NewBoltStore(db),
+		"memory": backend.NewMemoryStore(),
+	} {
+		b, err := backend.New(logger, store)
 		if err != nil {
-			t.Fatalf("error creating ticket: %s", err)
+			t.Fatalf("%s: failed to create backend: %s", name, err)
 		}
-		if _, ok := ids[ticket.ID]; ok {
-			t.Fatalf("duplicate id: %s", ticket.ID)
+
+		for _, invalid := range []string{"", "a/b"} {
+			_, err = b.CreateStage(invalid)
+			if _, ok := err.(backend.ErrInvalidStageName); !ok {
+				t.Fatalf("%s: expected ErrInvalidStageName for %q, but got %v", name, invalid, err)
+			}
+		}
+		_, err = b.CreateStage("keller")
+		if err != nil {
+			t.Fatalf("%s: failed to create stage: %s", name, err)
+		}
+		_, err = b.CreateStage("keller")
+		if _, ok := err.(backend.ErrStageExists); !ok {
+			t.Fatalf("%s: expected ErrStageExists, but got %v", name, err)
+		}
+
+		// Tickets without a stage go to the shorter queue.
+		createTickets(t, b, 2)
+		var stages []string
+		for i := 0; i < 3; i++ {
+			ticket, _, err := b.CreateTicket("")
+			if err != nil {
+				t.Fatalf("%s: failed to create ticket: %s", name, err)
+			}
+			stages = append(stages, ticket.Stage)
+		}
+		if !reflect.DeepEqual(stages, []string{"keller", "keller", model.DefaultStage}) {
+			t.Fatalf("%s: wrong stages for new tickets: %v", name, stages)
+		}
+
+		err = b.Advance("keller", model.DontCare)
+		if err != nil {
+			t.Fatalf("%s: failed to advance: %s", name, err)
+		}
+		err = b.UpdateState("keller", model.State{Playback: model.Playing, Source: "song.txt"})
+		if err != nil {
+			t.Fatalf("%s: failed to update state: %s", name, err)
+		}
+
+		main, err := b.GetQueue(model.DefaultStage)
+		if err != nil {
+			t.Fatalf("%s: failed to get queue: %s", name, err)
+		}
+		keller, err := b.GetQueue("keller")
+		if err != nil {
+			t.Fatalf("%s: failed to get queue: %s", name, err)
+		}
+		if main.Position != 0 || len(main.Queue) != 3 || keller.Position != 1 || len(keller.Queue) != 2 {
+			t.Fatalf("%s: wrong queues: %+v, %+v", name, main, keller)
+		}
+		state, err := b.GetState(model.DefaultStage)
+		if err != nil || state.Playback != model.Stopped {
+			t.Fatalf("%s: expected default stage to be stopped, but got %v, %v", name, state, err)
+		}
+
+		all, err := b.GetStages()
+		if err != nil {
+			t.Fatalf("%s: failed to get stages: %s", name, err)
+		}
+		expected := []model.Stage{{Name: model.DefaultStage, Waiting: 2}, {Name: "keller"}}
+		if !reflect.DeepEqual(all, expected) {
+			t.Fatalf("%s: expected stages %v, but got %v", name, expected, all)
+		}
+
+		err = b.Advance("garten", model.DontCare)
+		if _, ok := err.(backend.ErrStageDoesNotExist); !ok {
+			t.Fatalf("%s: expected ErrStageDoesNotExist, but got %v", name, err)
+		}
+		_, err = b.GetState("garten")
+		if _, ok := err.(backend.ErrStageDoesNotExist); !ok {
+			t.Fatalf("%s: expected ErrStageDoesNotExist, but got %v", name, err)
+		}
+
+		_, err = b.StartNight("")
+		if err != nil {
+			t.Fatalf("%s: failed to start night: %s", name, err)
+		}
+		archived, err := b.GetNight(1)
+		if err != nil {
+			t.Fatalf("%s: failed to get night: %s", name, err)
+		}
+		if len(archived.Stages) != 1 || archived.Stages[0].Stage != "keller" || len(archived.Stages[0].Queue) != 2 {
+			t.Fatalf("%s: wrong archived stages: %+v", name, archived.Stages)
+		}
+		keller, err = b.GetQueue("keller")
+		if err != nil || len(keller.Queue) != 0 {
+			t.Fatalf("%s: expected empty queue, but got %+v, %v", name, keller, err)
 		}
-		ids[ticket.ID] = struct{}{}
 	}
 }
 
@@ -82,7 +1709,7 @@ func BenchmarkCreateTicket(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		back.CreateTicket()
+		back.CreateTicket(model.DefaultStage)
 	}
 }
 
@@ -93,7 +1720,7 @@ func BenchmarkCreateTicketParallel(b *testing.B) {
 	b.ResetTimer()
 	b.RunParallel(func(pb *testing.PB) {
 		for pb.Next() {
-			back.CreateTicket()
+			back.CreateTicket(model.DefaultStage)
 		}
 	})
 }
@@ -107,7 +1734,7 @@ func BenchmarkGetTicket(b *testing.B) {
 			var ids []model.ID
 
 			for i := 0; i < n; i++ {
-				ticket, err := back.CreateTicket()
+				ticket, _, err := back.CreateTicket(model.DefaultStage)
 				if err != nil {
 					b.Fatalf("failed to create ticket: %s", err)
 				}
@@ -145,7 +1772,7 @@ func BenchmarkGetTicketParallel(b *testing.B) {
 			var ids []model.ID
 
 			for i := 0; i < n; i++ {
-				ticket, err := back.CreateTicket()
+				ticket, _, err := back.CreateTicket(model.DefaultStage)
 				if err != nil {
 					b.Fatalf("failed to create ticket: %s", err)
 				}
@@ -171,7 +1798,82 @@ func BenchmarkGetTicketParallel(b *testing.B) {
 	}
 }
 
+func createTickets(tb testing.TB, b *backend.Backend, n int) []model.ID {
+	ids := make([]model.ID, n)
+	for i := range ids {
+		ticket, _, err := b.CreateTicket(model.DefaultStage)
+		if err != nil {
+			tb.Fatalf("failed to create ticket %d: %s", i, err)
+		}
+		ids[i] = ticket.ID
+	}
+	return ids
+}
+
+func expectQueue(tb testing.TB, b *backend.Backend, expected []model.ID) {
+	queue, err := b.GetQueue(model.DefaultStage)
+	if err != nil {
+		tb.Fatalf("failed to get queue: %s", err)
+	}
+	if !reflect.DeepEqual(expected, queue.Queue) {
+		tb.Fatalf("expected queue %s, but got %s", expected, queue.Queue)
+	}
+}
+
+func expectEvent(tb testing.TB, events <-chan model.Event, typ model.EventType) model.Event {
+	select {
+	case e := <-events:
+		if e.Type != typ {
+			tb.Fatalf("expected event %s, but got %s", typ, e)
+		}
+		return e
+	case <-time.After(time.Second):
+		tb.Fatalf("timed out waiting for event %s", typ)
+		return model.Event{}
+	}
+}
+
+// playSong plays source for the current ticket until it is stopped with the
+// given scores.
+func playSong(tb testing.TB, b *backend.Backend, source string, scores ...model.Score) {
+	for _, s := range []model.State{
+		{Playback: model.Playing, Source: source, Length: time.Minute, Position: 58 * time.Second, Scores: scores},
+		{Playback: model.Stopped},
+	} {
+		err := b.UpdateState(model.DefaultStage, s)
+		if err != nil {
+			tb.Fatalf("failed to update state: %s", err)
+		}
+	}
+}
+
+func expectRankings(tb testing.TB, actual, expected []model.Ranking) {
+	if !reflect.DeepEqual(actual, expected) {
+		tb.Fatalf("expected rankings %v, but got %v", expected, actual)
+	}
+}
+
 func setupDB(tb testing.TB) (*backend.Backend, func()) {
+	logger := newLogger()
+
+	b, err := backend.New(logger, backend.NewMemoryStore())
+	if err != nil {
+		tb.Fatalf("failed to create backend: %s", err)
+	}
+
+	return b, func() {
+		logger.Sync()
+	}
+}
+
+func newLogger() log.Logger {
+	if testing.Verbose() {
+		return log.NewDevelopment()
+	}
+	return log.NullLogger
+}
+
+func setupBolt(tb testing.TB) (*bolt.DB, log.Logger, func()) {
 	dir, err := ioutil.TempDir("", "usdx-queue-test")
 	if err != nil {
 		tb.Fatalf("failed to create temp dir: %s", err)
@@ -187,18 +1889,9 @@ func setupDB(tb testing.TB) (*backend.Backend, func()) {
 	}
 	db.NoSync = true
 
-	logger := log.NullLogger
+	logger := newLogger()
 
-	if testing.Verbose() {
-		logger = log.NewDevelopment()
-	}
-
-	b, err := backend.New(logger, db)
-	if err != nil {
-		tb.Fatalf("failed to create backend: %s", err)
-	}
-
-	return b, func() {
+	return db, logger, func() {
 		err := db.Close()
 		if err != nil {
 			tb.Errorf("error closing bolt db: %v", err)
@@ -1,11 +1,28 @@
 package backend
 
-import "github.com/Patagonicus/usdx-queue/pkg/model"
+import (
+	"encoding/binary"
+	"sort"
+	"time"
+
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
 
 type ticket model.Ticket
 
 func (t ticket) Ticket() model.Ticket {
-	return model.Ticket(t)
+	mt := model.Ticket(t)
+	mt.Stage = t.stage()
+	return mt
+}
+
+// stage returns the stage of the ticket. Tickets created before stages were
+// introduced belong to the default stage.
+func (t ticket) stage() string {
+	if t.Stage == "" {
+		return model.DefaultStage
+	}
+	return t.Stage
 }
 
 type id model.ID
@@ -28,22 +45,218 @@ type pin = model.PIN
 var dontCare = model.DontCare
 
 type queue struct {
+	// Stage is set when the queue is loaded, as queues stored before stages
+	// were introduced do not have one.
+	Stage   string
 	Queue   []id
 	Pos     int
 	Paused  bool
 	Version version
+	// Requests holds the songs requested by tickets.
+	Requests map[id]string
+	// Priorities and Slots hold the reservations of tickets, so that they can
+	// be honoured without loading every ticket.
+	Priorities map[id]int
+	Slots      map[id]int
+	// Held holds tickets without names whose names were rejected by the
+	// scheduling. They are passed over until they get names, see arrange.
+	Held map[id]bool
+	// Statuses holds the status of queued tickets, see putQueue.
+	Statuses map[id]model.StatusChange
 }
 
 func (q queue) ModelQueue() model.Queue {
 	ids := make([]model.ID, len(q.Queue))
+	var requests map[model.ID]string
+	var statuses map[model.ID]model.StatusChange
+	var held map[model.ID]bool
 	for i, id := range q.Queue {
 		ids[i] = model.ID(id)
+		if status, ok := q.Statuses[id]; ok {
+			if statuses == nil {
+				statuses = make(map[model.ID]model.StatusChange, len(q.Queue))
+			}
+			statuses[model.ID(id)] = status
+		}
+		if song, ok := q.Requests[id]; ok {
+			if requests == nil {
+				requests = make(map[model.ID]string)
+			}
+			requests[model.ID(id)] = song
+		}
+		if q.Held[id] {
+			if held == nil {
+				held = make(map[model.ID]bool)
+			}
+			held[model.ID(id)] = true
+		}
 	}
 
 	return model.Queue{
-		ids,
-		q.Pos,
-		q.Paused,
-		q.Version,
+		Stage:      q.Stage,
+		Queue:      ids,
+		Position:   q.Pos,
+		Paused:     q.Paused,
+		Version:    q.Version,
+		Requests:   requests,
+		Priorities: modelReservations(q.Priorities),
+		Slots:      modelReservations(q.Slots),
+		Held:       held,
+		Statuses:   statuses,
+	}
+}
+
+func modelReservations(m map[id]int) map[model.ID]int {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make(map[model.ID]int, len(m))
+	for ticketID, v := range m {
+		result[ticketID.ID()] = v
+	}
+	return result
+}
+
+// Request sets the song requested by a ticket. An empty song removes the
+// request.
+func (q *queue) Request(ticketID id, song string) {
+	if song == "" {
+		delete(q.Requests, ticketID)
+		return
+	}
+	if q.Requests == nil {
+		q.Requests = make(map[id]string)
+	}
+	q.Requests[ticketID] = song
+}
+
+func (q queue) IndexOf(ticketID id) int {
+	for i, id := range q.Queue {
+		if id == ticketID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (q *queue) Move(from, to int) {
+	ticketID := q.Queue[from]
+	if from < to {
+		copy(q.Queue[from:to], q.Queue[from+1:to+1])
+	} else {
+		copy(q.Queue[to+1:from+1], q.Queue[to:from])
+	}
+	q.Queue[to] = ticketID
+}
+
+func (q *queue) Remove(i int) {
+	delete(q.Requests, q.Queue[i])
+	delete(q.Priorities, q.Queue[i])
+	delete(q.Slots, q.Queue[i])
+	delete(q.Held, q.Queue[i])
+	q.Queue = append(q.Queue[:i], q.Queue[i+1:]...)
+}
+
+type pendingAdvance model.PendingAdvance
+
+func (p pendingAdvance) PendingAdvance() model.PendingAdvance {
+	mp := model.PendingAdvance(p)
+	mp.Stage = p.stage()
+	return mp
+}
+
+func (p pendingAdvance) stage() string {
+	if p.Stage == "" {
+		return model.DefaultStage
 	}
+	return p.Stage
+}
+
+// playback is the persisted playback state. Ticket and Started refer to the
+// song that is currently playing, if any.
+type playback struct {
+	State   model.State
+	Ticket  id
+	Started time.Time
+}
+
+type playedSong model.PlayedSong
+
+func (p playedSong) PlayedSong() model.PlayedSong {
+	return model.PlayedSong(p)
+}
+
+// night is the current night. Only Name, Started and Scheduling are set.
+type night struct {
+	Name       string
+	Started    time.Time
+	Scheduling model.Scheduling
+}
+
+// archivedNight is a night that has been closed, including everything needed
+// to look at it later.
+type archivedNight struct {
+	ID      uint64
+	Name    string
+	Started time.Time
+	Closed  time.Time
+	// Scheduling is the scheduling the night ended with.
+	Scheduling model.Scheduling
+	Queue      queue
+	// Stages holds the queues of all stages other than the default one.
+	Stages  map[string]queue
+	Tickets map[id]ticket
+	PINs    map[id]pin
+	History []playedSong
+}
+
+func (n archivedNight) Night() model.Night {
+	return model.Night{
+		ID:          n.ID,
+		Name:        n.Name,
+		Started:     n.Started,
+		Closed:      n.Closed,
+		TicketCount: len(n.Tickets),
+		SongCount:   len(n.History),
+		Scheduling:  scheduling(n.Scheduling),
+	}
+}
+
+func (n archivedNight) ArchivedNight() model.ArchivedNight {
+	a := model.ArchivedNight{
+		Night:   n.Night(),
+		Queue:   n.Queue.ModelQueue(),
+		Tickets: make([]model.Ticket, 0, len(n.Tickets)),
+		History: make([]model.PlayedSong, len(n.History)),
+	}
+	for _, t := range n.Tickets {
+		a.Tickets = append(a.Tickets, t.Ticket())
+	}
+	sort.Slice(a.Tickets, func(i, j int) bool {
+		return ticketLess(a.Tickets[i].ID, a.Tickets[j].ID)
+	})
+	for i, p := range n.History {
+		a.History[i] = p.PlayedSong()
+	}
+	for _, q := range n.Stages {
+		a.Stages = append(a.Stages, q.ModelQueue())
+	}
+	sort.Slice(a.Stages, func(i, j int) bool {
+		return a.Stages[i].Stage < a.Stages[j].Stage
+	})
+	return a
+}
+
+// ticketLess orders ticket IDs numerically.
+func ticketLess(a, b model.ID) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+func sequenceKey(n uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, n)
+	return key
 }
@@ -4,95 +4,62 @@ import (
 	"bytes"
 	"encoding/gob"
 	"fmt"
+	"strings"
 
+	"github.com/Patagonicus/usdx-queue/pkg/model"
 	bolt "github.com/coreos/bbolt"
 )
 
 var (
-	queueBucket   = []byte("queue")
-	ticketsBucket = []byte("tickets")
-	pinsBucket    = []byte("pins")
+	queueBucket       = []byte("queue")
+	ticketsBucket     = []byte("tickets")
+	pinsBucket        = []byte("pins")
+	stateBucket       = []byte("state")
+	songsPlayedBucket = []byte("songs_played")
+	pendingBucket     = []byte("pending_advances")
+	metaBucket        = []byte("meta")
+	nightsBucket      = []byte("nights")
 )
 
-var allBuckets = [][]byte{
-	queueBucket,
-	ticketsBucket,
-	pinsBucket,
-}
-
-var queueKey = []byte("queue")
+var (
+	queueKey         = []byte("queue")
+	playbackKey      = []byte("playback")
+	nightKey         = []byte("night")
+	schemaVersionKey = []byte("schema_version")
+)
 
-type db struct {
+type boltStore struct {
 	db *bolt.DB
 }
 
-func (d db) Init() error {
-	err := d.checkBuckets()
-	if err != nil {
-		return err
-	}
-
-	return d.checkQueue()
+// NewBoltStore stores everything in a bolt database.
+func NewBoltStore(db *bolt.DB) Store {
+	return boltStore{db}
 }
 
-func (d db) checkBuckets() error {
-	err := d.View(func(t tx) error {
-		for _, bucket := range allBuckets {
-			_, err := t.bucket(bucket)
-			if err != nil {
-				return err
-			}
-		}
-		return nil
-	})
-
-	if _, ok := err.(errBucketNotFound); !ok {
-		return err
-	}
-
-	return d.Update(func(t tx) error {
-		for _, bucket := range allBuckets {
-			err := t.createBucketIfNotExist(bucket)
-			if err != nil {
-				return err
-			}
-		}
-		return nil
-	})
-}
-
-func (d db) checkQueue() error {
-	err := d.View(func(t tx) error {
-		_, err := t.GetQueue()
-		return err
-	})
-
-	if _, ok := err.(errKeyNotFound); !ok {
-		return nil
-	}
-
-	return d.Update(func(t tx) error {
-		return t.PutQueue(queue{})
-	})
+// Init brings the database to the latest schema.
+func (d boltStore) Init() error {
+	_, err := migrate(d.db, false)
+	return err
 }
 
-func (d db) View(f func(tx) error) error {
+func (d boltStore) View(f func(Tx) error) error {
 	return d.db.View(func(btx *bolt.Tx) error {
-		return f(tx{btx})
+		return f(boltTx{btx})
 	})
 }
 
-func (d db) Update(f func(tx) error) error {
+func (d boltStore) Update(f func(Tx) error) error {
 	return d.db.Update(func(btx *bolt.Tx) error {
-		return f(tx{btx})
+		return f(boltTx{btx})
 	})
 }
 
-type tx struct {
+type boltTx struct {
 	tx *bolt.Tx
 }
 
-func (t tx) bucket(key []byte) (*bolt.Bucket, error) {
+func (t boltTx) bucket(key []byte) (*bolt.Bucket, error) {
 	bucket := t.tx.Bucket(key)
 	if bucket == nil {
 		return nil, errBucketNotFound{key}
@@ -100,12 +67,12 @@ func (t tx) bucket(key []byte) (*bolt.Bucket, error) {
 	return bucket, nil
 }
 
-func (t tx) createBucketIfNotExist(key []byte) error {
+func (t boltTx) createBucketIfNotExist(key []byte) error {
 	_, err := t.tx.CreateBucketIfNotExists(key)
 	return err
 }
 
-func (t tx) get(bucket, key []byte) ([]byte, error) {
+func (t boltTx) get(bucket, key []byte) ([]byte, error) {
 	b, err := t.bucket(bucket)
 	if err != nil {
 		return nil, err
@@ -119,7 +86,7 @@ func (t tx) get(bucket, key []byte) ([]byte, error) {
 	return data, nil
 }
 
-func (t tx) put(bucket, key, data []byte) error {
+func (t boltTx) put(bucket, key, data []byte) error {
 	b, err := t.bucket(bucket)
 	if err != nil {
 		return err
@@ -128,7 +95,7 @@ func (t tx) put(bucket, key, data []byte) error {
 	return b.Put(key, data)
 }
 
-func (t tx) forEach(bucket []byte, f func(k, v []byte) error) error {
+func (t boltTx) forEach(bucket []byte, f func(k, v []byte) error) error {
 	b, err := t.bucket(bucket)
 	switch err.(type) {
 	case nil:
@@ -140,7 +107,7 @@ func (t tx) forEach(bucket []byte, f func(k, v []byte) error) error {
 	}
 }
 
-func (t tx) NextTicketSequence() (uint64, error) {
+func (t boltTx) NextTicketSequence() (uint64, error) {
 	bucket, err := t.bucket(ticketsBucket)
 	if err != nil {
 		return 0, err
@@ -149,7 +116,7 @@ func (t tx) NextTicketSequence() (uint64, error) {
 	return bucket.NextSequence()
 }
 
-func (t tx) GetTickets() (map[id]ticket, error) {
+func (t boltTx) GetTickets() (map[id]ticket, error) {
 	result := make(map[id]ticket)
 
 	err := t.forEach(ticketsBucket, func(k, v []byte) error {
@@ -164,7 +131,7 @@ func (t tx) GetTickets() (map[id]ticket, error) {
 	return result, err
 }
 
-func (t tx) GetTicket(id id) (ticket, error) {
+func (t boltTx) GetTicket(id id) (ticket, error) {
 	data, err := t.get(ticketsBucket, id.Key())
 	if err != nil {
 		return ticket{}, err
@@ -173,7 +140,7 @@ func (t tx) GetTicket(id id) (ticket, error) {
 	return decodeTicket(data)
 }
 
-func (t tx) PutTicket(ticket ticket) error {
+func (t boltTx) PutTicket(ticket ticket) error {
 	data, err := encode(ticket)
 	if err != nil {
 		return err
@@ -182,7 +149,7 @@ func (t tx) PutTicket(ticket ticket) error {
 	return t.put(ticketsBucket, id(ticket.ID).Key(), data)
 }
 
-func (t tx) GetPINs() (map[id]pin, error) {
+func (t boltTx) GetPINs() (map[id]pin, error) {
 	result := make(map[id]pin)
 
 	err := t.forEach(pinsBucket, func(k, v []byte) error {
@@ -197,7 +164,7 @@ func (t tx) GetPINs() (map[id]pin, error) {
 	return result, err
 }
 
-func (t tx) GetPIN(id id) (pin, error) {
+func (t boltTx) GetPIN(id id) (pin, error) {
 	data, err := t.get(pinsBucket, id.Key())
 	if err != nil {
 		return pin(""), err
@@ -206,7 +173,7 @@ func (t tx) GetPIN(id id) (pin, error) {
 	return decodePin(data)
 }
 
-func (t tx) PutPIN(id id, pin pin) error {
+func (t boltTx) PutPIN(id id, pin pin) error {
 	data, err := encode(pin)
 	if err != nil {
 		return err
@@ -215,22 +182,229 @@ func (t tx) PutPIN(id id, pin pin) error {
 	return t.put(pinsBucket, id.Key(), data)
 }
 
-func (t tx) GetQueue() (queue, error) {
-	data, err := t.get(queueBucket, queueKey)
+// stageKey returns the key of an entry that exists once per stage. The
+// default stage uses the keys from before stages were introduced.
+func stageKey(key []byte, stage string) []byte {
+	if stage == "" || stage == model.DefaultStage {
+		return key
+	}
+	return []byte(string(key) + "/" + stage)
+}
+
+func (t boltTx) GetStages() ([]string, error) {
+	var result []string
+
+	err := t.forEach(queueBucket, func(k, v []byte) error {
+		switch {
+		case bytes.Equal(k, queueKey):
+			result = append(result, model.DefaultStage)
+		case bytes.HasPrefix(k, queueKey):
+			result = append(result, strings.TrimPrefix(string(k), string(queueKey)+"/"))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func (t boltTx) GetQueue(stage string) (queue, error) {
+	data, err := t.get(queueBucket, stageKey(queueKey, stage))
 	if err != nil {
 		return queue{}, err
 	}
 
-	return decodeQueue(data)
+	q, err := decodeQueue(data)
+	q.Stage = stage
+	return q, err
 }
 
-func (t tx) PutQueue(queue queue) error {
+func (t boltTx) PutQueue(queue queue) error {
 	data, err := encode(queue)
 	if err != nil {
 		return err
 	}
 
-	return t.put(queueBucket, queueKey, data)
+	return t.put(queueBucket, stageKey(queueKey, queue.Stage), data)
+}
+
+func (t boltTx) GetPlayback(stage string) (playback, error) {
+	data, err := t.get(stateBucket, stageKey(playbackKey, stage))
+	if err != nil {
+		return playback{}, err
+	}
+
+	return decodePlayback(data)
+}
+
+func (t boltTx) PutPlayback(stage string, p playback) error {
+	data, err := encode(p)
+	if err != nil {
+		return err
+	}
+
+	return t.put(stateBucket, stageKey(playbackKey, stage), data)
+}
+
+func (t boltTx) AddPlayedSong(p playedSong) error {
+	bucket, err := t.bucket(songsPlayedBucket)
+	if err != nil {
+		return err
+	}
+
+	n, err := bucket.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	data, err := encode(p)
+	if err != nil {
+		return err
+	}
+
+	return bucket.Put(sequenceKey(n), data)
+}
+
+func (t boltTx) GetPlayedSongs() ([]playedSong, error) {
+	var result []playedSong
+
+	err := t.forEach(songsPlayedBucket, func(k, v []byte) error {
+		p, err := decodePlayedSong(v)
+		if err != nil {
+			return err
+		}
+		result = append(result, p)
+		return nil
+	})
+
+	return result, err
+}
+
+func (t boltTx) AddPendingAdvance(p pendingAdvance) (pendingAdvance, error) {
+	bucket, err := t.bucket(pendingBucket)
+	if err != nil {
+		return p, err
+	}
+
+	p.ID, err = bucket.NextSequence()
+	if err != nil {
+		return p, err
+	}
+
+	data, err := encode(p)
+	if err != nil {
+		return p, err
+	}
+
+	return p, bucket.Put(sequenceKey(p.ID), data)
+}
+
+func (t boltTx) GetPendingAdvance(n uint64) (pendingAdvance, error) {
+	data, err := t.get(pendingBucket, sequenceKey(n))
+	if err != nil {
+		return pendingAdvance{}, err
+	}
+
+	return decodePendingAdvance(data)
+}
+
+func (t boltTx) GetPendingAdvances() ([]pendingAdvance, error) {
+	var result []pendingAdvance
+
+	err := t.forEach(pendingBucket, func(k, v []byte) error {
+		p, err := decodePendingAdvance(v)
+		if err != nil {
+			return err
+		}
+		result = append(result, p)
+		return nil
+	})
+
+	return result, err
+}
+
+func (t boltTx) DeletePendingAdvance(n uint64) error {
+	bucket, err := t.bucket(pendingBucket)
+	if err != nil {
+		return err
+	}
+
+	return bucket.Delete(sequenceKey(n))
+}
+
+func (t boltTx) GetNight() (night, error) {
+	data, err := t.get(stateBucket, nightKey)
+	if err != nil {
+		return night{}, err
+	}
+
+	return decodeNight(data)
+}
+
+func (t boltTx) PutNight(n night) error {
+	data, err := encode(n)
+	if err != nil {
+		return err
+	}
+
+	return t.put(stateBucket, nightKey, data)
+}
+
+func (t boltTx) ArchiveNight(a archivedNight) (archivedNight, error) {
+	bucket, err := t.bucket(nightsBucket)
+	if err != nil {
+		return a, err
+	}
+
+	a.ID, err = bucket.NextSequence()
+	if err != nil {
+		return a, err
+	}
+
+	data, err := encode(a)
+	if err != nil {
+		return a, err
+	}
+
+	return a, bucket.Put(sequenceKey(a.ID), data)
+}
+
+func (t boltTx) GetArchivedNights() ([]archivedNight, error) {
+	var result []archivedNight
+
+	err := t.forEach(nightsBucket, func(k, v []byte) error {
+		a, err := decodeArchivedNight(v)
+		if err != nil {
+			return err
+		}
+		result = append(result, a)
+		return nil
+	})
+
+	return result, err
+}
+
+func (t boltTx) GetArchivedNight(n uint64) (archivedNight, error) {
+	data, err := t.get(nightsBucket, sequenceKey(n))
+	if err != nil {
+		return archivedNight{}, err
+	}
+
+	return decodeArchivedNight(data)
+}
+
+// ClearNight recreates the buckets, which also resets their sequences.
+func (t boltTx) ClearNight() error {
+	for _, bucket := range [][]byte{ticketsBucket, pinsBucket, songsPlayedBucket, pendingBucket} {
+		err := t.tx.DeleteBucket(bucket)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+		_, err = t.tx.CreateBucket(bucket)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func decodeTicket(data []byte) (ticket, error) {
@@ -251,6 +425,36 @@ func decodeQueue(data []byte) (queue, error) {
 	return queue, err
 }
 
+func decodePlayback(data []byte) (playback, error) {
+	var p playback
+	err := decode(data, &p)
+	return p, err
+}
+
+func decodePlayedSong(data []byte) (playedSong, error) {
+	var p playedSong
+	err := decode(data, &p)
+	return p, err
+}
+
+func decodePendingAdvance(data []byte) (pendingAdvance, error) {
+	var p pendingAdvance
+	err := decode(data, &p)
+	return p, err
+}
+
+func decodeNight(data []byte) (night, error) {
+	var n night
+	err := decode(data, &n)
+	return n, err
+}
+
+func decodeArchivedNight(data []byte) (archivedNight, error) {
+	var a archivedNight
+	err := decode(data, &a)
+	return a, err
+}
+
 func decode(data []byte, v interface{}) error {
 	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
 }
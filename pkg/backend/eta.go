@@ -0,0 +1,87 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+// defaultSongLength is assumed for songs of unknown length as long as nothing
+// has been played yet.
+const defaultSongLength = 4 * time.Minute
+
+// EstimateQueue returns the queue of stage together with the time each
+// upcoming ticket is expected to start singing. length returns the length of
+// a requested song or 0 if it is not known. Tickets without a known song are
+// assumed to take as long as the average played song. changeover is the time
+// between two songs.
+func (b *Backend) EstimateQueue(stage string, changeover time.Duration, length func(song string) time.Duration) (model.Queue, error) {
+	stage = stageName(stage)
+
+	var q queue
+	var played []playedSong
+	err := b.store.View(func(t Tx) error {
+		var err error
+		q, err = getQueue(t, stage)
+		if err != nil {
+			return err
+		}
+
+		played, err = t.GetPlayedSongs()
+		return err
+	})
+	if err != nil {
+		return model.Queue{}, err
+	}
+
+	state, _ := b.getState(stage)
+	mq := q.ModelQueue()
+	mq.ETA = estimate(q, state, averageLength(played), changeover, length, time.Now())
+	return mq, nil
+}
+
+func averageLength(played []playedSong) time.Duration {
+	var sum time.Duration
+	var n int
+	for _, p := range played {
+		if p.Length > 0 {
+			sum += p.Length
+			n++
+		}
+	}
+	if n == 0 {
+		return defaultSongLength
+	}
+	return sum / time.Duration(n)
+}
+
+func estimate(q queue, s model.State, average, changeover time.Duration, length func(song string) time.Duration, now time.Time) map[model.ID]time.Time {
+	if q.Pos < 0 || q.Pos >= len(q.Queue)-1 {
+		return nil
+	}
+
+	songLength := func(ticketID id) time.Duration {
+		if song, ok := q.Requests[ticketID]; ok {
+			if l := length(song); l > 0 {
+				return l
+			}
+		}
+		return average
+	}
+
+	// The current ticket is either singing or about to start.
+	next := now
+	if s.Playback == model.Stopped {
+		next = next.Add(changeover + songLength(q.Queue[q.Pos]))
+	} else if s.Position < s.Length {
+		next = next.Add(s.Length - s.Position)
+	}
+
+	result := make(map[model.ID]time.Time, len(q.Queue)-q.Pos-1)
+	for _, ticketID := range q.Queue[q.Pos+1:] {
+		next = next.Add(changeover)
+		result[ticketID.ID()] = next
+		next = next.Add(songLength(ticketID))
+	}
+	return result
+}
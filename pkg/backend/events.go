@@ -0,0 +1,103 @@
+package backend
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+const (
+	eventHistory    = 256
+	subscriberQueue = 64
+)
+
+// broker distributes events to subscribers. It keeps the most recent events so
+// that subscribers can resume after a reconnect without missing anything.
+//
+// The upper 32 bits of event ids are an epoch that is different for every
+// broker, so that an id from before a restart of the backend is never taken
+// for a current one.
+type broker struct {
+	m       *sync.Mutex
+	lastID  uint64
+	history []model.Event
+	subs    map[chan model.Event]struct{}
+}
+
+func newBroker() *broker {
+	epoch := uint64(uint32(time.Now().UnixNano()))
+	return &broker{
+		m:      new(sync.Mutex),
+		lastID: epoch << 32,
+		subs:   make(map[chan model.Event]struct{}),
+	}
+}
+
+func (b *broker) Publish(e model.Event) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.lastID++
+	e.ID = b.lastID
+
+	b.history = append(b.history, e)
+	if len(b.history) > eventHistory {
+		b.history = b.history[len(b.history)-eventHistory:]
+	}
+
+	for c := range b.subs {
+		select {
+		case c <- e:
+		default:
+			// The subscriber is too slow. Dropping it makes it reconnect and
+			// resume from the last event it received.
+			delete(b.subs, c)
+			close(c)
+		}
+	}
+}
+
+// Subscribe returns a channel receiving all events after lastID. If lastID is
+// 0 only new events are sent. If events after lastID are no longer known, the
+// first event is a resync event.
+func (b *broker) Subscribe(lastID uint64) (<-chan model.Event, func()) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	c := make(chan model.Event, subscriberQueue+eventHistory)
+
+	if lastID > 0 && lastID != b.lastID {
+		missed, ok := b.since(lastID)
+		if ok {
+			for _, e := range missed {
+				c <- e
+			}
+		} else {
+			c <- model.Event{
+				ID:      b.lastID,
+				Type:    model.EventResync,
+				Version: model.DontCare,
+			}
+		}
+	}
+
+	b.subs[c] = struct{}{}
+
+	return c, func() {
+		b.m.Lock()
+		defer b.m.Unlock()
+
+		if _, ok := b.subs[c]; ok {
+			delete(b.subs, c)
+			close(c)
+		}
+	}
+}
+
+func (b *broker) since(lastID uint64) ([]model.Event, bool) {
+	if lastID>>32 != b.lastID>>32 || lastID > b.lastID || len(b.history) == 0 || b.history[0].ID > lastID+1 {
+		return nil, false
+	}
+	return b.history[lastID+1-b.history[0].ID:], true
+}
@@ -0,0 +1,136 @@
+package backend
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+func (b *Backend) GetLeaderboard(f model.LeaderboardFilter) (model.Leaderboard, error) {
+	songs, err := b.GetHistory()
+	if err != nil {
+		return model.Leaderboard{}, err
+	}
+	return leaderboard(songs, f), nil
+}
+
+type tally struct {
+	name  string
+	best  int
+	sum   int
+	count int
+}
+
+func (t *tally) add(score int) {
+	if t.count == 0 || score > t.best {
+		t.best = score
+	}
+	t.sum += score
+	t.count++
+}
+
+func (t tally) Ranking() model.Ranking {
+	return model.Ranking{
+		Name:    t.name,
+		Best:    t.best,
+		Average: t.sum / t.count,
+		Count:   t.count,
+	}
+}
+
+type tallies map[string]*tally
+
+func (t tallies) add(key, name string, score int) {
+	e, ok := t[key]
+	if !ok {
+		e = &tally{name: name}
+		t[key] = e
+	}
+	e.add(score)
+}
+
+func (t tallies) Rankings(by model.RankBy, limit int) []model.Ranking {
+	result := make([]model.Ranking, 0, len(t))
+	for _, e := range t {
+		result = append(result, e.Ranking())
+	}
+
+	less := func(a, b model.Ranking) bool {
+		if a.Best != b.Best {
+			return a.Best > b.Best
+		}
+		if a.Average != b.Average {
+			return a.Average > b.Average
+		}
+		return a.Name < b.Name
+	}
+	if by == model.RankByAverage {
+		less = func(a, b model.Ranking) bool {
+			if a.Average != b.Average {
+				return a.Average > b.Average
+			}
+			if a.Best != b.Best {
+				return a.Best > b.Best
+			}
+			return a.Name < b.Name
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i], result[j])
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// leaderboard ranks singers and songs by the scores in songs. Singers are
+// identified by the names on their ticket, ignoring case. Scores of players
+// without a name only count for the song. Scores of zero are ignored, as
+// nobody sang on that microphone.
+func leaderboard(songs []model.PlayedSong, f model.LeaderboardFilter) model.Leaderboard {
+	singers := make(tallies)
+	sources := make(tallies)
+
+	for _, song := range songs {
+		if !f.Since.IsZero() && song.Finished.Before(f.Since) {
+			continue
+		}
+		if !f.Until.IsZero() && !song.Finished.Before(f.Until) {
+			continue
+		}
+
+		var players int
+		for _, s := range song.Scores {
+			if s.Total() > 0 {
+				players++
+			}
+		}
+		if players == 0 || !f.Mode.Matches(players) {
+			continue
+		}
+
+		for i, s := range song.Scores {
+			if s.Total() <= 0 {
+				continue
+			}
+			sources.add(song.Source, song.Source, s.Total())
+
+			if i >= len(song.Names) {
+				continue
+			}
+			name := strings.TrimSpace(song.Names[i])
+			if name == "" {
+				continue
+			}
+			singers.add(strings.ToLower(name), name, s.Total())
+		}
+	}
+
+	return model.Leaderboard{
+		Singers: singers.Rankings(f.RankBy, f.Limit),
+		Songs:   sources.Rankings(f.RankBy, f.Limit),
+	}
+}
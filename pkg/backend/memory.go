@@ -0,0 +1,358 @@
+package backend
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+// memoryStore keeps everything in memory. It is meant for tests and events
+// that do not need to survive a restart.
+type memoryStore struct {
+	m     *sync.RWMutex
+	state *memoryState
+}
+
+type memoryState struct {
+	ticketSeq  uint64
+	tickets    map[id]ticket
+	pins       map[id]pin
+	queues     map[string]queue
+	playbacks  map[string]playback
+	played     []playedSong
+	pendingSeq uint64
+	pending    map[uint64]pendingAdvance
+	night      *night
+	// nights are never changed after they have been archived, so they are
+	// not copied.
+	nights []archivedNight
+}
+
+func NewMemoryStore() Store {
+	return memoryStore{
+		m: new(sync.RWMutex),
+		state: &memoryState{
+			tickets:   make(map[id]ticket),
+			pins:      make(map[id]pin),
+			queues:    make(map[string]queue),
+			playbacks: make(map[string]playback),
+			pending:   make(map[uint64]pendingAdvance),
+		},
+	}
+}
+
+func (m memoryStore) Init() error {
+	return m.Update(func(t Tx) error {
+		_, err := t.GetQueue(model.DefaultStage)
+		if _, ok := err.(errKeyNotFound); !ok {
+			return err
+		}
+		return t.PutQueue(queue{Stage: model.DefaultStage})
+	})
+}
+
+func (m memoryStore) View(f func(Tx) error) error {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	return f(memoryTx{m.state})
+}
+
+// Update works on a copy of the state, which replaces the state only if f
+// succeeds. Values are copied when they are stored or read, so copying the
+// maps is enough.
+func (m memoryStore) Update(f func(Tx) error) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	next := m.state.clone()
+	err := f(memoryTx{next})
+	if err != nil {
+		return err
+	}
+	*m.state = *next
+	return nil
+}
+
+func (s *memoryState) clone() *memoryState {
+	c := *s
+	c.tickets = make(map[id]ticket, len(s.tickets))
+	for k, v := range s.tickets {
+		c.tickets[k] = v
+	}
+	c.pins = make(map[id]pin, len(s.pins))
+	for k, v := range s.pins {
+		c.pins[k] = v
+	}
+	c.queues = make(map[string]queue, len(s.queues))
+	for k, v := range s.queues {
+		c.queues[k] = v
+	}
+	c.playbacks = make(map[string]playback, len(s.playbacks))
+	for k, v := range s.playbacks {
+		c.playbacks[k] = v
+	}
+	c.played = append([]playedSong(nil), s.played...)
+	c.pending = make(map[uint64]pendingAdvance, len(s.pending))
+	for k, v := range s.pending {
+		c.pending[k] = v
+	}
+	c.nights = append([]archivedNight(nil), s.nights...)
+	return &c
+}
+
+type memoryTx struct {
+	s *memoryState
+}
+
+func (t memoryTx) NextTicketSequence() (uint64, error) {
+	t.s.ticketSeq++
+	return t.s.ticketSeq, nil
+}
+
+func (t memoryTx) GetTickets() (map[id]ticket, error) {
+	result := make(map[id]ticket, len(t.s.tickets))
+	for k, v := range t.s.tickets {
+		result[k] = copyTicket(v)
+	}
+	return result, nil
+}
+
+func (t memoryTx) GetTicket(ticketID id) (ticket, error) {
+	v, ok := t.s.tickets[ticketID]
+	if !ok {
+		return ticket{}, errKeyNotFound{ticketID.Key()}
+	}
+	return copyTicket(v), nil
+}
+
+func (t memoryTx) PutTicket(v ticket) error {
+	t.s.tickets[id(v.ID)] = copyTicket(v)
+	return nil
+}
+
+func (t memoryTx) GetPINs() (map[id]pin, error) {
+	result := make(map[id]pin, len(t.s.pins))
+	for k, v := range t.s.pins {
+		result[k] = v
+	}
+	return result, nil
+}
+
+func (t memoryTx) GetPIN(ticketID id) (pin, error) {
+	v, ok := t.s.pins[ticketID]
+	if !ok {
+		return pin(""), errKeyNotFound{ticketID.Key()}
+	}
+	return v, nil
+}
+
+func (t memoryTx) PutPIN(ticketID id, v pin) error {
+	t.s.pins[ticketID] = v
+	return nil
+}
+
+func (t memoryTx) GetStages() ([]string, error) {
+	var result []string
+	for k := range t.s.queues {
+		result = append(result, k)
+	}
+	return result, nil
+}
+
+func (t memoryTx) GetQueue(stage string) (queue, error) {
+	q, ok := t.s.queues[stage]
+	if !ok {
+		return queue{}, errKeyNotFound{stageKey(queueKey, stage)}
+	}
+	return copyQueue(q), nil
+}
+
+func (t memoryTx) PutQueue(q queue) error {
+	if q.Stage == "" {
+		q.Stage = model.DefaultStage
+	}
+	t.s.queues[q.Stage] = copyQueue(q)
+	return nil
+}
+
+func (t memoryTx) GetPlayback(stage string) (playback, error) {
+	p, ok := t.s.playbacks[stage]
+	if !ok {
+		return playback{}, errKeyNotFound{stageKey(playbackKey, stage)}
+	}
+	return copyPlayback(p), nil
+}
+
+func (t memoryTx) PutPlayback(stage string, p playback) error {
+	t.s.playbacks[stage] = copyPlayback(p)
+	return nil
+}
+
+func (t memoryTx) AddPlayedSong(p playedSong) error {
+	t.s.played = append(t.s.played, copyPlayedSong(p))
+	return nil
+}
+
+func (t memoryTx) GetPlayedSongs() ([]playedSong, error) {
+	var result []playedSong
+	for _, p := range t.s.played {
+		result = append(result, copyPlayedSong(p))
+	}
+	return result, nil
+}
+
+func (t memoryTx) AddPendingAdvance(p pendingAdvance) (pendingAdvance, error) {
+	t.s.pendingSeq++
+	p.ID = t.s.pendingSeq
+	t.s.pending[p.ID] = p
+	return p, nil
+}
+
+func (t memoryTx) GetPendingAdvance(n uint64) (pendingAdvance, error) {
+	p, ok := t.s.pending[n]
+	if !ok {
+		return pendingAdvance{}, errKeyNotFound{sequenceKey(n)}
+	}
+	return p, nil
+}
+
+func (t memoryTx) GetPendingAdvances() ([]pendingAdvance, error) {
+	var result []pendingAdvance
+	for _, p := range t.s.pending {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+func (t memoryTx) DeletePendingAdvance(n uint64) error {
+	delete(t.s.pending, n)
+	return nil
+}
+
+func (t memoryTx) GetNight() (night, error) {
+	if t.s.night == nil {
+		return night{}, errKeyNotFound{nightKey}
+	}
+	return *t.s.night, nil
+}
+
+func (t memoryTx) PutNight(n night) error {
+	t.s.night = &n
+	return nil
+}
+
+func (t memoryTx) ArchiveNight(a archivedNight) (archivedNight, error) {
+	a.ID = uint64(len(t.s.nights) + 1)
+	t.s.nights = append(t.s.nights, a)
+	return a, nil
+}
+
+func (t memoryTx) GetArchivedNights() ([]archivedNight, error) {
+	return append([]archivedNight(nil), t.s.nights...), nil
+}
+
+func (t memoryTx) GetArchivedNight(n uint64) (archivedNight, error) {
+	if n == 0 || n > uint64(len(t.s.nights)) {
+		return archivedNight{}, errKeyNotFound{sequenceKey(n)}
+	}
+	return t.s.nights[n-1], nil
+}
+
+func (t memoryTx) ClearNight() error {
+	t.s.ticketSeq = 0
+	t.s.tickets = make(map[id]ticket)
+	t.s.pins = make(map[id]pin)
+	t.s.played = nil
+	t.s.pendingSeq = 0
+	t.s.pending = make(map[uint64]pendingAdvance)
+	return nil
+}
+
+func copyTicket(t ticket) ticket {
+	t.Names = copyStrings(t.Names)
+	if len(t.StatusHistory) > 0 {
+		t.StatusHistory = append([]model.StatusChange(nil), t.StatusHistory...)
+	}
+	return t
+}
+
+func copyQueue(q queue) queue {
+	if len(q.Queue) > 0 {
+		q.Queue = append([]id(nil), q.Queue...)
+	} else {
+		q.Queue = nil
+	}
+	if len(q.Requests) == 0 {
+		q.Requests = nil
+	} else {
+		requests := make(map[id]string, len(q.Requests))
+		for k, v := range q.Requests {
+			requests[k] = v
+		}
+		q.Requests = requests
+	}
+	q.Priorities = copyReservations(q.Priorities)
+	q.Slots = copyReservations(q.Slots)
+	if len(q.Held) == 0 {
+		q.Held = nil
+	} else {
+		held := make(map[id]bool, len(q.Held))
+		for k, v := range q.Held {
+			held[k] = v
+		}
+		q.Held = held
+	}
+	if len(q.Statuses) == 0 {
+		q.Statuses = nil
+	} else {
+		statuses := make(map[id]model.StatusChange, len(q.Statuses))
+		for k, v := range q.Statuses {
+			statuses[k] = v
+		}
+		q.Statuses = statuses
+	}
+	return q
+}
+
+func copyReservations(m map[id]int) map[id]int {
+	if len(m) == 0 {
+		return nil
+	}
+	c := make(map[id]int, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+func copyPlayback(p playback) playback {
+	p.State.Scores = copyScores(p.State.Scores)
+	return p
+}
+
+func copyPlayedSong(p playedSong) playedSong {
+	p.Names = copyStrings(p.Names)
+	p.Scores = copyScores(p.Scores)
+	return p
+}
+
+// The copy functions return nil for empty slices and maps, like the bolt store
+// does after decoding.
+func copyStrings(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return append([]string(nil), s...)
+}
+
+func copyScores(s []model.Score) []model.Score {
+	if len(s) == 0 {
+		return nil
+	}
+	return append([]model.Score(nil), s...)
+}
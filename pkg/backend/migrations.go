@@ -0,0 +1,341 @@
+package backend
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+	bolt "github.com/coreos/bbolt"
+)
+
+// migration upgrades the database by one schema version. Databases created
+// before schema versions were introduced have version 0 but may already
+// contain some of the changes, so migrations have to be idempotent.
+type migration struct {
+	description string
+	apply       func(t boltTx) error
+}
+
+// migrations are applied in order. The schema version of a database is the
+// number of migrations that have been applied to it. Never change or remove
+// existing migrations, only append new ones.
+var migrations = []migration{
+	{
+		description: "create buckets for tickets, PINs and the queue",
+		apply: func(t boltTx) error {
+			err := createBuckets(t, queueBucket, ticketsBucket, pinsBucket)
+			if err != nil {
+				return err
+			}
+			_, err = t.GetQueue(model.DefaultStage)
+			if _, ok := err.(errKeyNotFound); ok {
+				return t.PutQueue(queue{Stage: model.DefaultStage})
+			}
+			return err
+		},
+	},
+	{
+		description: "create buckets for the playback state and played songs",
+		apply: func(t boltTx) error {
+			return createBuckets(t, stateBucket, songsPlayedBucket)
+		},
+	},
+	{
+		description: "create bucket for pending advances",
+		apply: func(t boltTx) error {
+			return createBuckets(t, pendingBucket)
+		},
+	},
+	{
+		description: "create bucket for archived nights",
+		apply: func(t boltTx) error {
+			return createBuckets(t, nightsBucket)
+		},
+	},
+	{
+		description: "record the status of tickets, replacing the cancelled flag",
+		apply:       backfillStatuses,
+	},
+}
+
+// legacyTicket holds fields that tickets used to have.
+type legacyTicket struct {
+	ID        model.ID
+	Cancelled bool
+}
+
+// backfillStatuses gives tickets and queues stored before statuses were
+// introduced the statuses they would have got, also in archived nights.
+// Records that cannot be decoded are left for Verify to report.
+func backfillStatuses(t boltTx) error {
+	now := time.Now()
+
+	tickets := make(map[id]ticket)
+	cancelled := make(map[id]bool)
+	err := t.forEach(ticketsBucket, func(k, v []byte) error {
+		ticket, err := decodeTicket(v)
+		if err != nil {
+			return nil
+		}
+		var legacy legacyTicket
+		if decode(v, &legacy) == nil {
+			cancelled[idFromKey(k)] = legacy.Cancelled
+		}
+		tickets[idFromKey(k)] = ticket
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	stages, err := t.GetStages()
+	if err != nil {
+		return err
+	}
+	for _, stage := range stages {
+		q, err := t.GetQueue(stage)
+		if err != nil {
+			continue
+		}
+		backfillQueue(&q, tickets, now)
+		err = t.PutQueue(q)
+		if err != nil {
+			return err
+		}
+	}
+	backfillTickets(tickets, cancelled, now)
+	for _, ticket := range tickets {
+		err = t.PutTicket(ticket)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Buckets must not be changed while iterating over them.
+	var nights []archivedNight
+	err = t.forEach(nightsBucket, func(k, v []byte) error {
+		a, err := decodeArchivedNight(v)
+		if err != nil {
+			return nil
+		}
+		var legacy struct {
+			Tickets map[id]legacyTicket
+		}
+		cancelled := make(map[id]bool)
+		if decode(v, &legacy) == nil {
+			for ticketID, ticket := range legacy.Tickets {
+				cancelled[ticketID] = ticket.Cancelled
+			}
+		}
+
+		backfillQueue(&a.Queue, a.Tickets, now)
+		for stage, q := range a.Stages {
+			backfillQueue(&q, a.Tickets, now)
+			a.Stages[stage] = q
+		}
+		backfillTickets(a.Tickets, cancelled, now)
+		nights = append(nights, a)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, a := range nights {
+		data, err := encode(a)
+		if err != nil {
+			return err
+		}
+		err = t.put(nightsBucket, sequenceKey(a.ID), data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillQueue records the status of queued tickets that do not have one in
+// q and in tickets.
+func backfillQueue(q *queue, tickets map[id]ticket, now time.Time) {
+	for i, ticketID := range q.Queue {
+		c, ok := q.Statuses[ticketID]
+		if !ok {
+			c = model.StatusChange{Status: q.statusAt(i), Time: now}
+			if q.Statuses == nil {
+				q.Statuses = make(map[id]model.StatusChange)
+			}
+			q.Statuses[ticketID] = c
+		}
+		backfillTicket(tickets, ticketID, c)
+	}
+}
+
+// backfillTickets gives the remaining tickets without a status one. They are
+// no longer queued, so they have been cancelled, dropped after no-shows or
+// removed by an admin.
+func backfillTickets(tickets map[id]ticket, cancelled map[id]bool, now time.Time) {
+	for ticketID, ticket := range tickets {
+		s := model.StatusSkipped
+		switch {
+		case cancelled[ticketID]:
+			s = model.StatusCancelled
+		case ticket.NoShows > 0:
+			s = model.StatusNoShow
+		}
+		backfillTicket(tickets, ticketID, model.StatusChange{Status: s, Time: now})
+	}
+}
+
+func backfillTicket(tickets map[id]ticket, ticketID id, c model.StatusChange) {
+	ticket, ok := tickets[ticketID]
+	if !ok || ticket.Status != "" {
+		return
+	}
+	ticket.setStatus(c)
+	tickets[ticketID] = ticket
+}
+
+func createBuckets(t boltTx, buckets ...[]byte) error {
+	for _, bucket := range buckets {
+		err := t.createBucketIfNotExist(bucket)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SchemaStatus describes the schema of a bolt database.
+type SchemaStatus struct {
+	Version int
+	Latest  int
+	// Pending describes the migrations that have not been applied yet.
+	Pending []string
+}
+
+type ErrSchemaTooNew struct {
+	Version int
+	Latest  int
+}
+
+func (e ErrSchemaTooNew) Error() string {
+	return fmt.Sprintf("database has schema version %d, but only %d is supported", e.Version, e.Latest)
+}
+
+// CheckSchema reports which migrations would be applied to db without
+// changing it.
+func CheckSchema(db *bolt.DB) (SchemaStatus, error) {
+	return migrate(db, true)
+}
+
+// Migrate applies all pending migrations to db. It returns the status from
+// before the migration.
+func Migrate(db *bolt.DB) (SchemaStatus, error) {
+	return migrate(db, false)
+}
+
+// migrate applies all pending migrations in a single transaction, so the
+// database either has the latest schema afterwards or is left unchanged.
+func migrate(db *bolt.DB, dryRun bool) (SchemaStatus, error) {
+	status := SchemaStatus{
+		Latest: len(migrations),
+	}
+
+	err := db.View(func(btx *bolt.Tx) error {
+		var err error
+		status.Version, err = boltTx{btx}.schemaVersion()
+		return err
+	})
+	if err != nil {
+		return status, err
+	}
+	if status.Version > status.Latest {
+		return status, ErrSchemaTooNew{status.Version, status.Latest}
+	}
+	for _, m := range migrations[status.Version:] {
+		status.Pending = append(status.Pending, m.description)
+	}
+	if dryRun || len(status.Pending) == 0 {
+		return status, nil
+	}
+
+	return status, db.Update(func(btx *bolt.Tx) error {
+		t := boltTx{btx}
+		for i := status.Version; i < len(migrations); i++ {
+			err := migrations[i].apply(t)
+			if err != nil {
+				return fmt.Errorf("migration to version %d failed: %s", i+1, err)
+			}
+		}
+		return t.putSchemaVersion(len(migrations))
+	})
+}
+
+func (t boltTx) schemaVersion() (int, error) {
+	data, err := t.get(metaBucket, schemaVersionKey)
+	switch err.(type) {
+	case nil:
+	case errBucketNotFound, errKeyNotFound:
+		return 0, nil
+	default:
+		return 0, err
+	}
+	if len(data) != 8 {
+		return 0, fmt.Errorf("invalid schema version: %v", data)
+	}
+	return int(binary.BigEndian.Uint64(data)), nil
+}
+
+func (t boltTx) putSchemaVersion(v int) error {
+	err := t.createBucketIfNotExist(metaBucket)
+	if err != nil {
+		return err
+	}
+	return t.put(metaBucket, schemaVersionKey, sequenceKey(uint64(v)))
+}
+
+// Verify decodes every record in db and returns the errors it encountered.
+func Verify(db *bolt.DB) []error {
+	var errs []error
+	report := func(what string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s", what, err))
+		}
+	}
+
+	err := db.View(func(btx *bolt.Tx) error {
+		t := boltTx{btx}
+		for _, c := range []struct {
+			bucket []byte
+			decode func([]byte) error
+		}{
+			{queueBucket, func(v []byte) error { _, err := decodeQueue(v); return err }},
+			{ticketsBucket, func(v []byte) error { _, err := decodeTicket(v); return err }},
+			{pinsBucket, func(v []byte) error { _, err := decodePin(v); return err }},
+			{songsPlayedBucket, func(v []byte) error { _, err := decodePlayedSong(v); return err }},
+			{pendingBucket, func(v []byte) error { _, err := decodePendingAdvance(v); return err }},
+			{nightsBucket, func(v []byte) error { _, err := decodeArchivedNight(v); return err }},
+		} {
+			t.forEach(c.bucket, func(k, v []byte) error {
+				report(fmt.Sprintf("%s %x", c.bucket, k), c.decode(v))
+				return nil
+			})
+		}
+
+		// The state bucket holds different types, so only the playback of
+		// each stage is checked. Missing entries are created when needed.
+		stages, err := t.GetStages()
+		report("stages", err)
+		for _, stage := range stages {
+			_, err = t.GetPlayback(stage)
+			switch err.(type) {
+			case errKeyNotFound, errBucketNotFound:
+			default:
+				report(fmt.Sprintf("playback of %s", stage), err)
+			}
+		}
+		return nil
+	})
+	report("database", err)
+	return errs
+}
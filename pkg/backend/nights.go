@@ -0,0 +1,196 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+// StartNight archives the tickets, PINs, queues and played songs of the
+// current night under name and starts a new night with empty queues and the
+// same scheduling. Ticket IDs start at 1 again. If name is empty, the date the night started
+// is used.
+func (b *Backend) StartNight(name string) (model.Night, error) {
+	var archived archivedNight
+	var queues []queue
+	b.updateM.Lock()
+	defer b.updateM.Unlock()
+	err := b.store.Update(func(t Tx) error {
+		current, err := t.GetNight()
+		if _, ok := err.(errKeyNotFound); !ok && err != nil {
+			return err
+		}
+
+		archived = archivedNight{
+			Name:       name,
+			Started:    current.Started,
+			Closed:     time.Now(),
+			Scheduling: current.Scheduling,
+		}
+		if archived.Name == "" {
+			archived.Name = current.Name
+		}
+		if archived.Name == "" && !current.Started.IsZero() {
+			archived.Name = current.Started.Format("2006-01-02")
+		}
+
+		archived.Tickets, err = t.GetTickets()
+		if err != nil {
+			return err
+		}
+		archived.PINs, err = t.GetPINs()
+		if err != nil {
+			return err
+		}
+		stages, err := getStages(t)
+		if err != nil {
+			return err
+		}
+		for _, stage := range stages {
+			q, err := t.GetQueue(stage)
+			if err != nil {
+				return err
+			}
+			if stage == model.DefaultStage {
+				archived.Queue = q
+				continue
+			}
+			if archived.Stages == nil {
+				archived.Stages = make(map[string]queue)
+			}
+			archived.Stages[stage] = q
+		}
+		archived.History, err = t.GetPlayedSongs()
+		if err != nil {
+			return err
+		}
+
+		archived, err = t.ArchiveNight(archived)
+		if err != nil {
+			return err
+		}
+
+		err = t.ClearNight()
+		if err != nil {
+			return err
+		}
+
+		for _, stage := range stages {
+			q := archived.Stages[stage]
+			if stage == model.DefaultStage {
+				q = archived.Queue
+			}
+			q = queue{Stage: stage, Version: q.Version + 1}
+			err = t.PutQueue(q)
+			if err != nil {
+				return err
+			}
+			queues = append(queues, q)
+
+			// A song that is still playing does not belong to any ticket of
+			// the new night.
+			p, err := t.GetPlayback(stage)
+			switch err.(type) {
+			case nil:
+				p.Ticket = ""
+				err = t.PutPlayback(stage, p)
+				if err != nil {
+					return err
+				}
+			case errKeyNotFound:
+			default:
+				return err
+			}
+		}
+
+		return t.PutNight(night{Started: archived.Closed, Scheduling: current.Scheduling})
+	})
+	if err != nil {
+		return model.Night{}, err
+	}
+
+	// Ticket ids start over, so lockouts of old tickets must not carry over
+	// to new ones.
+	b.attempts.reset()
+
+	n := archived.Night()
+	b.l.Info("started new night",
+		log.Stringer("archived", n),
+	)
+	b.events.Publish(model.Event{
+		Type:    model.EventNightStarted,
+		Version: model.DontCare,
+		Night:   &n,
+	})
+	for _, q := range queues {
+		b.publishQueue(model.EventQueueChanged, q)
+	}
+	return n, nil
+}
+
+// GetNights lists all archived nights, oldest first, followed by the current
+// night with ID 0.
+func (b *Backend) GetNights() ([]model.Night, error) {
+	var result []model.Night
+	err := b.store.View(func(t Tx) error {
+		archived, err := t.GetArchivedNights()
+		if err != nil {
+			return err
+		}
+		for _, a := range archived {
+			result = append(result, a.Night())
+		}
+
+		current, err := t.GetNight()
+		if _, ok := err.(errKeyNotFound); !ok && err != nil {
+			return err
+		}
+		tickets, err := t.GetTickets()
+		if err != nil {
+			return err
+		}
+		played, err := t.GetPlayedSongs()
+		if err != nil {
+			return err
+		}
+		result = append(result, model.Night{
+			Name:        current.Name,
+			Started:     current.Started,
+			TicketCount: len(tickets),
+			SongCount:   len(played),
+			Scheduling:  scheduling(current.Scheduling),
+		})
+		return nil
+	})
+	return result, err
+}
+
+func (b *Backend) GetNight(n uint64) (model.ArchivedNight, error) {
+	var archived archivedNight
+	err := b.store.View(func(t Tx) error {
+		var err error
+		archived, err = t.GetArchivedNight(n)
+		return err
+	})
+	if _, ok := err.(errKeyNotFound); ok {
+		return model.ArchivedNight{}, ErrNightDoesNotExist{n}
+	}
+	if err != nil {
+		return model.ArchivedNight{}, err
+	}
+
+	return archived.ArchivedNight(), nil
+}
+
+// initNight records when the current night started, if that is not known
+// yet.
+func (b *Backend) initNight() error {
+	return b.store.Update(func(t Tx) error {
+		_, err := t.GetNight()
+		if _, ok := err.(errKeyNotFound); ok {
+			return t.PutNight(night{Started: time.Now()})
+		}
+		return err
+	})
+}
@@ -0,0 +1,104 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+// NoShowPolicy says what happens to the current ticket if its singers do not
+// show up.
+type NoShowPolicy struct {
+	// Defer is the number of positions the ticket is moved back.
+	Defer int
+	// Max is the number of no-shows after which the ticket is removed from
+	// the queue. 0 never removes it.
+	Max int
+}
+
+// Drops tells whether a ticket with that many no-shows is removed from the
+// queue.
+func (p NoShowPolicy) Drops(noShows int) bool {
+	return p.Max > 0 && noShows >= p.Max
+}
+
+// NoShow records that the singers of the current ticket of stage did not show
+// up. The ticket is moved back by p.Defer positions, or removed from the queue
+// once it has p.Max no-shows. It returns the updated ticket.
+func (b *Backend) NoShow(stage string, p NoShowPolicy, v model.Version) (model.Ticket, error) {
+	var ticket ticket
+	var queue queue
+	b.updateM.Lock()
+	defer b.updateM.Unlock()
+	err := b.store.Update(func(t Tx) error {
+		var err error
+		queue, err = getQueue(t, stage)
+		if err != nil {
+			return err
+		}
+
+		err = checkVersion(v, queue.Version)
+		if err != nil {
+			return err
+		}
+
+		if queue.Paused || queue.Pos >= len(queue.Queue) {
+			return ErrInvalidQueueMovement
+		}
+
+		ticketID := queue.Queue[queue.Pos]
+		ticket, err = t.GetTicket(ticketID)
+		if _, ok := err.(errKeyNotFound); ok {
+			return ErrTicketDoesNotExist{ticketID.ID()}
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		ticket.NoShows++
+		ticket.setStatus(model.StatusChange{Status: model.StatusNoShow, Time: now})
+		ticket.Version++
+		if p.Drops(ticket.NoShows) {
+			queue.Remove(queue.Pos)
+		} else {
+			if p.Defer <= 0 || queue.Pos >= len(queue.Queue)-1 {
+				return ErrInvalidQueueMovement
+			}
+
+			position := queue.Pos + p.Defer
+			if position >= len(queue.Queue) {
+				position = len(queue.Queue) - 1
+			}
+			queue.release(ticketID)
+			queue.Move(queue.Pos, position)
+		}
+		queue.Version++
+
+		err = t.PutTicket(ticket)
+		if err != nil {
+			return err
+		}
+		err = putQueue(t, &queue, now)
+		if err != nil {
+			return err
+		}
+
+		// Deferred tickets are waiting again.
+		ticket, err = t.GetTicket(ticketID)
+		return err
+	})
+	if err != nil {
+		return model.Ticket{}, err
+	}
+
+	b.l.Info("singers did not show up",
+		log.Stringer("ticket", ticket.Ticket()),
+		log.Int("noShows", ticket.NoShows),
+		log.Bool("dropped", p.Drops(ticket.NoShows)),
+	)
+	b.publishTicket(model.EventTicketNoShow, ticket)
+	b.publishQueue(model.EventQueueChanged, queue)
+	return ticket.Ticket(), nil
+}
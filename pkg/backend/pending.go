@@ -0,0 +1,154 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+// ProposeAdvance records an advance that has to be confirmed by an admin. The
+// ID and creation time of p are set by the backend.
+func (b *Backend) ProposeAdvance(p model.PendingAdvance) (model.PendingAdvance, error) {
+	pending := pendingAdvance(p)
+	pending.Stage = stageName(p.Stage)
+	pending.Created = time.Now()
+
+	b.updateM.Lock()
+	defer b.updateM.Unlock()
+	err := b.store.Update(func(t Tx) error {
+		_, err := getQueue(t, pending.Stage)
+		if err != nil {
+			return err
+		}
+
+		pending, err = t.AddPendingAdvance(pending)
+		return err
+	})
+	if err != nil {
+		return model.PendingAdvance{}, err
+	}
+
+	b.l.Info("advance pending",
+		log.Stringer("pending", pending.PendingAdvance()),
+	)
+	b.publishPending(model.EventAdvancePending, pending)
+	return pending.PendingAdvance(), nil
+}
+
+func (b *Backend) GetPendingAdvances() ([]model.PendingAdvance, error) {
+	var pending []pendingAdvance
+	err := b.store.View(func(t Tx) error {
+		var err error
+		pending, err = t.GetPendingAdvances()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.PendingAdvance, len(pending))
+	for i, p := range pending {
+		result[i] = p.PendingAdvance()
+	}
+	return result, nil
+}
+
+// getPendingAdvance returns pending advance n. Unless stage is empty, the
+// advance has to belong to it.
+func getPendingAdvance(t Tx, stage string, n uint64) (pendingAdvance, error) {
+	pending, err := t.GetPendingAdvance(n)
+	if err != nil {
+		return pending, err
+	}
+	if stage != "" && pending.stage() != stageName(stage) {
+		return pending, ErrPendingAdvanceDoesNotExist{n}
+	}
+	return pending, nil
+}
+
+// ConfirmAdvance advances the queue for a pending advance. This fails with
+// ErrInvalidQueueMovement if the queue has moved on since the advance was
+// proposed. Unless stage is empty, only advances of that stage are found.
+func (b *Backend) ConfirmAdvance(stage string, n uint64, v model.Version) error {
+	var pending pendingAdvance
+	var queue queue
+	b.updateM.Lock()
+	defer b.updateM.Unlock()
+	err := b.store.Update(func(t Tx) error {
+		var err error
+		pending, err = getPendingAdvance(t, stage, n)
+		if err != nil {
+			return err
+		}
+
+		queue, err = getQueue(t, pending.stage())
+		if err != nil {
+			return err
+		}
+
+		err = checkVersion(v, queue.Version)
+		if err != nil {
+			return err
+		}
+
+		if queue.Paused || queue.Pos >= len(queue.Queue)-1 || queue.Queue[queue.Pos] != id(pending.Ticket) {
+			return ErrInvalidQueueMovement
+		}
+
+		queue.Pos++
+		queue.Version++
+		err = putQueue(t, &queue, time.Now())
+		if err != nil {
+			return err
+		}
+
+		return t.DeletePendingAdvance(n)
+	})
+	if _, ok := err.(errKeyNotFound); ok {
+		return ErrPendingAdvanceDoesNotExist{n}
+	}
+	if err != nil {
+		return err
+	}
+
+	b.publishQueue(model.EventQueueChanged, queue)
+	b.publishPending(model.EventAdvanceResolved, pending)
+	return nil
+}
+
+// RejectAdvance removes a pending advance without changing the queue. Unless
+// stage is empty, only advances of that stage are found.
+func (b *Backend) RejectAdvance(stage string, n uint64) error {
+	var pending pendingAdvance
+	b.updateM.Lock()
+	defer b.updateM.Unlock()
+	err := b.store.Update(func(t Tx) error {
+		var err error
+		pending, err = getPendingAdvance(t, stage, n)
+		if err != nil {
+			return err
+		}
+
+		return t.DeletePendingAdvance(n)
+	})
+	if _, ok := err.(errKeyNotFound); ok {
+		return ErrPendingAdvanceDoesNotExist{n}
+	}
+	if err != nil {
+		return err
+	}
+
+	b.publishPending(model.EventAdvanceResolved, pending)
+	return nil
+}
+
+func (b *Backend) publishPending(typ model.EventType, p pendingAdvance) {
+	mp := p.PendingAdvance()
+	b.events.Publish(model.Event{
+		Type:    typ,
+		Version: model.DontCare,
+		Stage:   mp.Stage,
+		Pending: &mp,
+	})
+}
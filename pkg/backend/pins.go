@@ -0,0 +1,277 @@
+package backend
+
+import (
+	"crypto/rand"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+// PINPolicy says how PINs are generated and how failed attempts to enter them
+// are throttled.
+type PINPolicy struct {
+	// Length and Alphabet of new PINs. Existing PINs are kept.
+	Length   int
+	Alphabet string
+	// FreeAttempts is the number of failed attempts per ticket and per
+	// source that are allowed before they are locked.
+	FreeAttempts int
+	// Lockout is the time a ticket or source is locked after the first
+	// failed attempt that is not free. It doubles with every further failed
+	// attempt, up to MaxLockout. Failures are forgotten after MaxLockout
+	// without a failed attempt.
+	Lockout    time.Duration
+	MaxLockout time.Duration
+}
+
+var DefaultPINPolicy = PINPolicy{
+	Length:       4,
+	Alphabet:     "0123456789",
+	FreeAttempts: 5,
+	Lockout:      30 * time.Second,
+	MaxLockout:   time.Hour,
+}
+
+func (p PINPolicy) validate() error {
+	if p.Length < 1 || p.FreeAttempts < 0 || p.Lockout <= 0 || p.MaxLockout < p.Lockout {
+		return ErrInvalidPINPolicy{p}
+	}
+	seen := make(map[rune]bool)
+	for _, r := range p.Alphabet {
+		if seen[r] {
+			return ErrInvalidPINPolicy{p}
+		}
+		seen[r] = true
+	}
+	if len(seen) < 2 {
+		return ErrInvalidPINPolicy{p}
+	}
+	return nil
+}
+
+func (p PINPolicy) generate() (pin, error) {
+	alphabet := []rune(p.Alphabet)
+	max := big.NewInt(int64(len(alphabet)))
+	result := make([]rune, p.Length)
+	for i := range result {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		result[i] = alphabet[n.Int64()]
+	}
+	return pin(result), nil
+}
+
+// lockout returns how long something with that many failed attempts is
+// locked.
+func (p PINPolicy) lockout(failures int) time.Duration {
+	n := failures - p.FreeAttempts
+	if n <= 0 {
+		return 0
+	}
+	d := p.Lockout
+	for i := 1; i < n && d < p.MaxLockout; i++ {
+		d *= 2
+	}
+	if d > p.MaxLockout {
+		d = p.MaxLockout
+	}
+	return d
+}
+
+// SetPINPolicy changes how PINs are generated and checked. It should be
+// called before the backend is used.
+func (b *Backend) SetPINPolicy(p PINPolicy) error {
+	err := p.validate()
+	if err != nil {
+		return err
+	}
+
+	b.attempts.m.Lock()
+	defer b.attempts.m.Unlock()
+	b.attempts.policy = p
+	return nil
+}
+
+type failures struct {
+	count int
+	last  time.Time
+	until time.Time
+}
+
+// attempts counts failed attempts to enter a PIN, per ticket and per source.
+// They are only kept in memory.
+type attempts struct {
+	m       sync.Mutex
+	policy  PINPolicy
+	tickets map[id]*failures
+	sources map[string]*failures
+}
+
+func newAttempts() *attempts {
+	return &attempts{
+		policy:  DefaultPINPolicy,
+		tickets: make(map[id]*failures),
+		sources: make(map[string]*failures),
+	}
+}
+
+func (a *attempts) generate() (pin, error) {
+	a.m.Lock()
+	p := a.policy
+	a.m.Unlock()
+	return p.generate()
+}
+
+// attempt lets verify compare a PIN unless the ticket or the source are
+// locked, and counts a wrong PIN. The lock is held throughout, so that
+// concurrent attempts cannot all pass the check before any of them is
+// counted.
+func (a *attempts) attempt(ticketID id, source string, now time.Time, verify func() (bool, error)) error {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	err := a.check(ticketID, source, now)
+	if err != nil {
+		return err
+	}
+
+	ok, err := verify()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		a.fail(ticketID, source, now)
+		return ErrUnauthorized
+	}
+	// Failures of the source are kept, as it could own a ticket to reset
+	// its counter with.
+	delete(a.tickets, ticketID)
+	return nil
+}
+
+// check returns ErrLocked if the ticket or the source are locked. a.m has to
+// be held.
+func (a *attempts) check(ticketID id, source string, now time.Time) error {
+	var until time.Time
+	var locked string
+	if f, ok := a.tickets[ticketID]; ok && f.until.After(until) {
+		until = f.until
+	}
+	if f, ok := a.sources[source]; ok && source != "" && f.until.After(until) {
+		until = f.until
+		locked = source
+	}
+	if until.After(now) {
+		return ErrLocked{ticketID.ID(), locked, until.Sub(now)}
+	}
+	return nil
+}
+
+// fail records a failed attempt. a.m has to be held.
+func (a *attempts) fail(ticketID id, source string, now time.Time) {
+	a.prune(now)
+	a.tickets[ticketID] = a.failed(a.tickets[ticketID], now)
+	if source != "" {
+		a.sources[source] = a.failed(a.sources[source], now)
+	}
+}
+
+func (a *attempts) failed(f *failures, now time.Time) *failures {
+	if f == nil {
+		f = new(failures)
+	}
+	f.count++
+	f.last = now
+	f.until = now.Add(a.policy.lockout(f.count))
+	return f
+}
+
+// forget forgets the failed attempts for a ticket.
+func (a *attempts) forget(ticketID id) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	delete(a.tickets, ticketID)
+}
+
+// reset forgets the failed attempts for all tickets, for example because
+// ticket ids start over. Failures of sources are kept.
+func (a *attempts) reset() {
+	a.m.Lock()
+	defer a.m.Unlock()
+	a.tickets = make(map[id]*failures)
+}
+
+// prune forgets failures that are older than MaxLockout.
+func (a *attempts) prune(now time.Time) {
+	stale := func(f *failures) bool {
+		return now.Sub(f.last) > a.policy.MaxLockout && !f.until.After(now)
+	}
+	for k, f := range a.tickets {
+		if stale(f) {
+			delete(a.tickets, k)
+		}
+	}
+	for k, f := range a.sources {
+		if stale(f) {
+			delete(a.sources, k)
+		}
+	}
+}
+
+func (a *attempts) lockouts(now time.Time) []model.Lockout {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	a.prune(now)
+	var result []model.Lockout
+	for k, f := range a.tickets {
+		if f.until.After(now) {
+			result = append(result, model.Lockout{Ticket: k.ID(), Failures: f.count, Until: f.until})
+		}
+	}
+	for k, f := range a.sources {
+		if f.until.After(now) {
+			result = append(result, model.Lockout{Source: k, Failures: f.count, Until: f.until})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Until.Before(result[j].Until)
+	})
+	return result
+}
+
+// verifyPIN checks p against the PIN of a ticket, counting failed attempts.
+// source identifies where the attempt came from, usually an IP address.
+func (b *Backend) verifyPIN(t Tx, ticketID id, p pin, source string) error {
+	err := b.attempts.attempt(ticketID, source, time.Now(), func() (bool, error) {
+		storedPIN, err := t.GetPIN(ticketID)
+		if err != nil {
+			return false, err
+		}
+		return storedPIN == p, nil
+	})
+	if err == ErrUnauthorized {
+		b.l.Info("wrong PIN",
+			log.String("ticket", string(ticketID)),
+			log.String("source", source),
+		)
+	}
+	return err
+}
+
+// Lockouts lists the tickets and sources that are currently locked because of
+// too many wrong PINs.
+func (b *Backend) Lockouts() []model.Lockout {
+	return b.attempts.lockouts(time.Now())
+}
+
+// Unlock forgets the failed attempts for a ticket.
+func (b *Backend) Unlock(ticketID model.ID) {
+	b.attempts.forget(id(ticketID))
+}
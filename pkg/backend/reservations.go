@@ -0,0 +1,107 @@
+package backend
+
+import (
+	"sort"
+
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+// insert adds a new ticket to the queue. Tickets with a priority go behind
+// the current ticket and the waiting tickets with at least the same priority,
+// all others to the end. Tickets with a reserved slot are then moved to it.
+func (q *queue) insert(ticketID id, r model.Reservation) {
+	i := len(q.Queue)
+	if r.Priority > 0 {
+		if q.Pos+1 < i {
+			i = q.Pos + 1
+		}
+		for i < len(q.Queue) && q.Priorities[q.Queue[i]] >= r.Priority {
+			i++
+		}
+		if q.Priorities == nil {
+			q.Priorities = make(map[id]int)
+		}
+		q.Priorities[ticketID] = r.Priority
+	}
+	if r.Slot > 0 {
+		if q.Slots == nil {
+			q.Slots = make(map[id]int)
+		}
+		q.Slots[ticketID] = r.Slot
+	}
+
+	q.Queue = append(q.Queue, "")
+	copy(q.Queue[i+1:], q.Queue[i:])
+	q.Queue[i] = ticketID
+	q.arrange()
+}
+
+// reserved tells whether a ticket is placed by its reservation instead of the
+// scheduling.
+func (q queue) reserved(ticketID id) bool {
+	return q.Priorities[ticketID] > 0 || q.Slots[ticketID] > 0
+}
+
+// release gives up the reserved slot of a ticket, so that it can be moved
+// elsewhere.
+func (q *queue) release(ticketID id) {
+	delete(q.Slots, ticketID)
+}
+
+// hold keeps a ticket in the queue, but passes it over until unhold is
+// called.
+func (q *queue) hold(ticketID id) {
+	if q.Held == nil {
+		q.Held = make(map[id]bool)
+	}
+	q.Held[ticketID] = true
+}
+
+func (q *queue) unhold(ticketID id) {
+	delete(q.Held, ticketID)
+}
+
+// arrange moves waiting tickets with a reserved slot to it or, if there are
+// not enough tickets in front of it yet, to the end. Held tickets that would
+// be next are moved behind the first ticket that is not held. It returns
+// whether the order changed.
+func (q *queue) arrange() bool {
+	start := q.Pos + 1
+	if (len(q.Slots) == 0 && len(q.Held) == 0) || start >= len(q.Queue) {
+		return false
+	}
+
+	var reserved, rest []id
+	for _, ticketID := range q.Queue[start:] {
+		if _, ok := q.Slots[ticketID]; ok {
+			reserved = append(reserved, ticketID)
+		} else {
+			rest = append(rest, ticketID)
+		}
+	}
+	for i, ticketID := range rest {
+		if !q.Held[ticketID] {
+			copy(rest[1:i+1], rest[:i])
+			rest[0] = ticketID
+			break
+		}
+	}
+	sort.SliceStable(reserved, func(i, j int) bool {
+		return q.Slots[reserved[i]] < q.Slots[reserved[j]]
+	})
+
+	changed := false
+	for i := start; i < len(q.Queue); i++ {
+		var next id
+		if len(reserved) > 0 && (len(rest) == 0 || q.Slots[reserved[0]] <= i+1) {
+			next, reserved = reserved[0], reserved[1:]
+		} else {
+			next, rest = rest[0], rest[1:]
+		}
+		if q.Queue[i] != next {
+			q.Queue[i] = next
+			changed = true
+		}
+	}
+	return changed
+}
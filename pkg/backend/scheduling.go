@@ -0,0 +1,201 @@
+package backend
+
+import (
+	"strings"
+
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+// scheduler decides where a ticket goes once the names of its singers are
+// known. Tickets are created without names, so they are appended to the queue
+// first and placed when the names are set.
+type scheduler interface {
+	// place returns the index the ticket at i is moved to. Tickets up to
+	// current have been sung or are being sung, so only later indexes may be
+	// returned.
+	place(tickets []model.Ticket, current, i int) (int, error)
+}
+
+func newScheduler(s model.Scheduling) (scheduler, error) {
+	switch s.Policy {
+	case "", model.FIFO:
+		return fifo{}, nil
+	case model.RoundRobin:
+		return roundRobin{}, nil
+	case model.MaxTickets:
+		if s.MaxTickets <= 0 {
+			return nil, ErrInvalidScheduling{s.Policy}
+		}
+		return maxTickets{s.MaxTickets}, nil
+	default:
+		return nil, ErrInvalidScheduling{s.Policy}
+	}
+}
+
+// scheduling returns s with the default policy filled in.
+func scheduling(s model.Scheduling) model.Scheduling {
+	if s.Policy == "" {
+		s.Policy = model.FIFO
+	}
+	return s
+}
+
+type fifo struct{}
+
+func (fifo) place(tickets []model.Ticket, current, i int) (int, error) {
+	return i, nil
+}
+
+// roundRobin groups the queue into rounds: a ticket is in round n if one of
+// its singers has n tickets before it. A ticket is placed behind all tickets
+// of its singers and all tickets of its round or an earlier one, but before
+// tickets of its round that were queued after it.
+type roundRobin struct{}
+
+func (roundRobin) place(tickets []model.Ticket, current, i int) (int, error) {
+	names := singers(tickets[i])
+	others := make([]model.Ticket, 0, len(tickets)-1)
+	others = append(others, tickets[:i]...)
+	others = append(others, tickets[i+1:]...)
+
+	counts := make(map[string]int)
+	last := current
+	for j, other := range others {
+		for _, name := range singers(other) {
+			counts[name]++
+			if contains(names, name) && j > last {
+				last = j
+			}
+		}
+	}
+	round := 0
+	for _, name := range names {
+		if counts[name] > round {
+			round = counts[name]
+		}
+	}
+
+	seen := make(map[string]int)
+	for j, other := range others {
+		otherNames := singers(other)
+		otherRound := 0
+		for _, name := range otherNames {
+			if seen[name] > otherRound {
+				otherRound = seen[name]
+			}
+			seen[name]++
+		}
+
+		if j > last && (otherRound > round || (otherRound == round && ticketLess(tickets[i].ID, other.ID))) {
+			return j, nil
+		}
+	}
+	return len(others), nil
+}
+
+// maxTickets keeps the order, but rejects tickets of singers who already have
+// max tickets waiting.
+type maxTickets struct {
+	max int
+}
+
+func (m maxTickets) place(tickets []model.Ticket, current, i int) (int, error) {
+	counts := make(map[string]int)
+	for _, ticket := range tickets[current+1:] {
+		for _, name := range singers(ticket) {
+			counts[name]++
+		}
+	}
+
+	for _, name := range singers(tickets[i]) {
+		if counts[name] > m.max {
+			return 0, ErrTooManyTickets{name, m.max}
+		}
+	}
+	return i, nil
+}
+
+// singers returns the names on a ticket, normalized for comparison.
+func singers(t model.Ticket) []string {
+	var names []string
+	for _, name := range t.Names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" && !contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// schedule returns the index the ticket at i in q is moved to under the
+// scheduling of the current night.
+func schedule(t Tx, q queue, i int) (int, error) {
+	n, err := t.GetNight()
+	if _, ok := err.(errKeyNotFound); !ok && err != nil {
+		return 0, err
+	}
+
+	s, err := newScheduler(n.Scheduling)
+	if err != nil {
+		return 0, err
+	}
+
+	tickets := make([]model.Ticket, len(q.Queue))
+	for j, ticketID := range q.Queue {
+		ticket, err := t.GetTicket(ticketID)
+		switch err.(type) {
+		case nil:
+			tickets[j] = ticket.Ticket()
+		case errKeyNotFound:
+			tickets[j].ID = ticketID.ID()
+		default:
+			return 0, err
+		}
+	}
+
+	// Tickets with a priority stay in front.
+	current := q.Pos
+	for current+1 < i && q.Priorities[q.Queue[current+1]] > 0 {
+		current++
+	}
+
+	return s.place(tickets, current, i)
+}
+
+// SetScheduling changes the scheduling of the current night. It applies to
+// tickets whose names are set afterwards, the queue is not reordered.
+func (b *Backend) SetScheduling(s model.Scheduling) error {
+	_, err := newScheduler(s)
+	if err != nil {
+		return err
+	}
+
+	err = b.store.Update(func(t Tx) error {
+		n, err := t.GetNight()
+		if _, ok := err.(errKeyNotFound); !ok && err != nil {
+			return err
+		}
+
+		n.Scheduling = s
+		return t.PutNight(n)
+	})
+	if err != nil {
+		return err
+	}
+
+	b.l.Info("changed scheduling",
+		log.String("policy", string(s.Policy)),
+		log.Int("maxTickets", s.MaxTickets),
+	)
+	return nil
+}
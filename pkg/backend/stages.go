@@ -0,0 +1,135 @@
+package backend
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+// stageName returns the stage clients mean if they do not name one.
+func stageName(stage string) string {
+	if stage == "" {
+		return model.DefaultStage
+	}
+	return stage
+}
+
+// getQueue returns the queue of stage, which has to exist.
+func getQueue(t Tx, stage string) (queue, error) {
+	stage = stageName(stage)
+	q, err := t.GetQueue(stage)
+	if _, ok := err.(errKeyNotFound); ok {
+		return q, ErrStageDoesNotExist{stage}
+	}
+	return q, err
+}
+
+// getStages returns the names of all stages, the default stage first.
+func getStages(t Tx) ([]string, error) {
+	stages, err := t.GetStages()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(stages, func(i, j int) bool {
+		if stages[i] == model.DefaultStage || stages[j] == model.DefaultStage {
+			return stages[i] == model.DefaultStage
+		}
+		return stages[i] < stages[j]
+	})
+	return stages, nil
+}
+
+func (q queue) ModelStage() model.Stage {
+	waiting := len(q.Queue) - q.Pos - 1
+	if waiting < 0 {
+		waiting = 0
+	}
+	return model.Stage{
+		Name:    q.Stage,
+		Waiting: waiting,
+		Paused:  q.Paused,
+	}
+}
+
+// shortestStage returns the stage a new ticket will be sung soonest on.
+// Paused stages are only used if all stages are paused.
+func shortestStage(t Tx) (string, error) {
+	stages, err := getStages(t)
+	if err != nil {
+		return "", err
+	}
+
+	best := model.DefaultStage
+	var bestLeft int
+	var bestPaused bool
+	for i, stage := range stages {
+		q, err := t.GetQueue(stage)
+		if err != nil {
+			return "", err
+		}
+
+		// Tickets that have not been sung yet, including the current one.
+		left := len(q.Queue) - q.Pos
+		if i == 0 || (bestPaused && !q.Paused) || (bestPaused == q.Paused && left < bestLeft) {
+			best, bestLeft, bestPaused = stage, left, q.Paused
+		}
+	}
+	return best, nil
+}
+
+// GetStages lists all stages, the default stage first.
+func (b *Backend) GetStages() ([]model.Stage, error) {
+	var result []model.Stage
+	err := b.store.View(func(t Tx) error {
+		stages, err := getStages(t)
+		if err != nil {
+			return err
+		}
+
+		for _, stage := range stages {
+			q, err := t.GetQueue(stage)
+			if err != nil {
+				return err
+			}
+			result = append(result, q.ModelStage())
+		}
+		return nil
+	})
+	return result, err
+}
+
+// CreateStage adds a stage with an empty queue.
+func (b *Backend) CreateStage(name string) (model.Stage, error) {
+	if name == "" || strings.Contains(name, "/") {
+		return model.Stage{}, ErrInvalidStageName{name}
+	}
+
+	q := queue{Stage: name}
+	b.updateM.Lock()
+	defer b.updateM.Unlock()
+	err := b.store.Update(func(t Tx) error {
+		_, err := t.GetQueue(name)
+		switch err.(type) {
+		case nil:
+			return ErrStageExists{name}
+		case errKeyNotFound:
+		default:
+			return err
+		}
+
+		return t.PutQueue(q)
+	})
+	if err != nil {
+		return model.Stage{}, err
+	}
+
+	b.l.Info("created stage",
+		log.String("stage", name),
+	)
+	b.setState(name, model.State{})
+	b.publishQueue(model.EventStageCreated, q)
+	return q.ModelStage(), nil
+}
@@ -0,0 +1,95 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+// statusAt returns the status of the ticket at index i of the queue.
+func (q queue) statusAt(i int) model.TicketStatus {
+	switch {
+	case i < q.Pos:
+		return model.StatusSung
+	case i == q.Pos:
+		return model.StatusOnStage
+	default:
+		return model.StatusWaiting
+	}
+}
+
+// setStatus records a status change. It returns false if the ticket already
+// has that status.
+func (t *ticket) setStatus(c model.StatusChange) bool {
+	if t.Status == c.Status {
+		return false
+	}
+	t.Status = c.Status
+	t.StatusHistory = append(t.StatusHistory, c)
+	return true
+}
+
+// final tells whether a ticket left the queue for a reason the caller already
+// recorded.
+func (t ticket) final() bool {
+	return t.Status == model.StatusCancelled || t.Status == model.StatusNoShow
+}
+
+// putQueue stores q after moving tickets with a reserved slot back to it and
+// updating the status of its tickets according to their position. Tickets
+// that have been removed from the queue are skipped, unless they already got a
+// final status. Every write of a changed queue has to go through it.
+func putQueue(t Tx, q *queue, now time.Time) error {
+	q.arrange()
+
+	queued := make(map[id]bool, len(q.Queue))
+	for i, ticketID := range q.Queue {
+		queued[ticketID] = true
+		s := q.statusAt(i)
+		if q.Statuses[ticketID].Status == s {
+			continue
+		}
+
+		c := model.StatusChange{Status: s, Time: now}
+		if q.Statuses == nil {
+			q.Statuses = make(map[id]model.StatusChange)
+		}
+		q.Statuses[ticketID] = c
+		err := updateStatus(t, ticketID, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	for ticketID := range q.Statuses {
+		if queued[ticketID] {
+			continue
+		}
+		delete(q.Statuses, ticketID)
+		err := updateStatus(t, ticketID, model.StatusChange{Status: model.StatusSkipped, Time: now})
+		if err != nil {
+			return err
+		}
+	}
+
+	return t.PutQueue(*q)
+}
+
+func updateStatus(t Tx, ticketID id, c model.StatusChange) error {
+	ticket, err := t.GetTicket(ticketID)
+	if _, ok := err.(errKeyNotFound); ok {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.Status == model.StatusSkipped && ticket.final() {
+		return nil
+	}
+	if !ticket.setStatus(c) {
+		return nil
+	}
+	ticket.Version++
+	return t.PutTicket(ticket)
+}
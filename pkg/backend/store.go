@@ -0,0 +1,54 @@
+package backend
+
+// Store persists tickets, PINs, the queue and the playback history of a
+// Backend. All access happens in transactions: changes made in Update are
+// discarded if f returns an error.
+//
+// Getters return errKeyNotFound if the requested entry does not exist.
+type Store interface {
+	// Init prepares the store for use, creating an empty queue for
+	// the default stage if necessary.
+	Init() error
+	View(f func(Tx) error) error
+	Update(f func(Tx) error) error
+}
+
+// Tx is a transaction of a Store.
+type Tx interface {
+	NextTicketSequence() (uint64, error)
+	GetTickets() (map[id]ticket, error)
+	GetTicket(id id) (ticket, error)
+	PutTicket(ticket ticket) error
+
+	GetPINs() (map[id]pin, error)
+	GetPIN(id id) (pin, error)
+	PutPIN(id id, pin pin) error
+
+	// GetStages lists the names of all stages in no particular order.
+	GetStages() ([]string, error)
+	GetQueue(stage string) (queue, error)
+	// PutQueue stores q as the queue of q.Stage, creating the stage if it
+	// does not exist.
+	PutQueue(q queue) error
+
+	GetPlayback(stage string) (playback, error)
+	PutPlayback(stage string, p playback) error
+	AddPlayedSong(p playedSong) error
+	GetPlayedSongs() ([]playedSong, error)
+
+	// AddPendingAdvance stores p with a new ID and returns it.
+	AddPendingAdvance(p pendingAdvance) (pendingAdvance, error)
+	GetPendingAdvance(n uint64) (pendingAdvance, error)
+	GetPendingAdvances() ([]pendingAdvance, error)
+	DeletePendingAdvance(n uint64) error
+
+	GetNight() (night, error)
+	PutNight(n night) error
+	// ArchiveNight stores a with a new ID and returns it.
+	ArchiveNight(a archivedNight) (archivedNight, error)
+	GetArchivedNights() ([]archivedNight, error)
+	GetArchivedNight(n uint64) (archivedNight, error)
+	// ClearNight removes all tickets, PINs, played songs and pending advances
+	// and restarts ticket IDs at 1.
+	ClearNight() error
+}
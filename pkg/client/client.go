@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 	"time"
 
 	"github.com/Patagonicus/usdx-queue/pkg/auth"
@@ -18,7 +19,43 @@ import (
 	"github.com/Patagonicus/usdx-queue/pkg/model"
 )
 
-var ErrPINInvalid = errors.New("PIN invalid")
+var (
+	ErrPINInvalid      = errors.New("PIN invalid")
+	ErrVersionConflict = errors.New("version conflict")
+	// ErrTooManyTickets is returned if a singer on the ticket already has as
+	// many tickets in the queue as the scheduling allows.
+	ErrTooManyTickets = errors.New("too many tickets")
+	// ErrTicketCancelled is returned for tickets that have been given up.
+	ErrTicketCancelled = errors.New("ticket cancelled")
+	// ErrTicketSung is returned when cancelling a ticket that has already
+	// been sung.
+	ErrTicketSung = errors.New("ticket already sung")
+)
+
+// ErrLocked is returned if too many wrong PINs have been entered for the
+// ticket or from the same address.
+type ErrLocked struct {
+	RetryAfter time.Duration
+}
+
+func (e ErrLocked) Error() string {
+	return fmt.Sprintf("too many wrong PINs, retry after %s", e.RetryAfter)
+}
+
+// lockedError returns ErrLocked for responses with status 429.
+func lockedError(s status, headers map[string][]string) error {
+	if s.Code != http.StatusTooManyRequests {
+		return nil
+	}
+	var e ErrLocked
+	if h, ok := headers["Retry-After"]; ok && len(h) > 0 {
+		seconds, err := strconv.Atoi(h[0])
+		if err == nil {
+			e.RetryAfter = time.Duration(seconds) * time.Second
+		}
+	}
+	return e
+}
 
 type Client struct {
 	c          *http.Client
@@ -48,6 +85,19 @@ func NewWithPub(l log.Logger, address, pub *url.URL, token auth.Token) Client {
 	}
 }
 
+// WithSource returns a client that tells the backend that requests with a PIN
+// come from addr, so that wrong PINs are counted for it instead of for this
+// service.
+func (c Client) WithSource(addr string) Client {
+	headers := make(map[string][]string, len(c.headers)+1)
+	for k, v := range c.headers {
+		headers[k] = v
+	}
+	headers["X-Forwarded-For"] = []string{addr}
+	c.headers = headers
+	return c
+}
+
 func (c Client) do(req *http.Request) (status, map[string][]string, body, error) {
 	c.l.Debug("executing request",
 		log.Stringer("request", req.URL),
@@ -82,6 +132,28 @@ func (c Client) patch(url *url.URL, header map[string][]string, b io.Reader) (st
 	return c.do(req)
 }
 
+func (c Client) del(url *url.URL, header map[string][]string, b io.Reader) (status, map[string][]string, body, error) {
+	req, err := http.NewRequest("DELETE", url.String(), b)
+	if err != nil {
+		return status{}, nil, body{}, err
+	}
+	req.Header = header
+	return c.do(req)
+}
+
+func (c Client) versionHeaders(v model.Version) map[string][]string {
+	if v == model.DontCare {
+		return c.headers
+	}
+
+	headers := make(map[string][]string, len(c.headers)+1)
+	for k, v := range c.headers {
+		headers[k] = v
+	}
+	headers["If-Match"] = []string{strconv.Quote(strconv.FormatInt(int64(v), 10))}
+	return headers
+}
+
 func (c Client) getURL(p string) *url.URL {
 	url := new(url.URL)
 	*url = *c.address
@@ -89,35 +161,66 @@ func (c Client) getURL(p string) *url.URL {
 	return url
 }
 
-func (c Client) CreateTicket() (model.ID, model.PIN, error) {
-	status, headers, body, err := c.post(c.getURL("/v1/tickets"), c.headers, nil)
+// CreateTicket creates a ticket on the stage the client is bound to or, if it
+// is not bound to one, on the stage with the shortest queue. The returned
+// ticket only has its ID and stage set.
+func (c Client) CreateTicket() (model.Ticket, model.PIN, error) {
+	return c.createTicket(nil)
+}
+
+// CreateReservedTicket creates a ticket that is placed ahead of the normal
+// order. This requires an admin token.
+func (c Client) CreateReservedTicket(r model.Reservation) (model.Ticket, model.PIN, error) {
+	data, err := json.Marshal(r)
 	if err != nil {
-		return model.ID(""), model.PIN(""), err
+		return model.Ticket{}, model.PIN(""), err
+	}
+	return c.createTicket(bytes.NewReader(data))
+}
+
+func (c Client) createTicket(b io.Reader) (model.Ticket, model.PIN, error) {
+	status, headers, body, err := c.post(c.getURL("/v1/tickets"), c.headers, b)
+	if err != nil {
+		return model.Ticket{}, model.PIN(""), err
 	}
 	defer body.Close()
 
 	if !status.IsSuccess() {
-		return model.ID(""), model.PIN(""), fmt.Errorf("no success creating ticket: %d, %s", status.Code, status.Reason)
+		return model.Ticket{}, model.PIN(""), fmt.Errorf("no success creating ticket: %d, %s", status.Code, status.Reason)
 	}
 
 	var response struct {
-		PIN model.PIN
+		PIN   model.PIN
+		Stage string
 	}
 	err = json.NewDecoder(body).Decode(&response)
 	if err != nil {
-		return model.ID(""), model.PIN(""), err
+		return model.Ticket{}, model.PIN(""), err
 	}
 
 	location, ok := headers["Location"]
 	if !ok || len(location) != 1 {
-		return model.ID(""), model.PIN(""), fmt.Errorf("error getting ID of new ticket")
+		return model.Ticket{}, model.PIN(""), fmt.Errorf("error getting ID of new ticket")
 	}
 
-	return model.ID(location[0]), response.PIN, nil
+	return model.Ticket{
+		ID:    model.ID(location[0]),
+		Stage: response.Stage,
+	}, response.PIN, nil
 }
 
 func (c Client) GetQueue() (model.Queue, error) {
-	status, _, body, err := c.get(c.getURL("/v1/queue"), c.headers)
+	return c.getQueue(c.getURL("/v1/queue"))
+}
+
+// GetStageQueue returns the queue of a stage instead of the one the client is
+// bound to.
+func (c Client) GetStageQueue(stage string) (model.Queue, error) {
+	return c.getQueue(c.getURL("/v1/stages/" + url.PathEscape(stage) + "/queue"))
+}
+
+func (c Client) getQueue(u *url.URL) (model.Queue, error) {
+	status, _, body, err := c.get(u, c.headers)
 	c.l.Debug("got queue",
 		log.Any("status", status),
 		log.Error(err),
@@ -185,6 +288,140 @@ func (c Client) GetState() (model.State, error) {
 	return state, err
 }
 
+func (c Client) GetStages() ([]model.Stage, error) {
+	status, _, body, err := c.get(c.getURL("/v1/stages"), c.headers)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return nil, fmt.Errorf("could not get stages: %d, %s", status.Code, status.Reason)
+	}
+
+	var stages []model.Stage
+	err = json.NewDecoder(body).Decode(&stages)
+	return stages, err
+}
+
+func (c Client) CreateStage(name string) (model.Stage, error) {
+	data, err := json.Marshal(struct {
+		Name string `json:"name"`
+	}{name})
+	if err != nil {
+		return model.Stage{}, err
+	}
+
+	status, _, body, err := c.post(c.getURL("/v1/stages"), c.headers, bytes.NewReader(data))
+	if err != nil {
+		return model.Stage{}, err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return model.Stage{}, fmt.Errorf("could not create stage: %d, %s", status.Code, status.Reason)
+	}
+
+	var stage model.Stage
+	err = json.NewDecoder(body).Decode(&stage)
+	return stage, err
+}
+
+func (c Client) GetHistory() ([]model.PlayedSong, error) {
+	status, _, body, err := c.get(c.getURL("/v1/history"), c.headers)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return nil, fmt.Errorf("could not get history: %d, %s", status.Code, status.Reason)
+	}
+
+	var songs []model.PlayedSong
+	err = json.NewDecoder(body).Decode(&songs)
+	return songs, err
+}
+
+func (c Client) GetLeaderboard(f model.LeaderboardFilter) (model.Leaderboard, error) {
+	query := make(url.Values)
+	if !f.Since.IsZero() {
+		query.Set("since", f.Since.Format(time.RFC3339))
+	}
+	if !f.Until.IsZero() {
+		query.Set("until", f.Until.Format(time.RFC3339))
+	}
+	if f.Mode != model.AnyMode {
+		query.Set("mode", string(f.Mode))
+	}
+	if f.RankBy != "" {
+		query.Set("sort", string(f.RankBy))
+	}
+	if f.Limit > 0 {
+		query.Set("limit", strconv.Itoa(f.Limit))
+	}
+
+	url := c.getURL("/v1/leaderboard")
+	url.RawQuery = query.Encode()
+	status, _, body, err := c.get(url, c.headers)
+	if err != nil {
+		return model.Leaderboard{}, err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return model.Leaderboard{}, fmt.Errorf("could not get leaderboard: %d, %s", status.Code, status.Reason)
+	}
+
+	var board model.Leaderboard
+	err = json.NewDecoder(body).Decode(&board)
+	return board, err
+}
+
+func (c Client) SearchSongs(f model.SongFilter) (model.SongPage, error) {
+	query := make(url.Values)
+	for k, v := range map[string]string{
+		"q":        f.Query,
+		"language": f.Language,
+		"genre":    f.Genre,
+		"sort":     string(f.Sort),
+		"cursor":   f.Cursor,
+	} {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+	for k, v := range map[string]int{
+		"min_year": f.MinYear,
+		"max_year": f.MaxYear,
+		"limit":    f.Limit,
+	} {
+		if v > 0 {
+			query.Set(k, strconv.Itoa(v))
+		}
+	}
+	// Without any parameter the backend returns all songs instead of a page.
+	if len(query) == 0 {
+		query.Set("limit", "0")
+	}
+
+	url := c.getURL("/v1/songs")
+	url.RawQuery = query.Encode()
+	status, _, body, err := c.get(url, c.headers)
+	if err != nil {
+		return model.SongPage{}, err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return model.SongPage{}, fmt.Errorf("could not search songs: %d, %s", status.Code, status.Reason)
+	}
+
+	var page model.SongPage
+	err = json.NewDecoder(body).Decode(&page)
+	return page, err
+}
+
 func (c Client) SetState(state model.State) error {
 	data, err := json.Marshal(state)
 	if err != nil {
@@ -203,45 +440,356 @@ func (c Client) SetState(state model.State) error {
 	return nil
 }
 
-func (c Client) Advance() error {
-	status, _, body, err := c.post(c.getURL("/v1/queue/actions/advance"), c.headers, nil)
+func (c Client) ReportAnomaly(a model.Anomaly) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	status, _, body, err := c.post(c.getURL("/v1/anomalies"), c.headers, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return fmt.Errorf("could not report anomaly: %d, %s", status.Code, status.Reason)
+	}
+	return nil
+}
+
+func (c Client) Advance(v model.Version) error {
+	status, headers, body, err := c.post(c.getURL("/v1/queue/actions/advance"), c.versionHeaders(v), nil)
 	if err != nil {
 		return err
 	}
 	defer body.Close()
 
+	if isVersionConflict(status, headers) {
+		return ErrVersionConflict
+	}
 	if !status.IsSuccess() {
 		return fmt.Errorf("could not advance: %d, %s", status.Code, status.Reason)
 	}
 	return nil
 }
 
-func (c Client) GoBack() error {
-	status, _, body, err := c.post(c.getURL("/v1/queue/actions/goback"), c.headers, nil)
+func (c Client) GoBack(v model.Version) error {
+	status, headers, body, err := c.post(c.getURL("/v1/queue/actions/goback"), c.versionHeaders(v), nil)
 	if err != nil {
 		return err
 	}
 	defer body.Close()
 
+	if isVersionConflict(status, headers) {
+		return ErrVersionConflict
+	}
 	if !status.IsSuccess() {
 		return fmt.Errorf("could not go back: %d, %s", status.Code, status.Reason)
 	}
 	return nil
 }
 
-func (c Client) TogglePause() error {
-	status, _, body, err := c.post(c.getURL("/v1/queue/actions/pause"), c.headers, nil)
+func (c Client) TogglePause(v model.Version) error {
+	status, headers, body, err := c.post(c.getURL("/v1/queue/actions/pause"), c.versionHeaders(v), nil)
 	if err != nil {
 		return err
 	}
 	defer body.Close()
 
+	if isVersionConflict(status, headers) {
+		return ErrVersionConflict
+	}
 	if !status.IsSuccess() {
 		return fmt.Errorf("could not toggle pause: %d, %s", status.Code, status.Reason)
 	}
 	return nil
 }
 
+// NoShow reports that the singers of the current ticket did not show up. It
+// returns the updated ticket and whether it was removed from the queue.
+func (c Client) NoShow(v model.Version) (model.Ticket, bool, error) {
+	status, headers, body, err := c.post(c.getURL("/v1/queue/actions/noshow"), c.versionHeaders(v), nil)
+	if err != nil {
+		return model.Ticket{}, false, err
+	}
+	defer body.Close()
+
+	if isVersionConflict(status, headers) {
+		return model.Ticket{}, false, ErrVersionConflict
+	}
+	if !status.IsSuccess() {
+		return model.Ticket{}, false, fmt.Errorf("could not report no-show: %d, %s", status.Code, status.Reason)
+	}
+
+	var result struct {
+		Ticket  model.Ticket `json:"ticket"`
+		Dropped bool         `json:"dropped"`
+	}
+	err = json.NewDecoder(body).Decode(&result)
+	return result.Ticket, result.Dropped, err
+}
+
+func (c Client) MoveTicket(id model.ID, position int, v model.Version) error {
+	data, err := json.Marshal(struct {
+		Position int `json:"position"`
+	}{
+		Position: position,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := c.getURL("/v1/queue/" + url.PathEscape(string(id)) + "/actions/move")
+	status, headers, body, err := c.post(url, c.versionHeaders(v), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if isVersionConflict(status, headers) {
+		return ErrVersionConflict
+	}
+	if !status.IsSuccess() {
+		return fmt.Errorf("could not move ticket: %d, %s", status.Code, status.Reason)
+	}
+	return nil
+}
+
+func (c Client) RemoveTicket(id model.ID, v model.Version) error {
+	url := c.getURL("/v1/queue/" + url.PathEscape(string(id)))
+	status, headers, body, err := c.del(url, c.versionHeaders(v), nil)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if isVersionConflict(status, headers) {
+		return ErrVersionConflict
+	}
+	if !status.IsSuccess() {
+		return fmt.Errorf("could not remove ticket: %d, %s", status.Code, status.Reason)
+	}
+	return nil
+}
+
+func (c Client) Defer(id model.ID, n int, v model.Version) error {
+	data, err := json.Marshal(struct {
+		By int `json:"by"`
+	}{
+		By: n,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := c.getURL("/v1/queue/" + url.PathEscape(string(id)) + "/actions/defer")
+	status, headers, body, err := c.post(url, c.versionHeaders(v), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if isVersionConflict(status, headers) {
+		return ErrVersionConflict
+	}
+	if !status.IsSuccess() {
+		return fmt.Errorf("could not defer ticket: %d, %s", status.Code, status.Reason)
+	}
+	return nil
+}
+
+func (c Client) ProposeAdvance(p model.PendingAdvance) (model.PendingAdvance, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return model.PendingAdvance{}, err
+	}
+
+	status, _, body, err := c.post(c.getURL("/v1/queue/pending"), c.headers, bytes.NewReader(data))
+	if err != nil {
+		return model.PendingAdvance{}, err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return model.PendingAdvance{}, fmt.Errorf("could not propose advance: %d, %s", status.Code, status.Reason)
+	}
+
+	var pending model.PendingAdvance
+	err = json.NewDecoder(body).Decode(&pending)
+	return pending, err
+}
+
+func (c Client) GetPendingAdvances() ([]model.PendingAdvance, error) {
+	status, _, body, err := c.get(c.getURL("/v1/queue/pending"), c.headers)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return nil, fmt.Errorf("could not get pending advances: %d, %s", status.Code, status.Reason)
+	}
+
+	var pending []model.PendingAdvance
+	err = json.NewDecoder(body).Decode(&pending)
+	return pending, err
+}
+
+func (c Client) ConfirmAdvance(n uint64, v model.Version) error {
+	url := c.getURL("/v1/queue/pending/" + strconv.FormatUint(n, 10) + "/actions/confirm")
+	status, headers, body, err := c.post(url, c.versionHeaders(v), nil)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if isVersionConflict(status, headers) {
+		return ErrVersionConflict
+	}
+	if !status.IsSuccess() {
+		return fmt.Errorf("could not confirm advance: %d, %s", status.Code, status.Reason)
+	}
+	return nil
+}
+
+func (c Client) RejectAdvance(n uint64) error {
+	url := c.getURL("/v1/queue/pending/" + strconv.FormatUint(n, 10) + "/actions/reject")
+	status, _, body, err := c.post(url, c.headers, nil)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return fmt.Errorf("could not reject advance: %d, %s", status.Code, status.Reason)
+	}
+	return nil
+}
+
+// GetLockouts lists the tickets and addresses that are locked because of too
+// many wrong PINs.
+func (c Client) GetLockouts() ([]model.Lockout, error) {
+	status, _, body, err := c.get(c.getURL("/v1/lockouts"), c.headers)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return nil, fmt.Errorf("could not get lockouts: %d, %s", status.Code, status.Reason)
+	}
+
+	var lockouts []model.Lockout
+	err = json.NewDecoder(body).Decode(&lockouts)
+	return lockouts, err
+}
+
+// UnlockTicket forgets the wrong PINs entered for a ticket.
+func (c Client) UnlockTicket(id model.ID) error {
+	url := c.getURL("/v1/tickets/" + url.PathEscape(string(id)) + "/actions/unlock")
+	status, _, body, err := c.post(url, c.headers, nil)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return fmt.Errorf("could not unlock ticket: %d, %s", status.Code, status.Reason)
+	}
+	return nil
+}
+
+func (c Client) ReloadSongs() error {
+	status, _, body, err := c.post(c.getURL("/v1/songs/actions/reload"), c.headers, nil)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return fmt.Errorf("could not reload songs: %d, %s", status.Code, status.Reason)
+	}
+	return nil
+}
+
+// StartNight archives the current night under name and starts a new one.
+func (c Client) StartNight(name string) (model.Night, error) {
+	data, err := json.Marshal(struct {
+		Name string `json:"name,omitempty"`
+	}{name})
+	if err != nil {
+		return model.Night{}, err
+	}
+
+	status, _, body, err := c.post(c.getURL("/v1/nights"), c.headers, bytes.NewReader(data))
+	if err != nil {
+		return model.Night{}, err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return model.Night{}, fmt.Errorf("could not start night: %d, %s", status.Code, status.Reason)
+	}
+
+	var night model.Night
+	err = json.NewDecoder(body).Decode(&night)
+	return night, err
+}
+
+// SetScheduling changes the scheduling of the current night.
+func (c Client) SetScheduling(s model.Scheduling) error {
+	data, err := json.Marshal(struct {
+		Scheduling model.Scheduling `json:"scheduling"`
+	}{s})
+	if err != nil {
+		return err
+	}
+
+	status, _, body, err := c.patch(c.getURL("/v1/nights/0"), c.headers, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return fmt.Errorf("could not set scheduling: %d, %s", status.Code, status.Reason)
+	}
+	return nil
+}
+
+func (c Client) GetNights() ([]model.Night, error) {
+	status, _, body, err := c.get(c.getURL("/v1/nights"), c.headers)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return nil, fmt.Errorf("could not get nights: %d, %s", status.Code, status.Reason)
+	}
+
+	var nights []model.Night
+	err = json.NewDecoder(body).Decode(&nights)
+	return nights, err
+}
+
+func (c Client) GetNight(n uint64) (model.ArchivedNight, error) {
+	status, _, body, err := c.get(c.getURL("/v1/nights/"+strconv.FormatUint(n, 10)), c.headers)
+	if err != nil {
+		return model.ArchivedNight{}, err
+	}
+	defer body.Close()
+
+	if !status.IsSuccess() {
+		return model.ArchivedNight{}, fmt.Errorf("could not get night: %d, %s", status.Code, status.Reason)
+	}
+
+	var night model.ArchivedNight
+	err = json.NewDecoder(body).Decode(&night)
+	return night, err
+}
+
 func (c Client) GetCoverURL(source string) string {
 	return fmt.Sprintf("%s/v1/songs/%s/cover", c.pubAddress, base64.StdEncoding.EncodeToString([]byte(source)))
 }
@@ -263,7 +811,11 @@ func (c Client) GetSongs() ([]model.Song, error) {
 }
 
 func (c Client) GetSong(source string) (model.Song, error) {
-	url := c.getURL("/v1/songs/" + url.PathEscape(base64.StdEncoding.EncodeToString([]byte(source))))
+	return c.GetSongByID(base64.StdEncoding.EncodeToString([]byte(source)))
+}
+
+func (c Client) GetSongByID(id string) (model.Song, error) {
+	url := c.getURL("/v1/songs/" + url.PathEscape(id))
 	status, _, body, err := c.get(url, c.headers)
 	if err != nil {
 		return model.Song{}, err
@@ -292,12 +844,21 @@ func (c Client) SetNames(id model.ID, pin model.PIN, names []string) error {
 	}
 
 	url := c.getURL("/v1/tickets/" + url.PathEscape(string(id)))
-	status, _, body, err := c.patch(url, c.headers, bytes.NewReader(data))
+	status, headers, body, err := c.patch(url, c.headers, bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
 	defer body.Close()
 
+	if err := lockedError(status, headers); err != nil {
+		return err
+	}
+	if status.Code == http.StatusUnprocessableEntity {
+		return ErrTooManyTickets
+	}
+	if status.Code == http.StatusGone {
+		return ErrTicketCancelled
+	}
 	if !status.IsSuccess() {
 		return fmt.Errorf("failed to set names: %d, %s", status.Code, status.Reason)
 	}
@@ -317,6 +878,143 @@ func (c Client) SetNames(id model.ID, pin model.PIN, names []string) error {
 	return nil
 }
 
+// CancelTicket gives up the ticket, so that the queue continues without it.
+// Tickets that have already been sung cannot be cancelled.
+func (c Client) CancelTicket(id model.ID, pin model.PIN) error {
+	data, err := json.Marshal(struct {
+		PIN string `json:"pin"`
+	}{
+		PIN: string(pin),
+	})
+	if err != nil {
+		return err
+	}
+
+	url := c.getURL("/v1/tickets/" + url.PathEscape(string(id)))
+	status, headers, body, err := c.del(url, c.headers, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if err := lockedError(status, headers); err != nil {
+		return err
+	}
+	if status.Code == http.StatusGone {
+		return ErrTicketCancelled
+	}
+	if status.Code == http.StatusConflict {
+		return ErrTicketSung
+	}
+	if !status.IsSuccess() {
+		return fmt.Errorf("failed to cancel ticket: %d, %s", status.Code, status.Reason)
+	}
+	if status.Code == http.StatusNoContent {
+		return nil
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	err = json.NewDecoder(body).Decode(&result)
+	if err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return ErrPINInvalid
+	}
+
+	return nil
+}
+
+// VerifyPIN returns ErrPINInvalid unless pin is the PIN of the ticket. Unknown
+// tickets are treated the same.
+func (c Client) VerifyPIN(id model.ID, pin model.PIN) error {
+	data, err := json.Marshal(struct {
+		PIN string `json:"pin"`
+	}{
+		PIN: string(pin),
+	})
+	if err != nil {
+		return err
+	}
+
+	url := c.getURL("/v1/tickets/" + url.PathEscape(string(id)) + "/actions/verify")
+	status, headers, body, err := c.post(url, c.headers, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if err := lockedError(status, headers); err != nil {
+		return err
+	}
+	if status.Code == http.StatusNotFound {
+		return ErrPINInvalid
+	}
+	if !status.IsSuccess() {
+		return fmt.Errorf("failed to verify PIN: %d, %s", status.Code, status.Reason)
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	err = json.NewDecoder(body).Decode(&result)
+	if err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return ErrPINInvalid
+	}
+
+	return nil
+}
+
+// RequestSong sets the song the ticket wants to sing. An empty song removes the
+// request.
+func (c Client) RequestSong(id model.ID, pin model.PIN, song string) error {
+	data, err := json.Marshal(struct {
+		Song string `json:"song"`
+		PIN  string `json:"pin"`
+	}{
+		Song: song,
+		PIN:  string(pin),
+	})
+	if err != nil {
+		return err
+	}
+
+	url := c.getURL("/v1/tickets/" + url.PathEscape(string(id)))
+	status, headers, body, err := c.patch(url, c.headers, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if err := lockedError(status, headers); err != nil {
+		return err
+	}
+	if !status.IsSuccess() {
+		return fmt.Errorf("failed to request song: %d, %s", status.Code, status.Reason)
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	err = json.NewDecoder(body).Decode(&result)
+	if err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return ErrPINInvalid
+	}
+
+	return nil
+}
+
 type status struct {
 	Code   int
 	Reason string
@@ -326,6 +1024,11 @@ func (s status) IsSuccess() bool {
 	return s.Code >= 200 && s.Code <= 299
 }
 
+func isVersionConflict(s status, headers map[string][]string) bool {
+	_, ok := headers["Etag"]
+	return s.Code == http.StatusConflict && ok
+}
+
 type body struct {
 	io.ReadCloser
 }
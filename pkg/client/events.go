@@ -0,0 +1,124 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+	"github.com/Patagonicus/usdx-queue/pkg/model"
+)
+
+const (
+	minReconnectDelay = 1 * time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// Subscribe streams events from the backend until ctx is done. Lost
+// connections are reestablished automatically, resuming after the last
+// received event. The returned channel is closed when ctx is done.
+func (c Client) Subscribe(ctx context.Context) <-chan model.Event {
+	events := make(chan model.Event)
+
+	go func() {
+		defer close(events)
+
+		var lastID uint64
+		delay := minReconnectDelay
+		for {
+			var n int
+			var err error
+			lastID, n, err = c.stream(ctx, lastID, events)
+			if n > 0 {
+				delay = minReconnectDelay
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			c.l.Debug("event stream ended, reconnecting",
+				log.Error(err),
+				log.Duration("delay", delay),
+			)
+
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+
+			delay *= 2
+			if delay > maxReconnectDelay {
+				delay = maxReconnectDelay
+			}
+		}
+	}()
+
+	return events
+}
+
+// stream reads events from a single connection. It returns the ID of the last
+// event it received and the number of received events.
+func (c Client) stream(ctx context.Context, lastID uint64, events chan<- model.Event) (uint64, int, error) {
+	req, err := http.NewRequest("GET", c.getURL("/v1/events").String(), nil)
+	if err != nil {
+		return lastID, 0, err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range c.headers {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if lastID > 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatUint(lastID, 10))
+	}
+
+	// The regular client has a timeout, which would end the stream.
+	resp, err := (&http.Client{Transport: c.c.Transport}).Do(req)
+	if err != nil {
+		return lastID, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return lastID, 0, fmt.Errorf("could not subscribe to events: %d, %s", resp.StatusCode, resp.Status)
+	}
+
+	var n int
+	var data strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if data.Len() == 0 {
+				continue
+			}
+			var event model.Event
+			err := json.Unmarshal([]byte(data.String()), &event)
+			data.Reset()
+			if err != nil {
+				c.l.Warn("failed to decode event",
+					log.Error(err),
+				)
+				continue
+			}
+			select {
+			case events <- event:
+				lastID = event.ID
+				n++
+			case <-ctx.Done():
+				return lastID, n, ctx.Err()
+			}
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+
+	return lastID, n, scanner.Err()
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+// SongNames resolves song ids to "Artist – Title". It loads all songs at
+// once, so that looking up the songs of a whole queue takes one request, and
+// keeps them for maxAge or until Invalidate is called.
+type SongNames struct {
+	c      Client
+	maxAge time.Duration
+	m      *sync.Mutex
+	names  map[string]string
+	at     time.Time
+}
+
+func NewSongNames(c Client, maxAge time.Duration) *SongNames {
+	return &SongNames{
+		c:      c,
+		maxAge: maxAge,
+		m:      new(sync.Mutex),
+	}
+}
+
+// Invalidate makes the next lookup load the songs again, for example after the
+// backend reloaded them.
+func (s *SongNames) Invalidate() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.names = nil
+}
+
+// Name returns the name of the song with the given id, or an empty string if
+// there is no such song.
+func (s *SongNames) Name(id string) (string, error) {
+	if id == "" {
+		return "", nil
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.names == nil || time.Since(s.at) > s.maxAge {
+		songs, err := s.c.GetSongs()
+		if err != nil {
+			return "", err
+		}
+		s.names = make(map[string]string, len(songs))
+		for _, song := range songs {
+			s.names[song.ID] = song.Artist + " – " + song.Title
+		}
+		s.at = time.Now()
+	}
+	return s.names[id], nil
+}
+
+// NameOf returns the name of the song with the given source, as used in the
+// history and the leaderboard.
+func (s *SongNames) NameOf(source string) (string, error) {
+	return s.Name(base64.StdEncoding.EncodeToString([]byte(source)))
+}
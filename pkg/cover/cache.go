@@ -0,0 +1,87 @@
+package cover
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	cache "github.com/patrickmn/go-cache"
+)
+
+// Cache keeps thumbnails in memory and, if a directory is given, on disk, so
+// that they survive restarts.
+type Cache struct {
+	mem *cache.Cache
+	dir string
+}
+
+func NewCache(dir string) *Cache {
+	return &Cache{
+		mem: cache.New(30*time.Minute, 10*time.Minute),
+		dir: dir,
+	}
+}
+
+// Get returns the thumbnail stored under key or creates it with f. key must be
+// usable as file name.
+func (c *Cache) Get(key string, f func() ([]byte, error)) ([]byte, error) {
+	if data, ok := c.mem.Get(key); ok {
+		return data.([]byte), nil
+	}
+
+	if c.dir != "" {
+		path := filepath.Join(c.dir, key)
+		data, err := ioutil.ReadFile(path)
+		if err == nil {
+			// Prune goes by the modification time, so thumbnails that are
+			// still used are kept.
+			now := time.Now()
+			os.Chtimes(path, now, now)
+			c.mem.SetDefault(key, data)
+			return data, nil
+		}
+	}
+
+	data, err := f()
+	if err != nil {
+		return nil, err
+	}
+	c.mem.SetDefault(key, data)
+
+	// The disk is only a second level cache, failing to write to it is not
+	// an error.
+	if c.dir != "" && os.MkdirAll(c.dir, 0755) == nil {
+		ioutil.WriteFile(filepath.Join(c.dir, key), data, 0644)
+	}
+	return data, nil
+}
+
+// Prune removes thumbnails from the disk that have not been used for maxAge,
+// for example because the cover changed. It returns how many were removed.
+func (c *Cache) Prune(maxAge time.Duration) (int, error) {
+	if c.dir == "" {
+		return 0, nil
+	}
+
+	infos, err := ioutil.ReadDir(c.dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, info := range infos {
+		if !info.Mode().IsRegular() || time.Since(info.ModTime()) < maxAge {
+			continue
+		}
+		err = os.Remove(filepath.Join(c.dir, info.Name()))
+		if err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,114 @@
+// Package cover scales cover images down to thumbnails.
+package cover
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+// Thumbnail scales the image in data so that it fits into size×size pixels.
+// PNG images stay PNG to keep transparency, everything else becomes JPEG.
+// Images that are already small enough are returned unchanged.
+func Thumbnail(data []byte, size int) ([]byte, error) {
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	b := src.Bounds()
+	if b.Dx() <= size && b.Dy() <= size {
+		return data, nil
+	}
+
+	w, h := size, size
+	if b.Dx() > b.Dy() {
+		h = maxInt(1, b.Dy()*size/b.Dx())
+	} else {
+		w = maxInt(1, b.Dx()*size/b.Dy())
+	}
+	dst := scale(src, w, h)
+
+	buf := new(bytes.Buffer)
+	if format == "png" {
+		err = png.Encode(buf, dst)
+	} else {
+		err = jpeg.Encode(buf, dst, &jpeg.Options{Quality: 85})
+	}
+	return buf.Bytes(), err
+}
+
+// scale shrinks src to w×h by averaging all source pixels that fall into a
+// destination pixel.
+func scale(src image.Image, w, h int) *image.NRGBA {
+	b := src.Bounds()
+	in := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(in, in.Bounds(), src, b.Min, draw.Src)
+
+	out := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0, y1 := y*b.Dy()/h, maxInt((y+1)*b.Dy()/h, y*b.Dy()/h+1)
+		for x := 0; x < w; x++ {
+			x0, x1 := x*b.Dx()/w, maxInt((x+1)*b.Dx()/w, x*b.Dx()/w+1)
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := in.Pix[sy*in.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					for c := 0; c < 4; c++ {
+						sum[c] += int(row[sx*4+c])
+					}
+				}
+			}
+			n := (y1 - y0) * (x1 - x0)
+			i := y*out.Stride + x*4
+			for c := 0; c < 4; c++ {
+				out.Pix[i+c] = uint8(sum[c] / n)
+			}
+		}
+	}
+	return out
+}
+
+// Placeholder returns a PNG of size×size pixels that shows a record, for
+// songs without a usable cover.
+func Placeholder(size int) []byte {
+	var (
+		background = color.NRGBA{0x44, 0x44, 0x44, 0xff}
+		record     = color.NRGBA{0x22, 0x22, 0x22, 0xff}
+		label      = color.NRGBA{0x4c, 0xaf, 0x50, 0xff}
+	)
+
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	center := float64(size) / 2
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx, dy := float64(x)+0.5-center, float64(y)+0.5-center
+			d := (dx*dx + dy*dy) / (center * center)
+			switch {
+			case d < 0.01:
+				img.SetNRGBA(x, y, background)
+			case d < 0.1:
+				img.SetNRGBA(x, y, label)
+			case d < 0.7:
+				img.SetNRGBA(x, y, record)
+			default:
+				img.SetNRGBA(x, y, background)
+			}
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	png.Encode(buf, img)
+	return buf.Bytes()
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,236 @@
+package library
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Patagonicus/usdx-queue/pkg/log"
+)
+
+// Dir is a library that scans an UltraStar song directory.
+type Dir struct {
+	root string
+	l    log.Logger
+}
+
+func NewDir(l log.Logger, root string) Dir {
+	return Dir{
+		root: root,
+		l:    l,
+	}
+}
+
+// GetAll reads the headers of all .txt files below the root. Files that cannot
+// be read are skipped.
+func (d Dir) GetAll() ([]Song, error) {
+	var songs []Song
+	err := filepath.Walk(d.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			d.l.Warn("failed to scan path",
+				log.String("path", path),
+				log.Error(err),
+			)
+			if info != nil && info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() || !strings.EqualFold(filepath.Ext(path), ".txt") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(d.root, path)
+		if err != nil {
+			return err
+		}
+
+		song, err := readSong(path)
+		if err != nil {
+			d.l.Warn("failed to read song",
+				log.String("path", path),
+				log.Error(err),
+			)
+			return nil
+		}
+		song.Dir = filepath.Dir(rel)
+		song.SourceFile = filepath.Base(rel)
+		songs = append(songs, song)
+		return nil
+	})
+	return songs, err
+}
+
+func readSong(path string) (Song, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Song{}, err
+	}
+	defer f.Close()
+
+	song, err := parseSong(f)
+	if err != nil {
+		return song, err
+	}
+
+	dir := filepath.Dir(path)
+	if !exists(dir, song.VideoPath) {
+		song.VideoPath = ""
+	}
+	if !exists(dir, song.BackgroundPath) {
+		song.BackgroundPath = ""
+	}
+	return song, nil
+}
+
+func exists(dir, path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(dir, path))
+	return err == nil
+}
+
+// parseSong reads the #KEY:value lines at the start of a song file and
+// computes the length of the song from its notes. Files that are not valid
+// UTF-8 are assumed to be Latin-1, which older songs commonly use.
+func parseSong(r io.Reader) (Song, error) {
+	var song Song
+	var (
+		bpm      float64
+		gap      float64
+		end      float64
+		relative bool
+		// offset is the beat the current line starts at in relative mode.
+		offset  int
+		maxBeat int
+		inBody  bool
+	)
+	scanner := bufio.NewScanner(r)
+	first := true
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if first {
+			line = bytes.TrimPrefix(line, []byte("\xef\xbb\xbf"))
+			first = false
+		}
+		if len(line) == 0 {
+			continue
+		}
+		if inBody || line[0] != '#' {
+			inBody = true
+			beat, ok := parseBody(string(line), relative, &offset, &song.Duet)
+			if ok && beat > maxBeat {
+				maxBeat = beat
+			}
+			if line[0] == 'E' {
+				break
+			}
+			continue
+		}
+
+		parts := strings.SplitN(decodeLine(line[1:]), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(parts[1])
+
+		switch strings.ToUpper(strings.TrimSpace(parts[0])) {
+		case "TITLE":
+			song.Title = value
+		case "ARTIST":
+			song.Artist = value
+		case "YEAR":
+			song.Year, _ = strconv.Atoi(value)
+		case "COVER":
+			song.CoverPath = value
+		case "LANGUAGE":
+			song.Language = value
+		case "GENRE":
+			song.Genre = value
+		case "EDITION":
+			song.Edition = value
+		case "VIDEO":
+			song.VideoPath = value
+		case "BACKGROUND":
+			song.BackgroundPath = value
+		case "BPM":
+			bpm = parseFloat(value)
+		case "GAP":
+			gap = parseFloat(value)
+		case "END":
+			end = parseFloat(value)
+		case "RELATIVE":
+			relative = strings.EqualFold(value, "yes")
+		case "P1", "P2", "DUETSINGERP1", "DUETSINGERP2":
+			song.Duet = true
+		}
+	}
+
+	switch {
+	case end > 0:
+		song.Length = time.Duration(end * float64(time.Millisecond))
+	case bpm > 0 && maxBeat > 0:
+		// UltraStar beats are quarter notes of the given BPM.
+		beats := float64(maxBeat) * 60 / (bpm * 4)
+		song.Length = time.Duration(gap*float64(time.Millisecond) + beats*float64(time.Second))
+	}
+	return song, scanner.Err()
+}
+
+// parseBody handles a line after the header. It returns the beat at which a
+// note ends.
+func parseBody(line string, relative bool, offset *int, duet *bool) (int, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return 0, false
+	}
+
+	switch fields[0] {
+	case ":", "*", "F", "R", "G":
+		if len(fields) < 3 {
+			return 0, false
+		}
+		start, err1 := strconv.Atoi(fields[1])
+		length, err2 := strconv.Atoi(fields[2])
+		if err1 != nil || err2 != nil {
+			return 0, false
+		}
+		return *offset + start + length, true
+	case "-":
+		if !relative || len(fields) < 2 {
+			return 0, false
+		}
+		shift := fields[len(fields)-1]
+		n, err := strconv.Atoi(shift)
+		if err == nil {
+			*offset += n
+		}
+		return 0, false
+	case "P", "P1", "P2", "P3":
+		*duet = true
+	}
+	return 0, false
+}
+
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	return f
+}
+
+func decodeLine(line []byte) string {
+	if utf8.Valid(line) {
+		return string(line)
+	}
+	runes := make([]rune, len(line))
+	for i, b := range line {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
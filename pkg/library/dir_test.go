@@ -0,0 +1,68 @@
+package library
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseHeader(t *testing.T) {
+	input := "\xef\xbb\xbf#TITLE:Karate\r\n#ARTIST: BABYMETAL\n#YEAR:2016\n#COVER:cover.jpg\n#LANGUAGE:Japanese\n#GENRE:Metal\n#EDITION:SingStar\n#VIDEO:video.mp4\n#BPM:300\n: 0 2 5 Ka\n#TITLE:ignored\n"
+
+	song, err := parseSong(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("failed to parse header: %s", err)
+	}
+
+	expected := Song{
+		Title:     "Karate",
+		Artist:    "BABYMETAL",
+		Year:      2016,
+		CoverPath: "cover.jpg",
+		Language:  "Japanese",
+		Genre:     "Metal",
+		Edition:   "SingStar",
+		VideoPath: "video.mp4",
+		Length:    100 * time.Millisecond,
+	}
+	if !reflect.DeepEqual(song, expected) {
+		t.Fatalf("expected %v, but got %v", expected, song)
+	}
+}
+
+func TestParseHeaderLatin1(t *testing.T) {
+	song, err := parseSong(strings.NewReader("#ARTIST:Die \xc4rzte\n#TITLE:Schrei nach Liebe\n"))
+	if err != nil {
+		t.Fatalf("failed to parse header: %s", err)
+	}
+	if song.Artist != "Die Ärzte" {
+		t.Fatalf("expected Die Ärzte, but got %s", song.Artist)
+	}
+}
+
+func TestParseLength(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		input    string
+		expected time.Duration
+		duet     bool
+	}{
+		{"absolute", "#BPM:150\n#GAP:1000\n: 0 10 5 a\n- 12\n* 20 40 5 b\nE\n: 1000 10 5 ignored\n", 7 * time.Second, false},
+		{"comma", "#BPM:150,0\n: 0 60 5 a\n", 6 * time.Second, false},
+		{"relative", "#BPM:150\n#RELATIVE:yes\n: 0 10 5 a\n- 12 20\n: 0 40 5 b\n", 6 * time.Second, false},
+		{"end", "#BPM:150\n#END:90000\n: 0 10 5 a\n", 90 * time.Second, false},
+		{"duet", "#BPM:150\nP1\n: 0 10 5 a\nP2\n: 0 60 5 b\n", 6 * time.Second, true},
+	} {
+		song, err := parseSong(strings.NewReader(c.input))
+		if err != nil {
+			t.Fatalf("%s: failed to parse: %s", c.name, err)
+		}
+		if song.Length != c.expected {
+			t.Errorf("%s: expected length %s, but got %s", c.name, c.expected, song.Length)
+		}
+		if song.Duet != c.duet {
+			t.Errorf("%s: expected duet %t, but got %t", c.name, c.duet, song.Duet)
+		}
+	}
+}
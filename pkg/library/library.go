@@ -0,0 +1,65 @@
+// Package library lists the songs that can be sung.
+package library
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/Patagonicus/usdx-reader/pkg/storage"
+)
+
+// Song is a song in a library. Dir is relative to the root of the library,
+// SourceFile and the other paths are relative to Dir. VideoPath and
+// BackgroundPath are only set if the files exist.
+type Song struct {
+	Dir            string
+	SourceFile     string
+	Title          string
+	Artist         string
+	Year           int
+	CoverPath      string
+	Language       string
+	Genre          string
+	Edition        string
+	Duet           bool
+	Length         time.Duration
+	VideoPath      string
+	BackgroundPath string
+}
+
+// Source returns the path of the song's text file relative to the root of the
+// library.
+func (s Song) Source() string {
+	return filepath.Join(s.Dir, s.SourceFile)
+}
+
+type Library interface {
+	GetAll() ([]Song, error)
+}
+
+type storageLibrary struct {
+	b storage.Backend
+}
+
+// FromStorage uses a usdx-reader storage backend as library. The storage only
+// knows title, artist, year and cover, all other fields are left empty.
+func FromStorage(b storage.Backend) Library {
+	return storageLibrary{b}
+}
+
+func (s storageLibrary) GetAll() ([]Song, error) {
+	var songs []Song
+	r := s.b.GetAll()
+	for r.Next() {
+		song := r.Song()
+		songs = append(songs, Song{
+			Dir:        song.Dir,
+			SourceFile: song.SourceFile,
+			Title:      song.Title,
+			Artist:     song.Artist,
+			Year:       song.Year,
+			CoverPath:  song.CoverPath,
+		})
+	}
+	return songs, r.Err()
+}
@@ -35,20 +35,139 @@ func (v Version) String() string {
 }
 
 type Ticket struct {
-	ID      ID       `json:"id"`
-	Names   []string `json:"names,omitempty"`
-	Version Version  `json:"version"`
+	ID    ID       `json:"id"`
+	Names []string `json:"names,omitempty"`
+	// Song is the ID of the requested song, if any.
+	Song string `json:"song,omitempty"`
+	// Stage is the stage whose queue the ticket is in.
+	Stage string `json:"stage,omitempty"`
+	// NoShows counts how often the singers did not show up when it was
+	// their turn.
+	NoShows int `json:"noShows,omitempty"`
+	// Priority and Slot are set for tickets created with a Reservation.
+	Priority int `json:"priority,omitempty"`
+	Slot     int `json:"slot,omitempty"`
+	// Status is where the ticket is in its lifecycle. StatusHistory lists
+	// every change of it, oldest first.
+	Status        TicketStatus   `json:"status"`
+	StatusHistory []StatusChange `json:"statusHistory,omitempty"`
+	Version       Version        `json:"version"`
+}
+
+// Cancelled tells whether the holder gave up the ticket.
+func (t Ticket) Cancelled() bool {
+	return t.Status == StatusCancelled
 }
 
 func (t Ticket) String() string {
-	return fmt.Sprintf("Ticket{%s %s %s}", t.ID, t.Names, t.Version)
+	return fmt.Sprintf("Ticket{%s %s %s %s}", t.ID, t.Names, t.Song, t.Version)
+}
+
+type TicketStatus string
+
+const (
+	// StatusWaiting tickets are in the queue after the current one.
+	StatusWaiting TicketStatus = "waiting"
+	// StatusOnStage is the status of the current ticket.
+	StatusOnStage TicketStatus = "on-stage"
+	// StatusSung tickets are in the queue before the current one.
+	StatusSung TicketStatus = "sung"
+	// StatusSkipped tickets have been removed from the queue by an admin.
+	StatusSkipped   TicketStatus = "skipped"
+	StatusCancelled TicketStatus = "cancelled"
+	// StatusNoShow is set when the singers did not show up. Tickets that are
+	// deferred instead of dropped become waiting again.
+	StatusNoShow TicketStatus = "no-show"
+)
+
+// StatusChange records when a ticket got a status.
+type StatusChange struct {
+	Status TicketStatus `json:"status"`
+	Time   time.Time    `json:"time"`
+}
+
+// Lockout is a ticket or a source, like an IP address, that may not enter
+// PINs until the given time because of too many failed attempts.
+type Lockout struct {
+	Ticket   ID        `json:"ticket,omitempty"`
+	Source   string    `json:"source,omitempty"`
+	Failures int       `json:"failures"`
+	Until    time.Time `json:"until"`
+}
+
+// TicketUpdate lists the changes to a ticket. Nil fields are left unchanged.
+type TicketUpdate struct {
+	Names []string
+	Song  *string
+}
+
+// Reservation places a ticket ahead of the normal order, for example for
+// birthdays or special guests.
+type Reservation struct {
+	// Priority tickets are placed behind the current ticket and the waiting
+	// tickets with at least the same priority.
+	Priority int `json:"priority,omitempty"`
+	// Slot is the position reserved for the ticket, counting from 1 for the
+	// first ticket of the night. The ticket is moved there as soon as enough
+	// tickets are queued in front of it.
+	Slot int `json:"slot,omitempty"`
+}
+
+// DefaultStage is the stage used by clients that do not name one.
+const DefaultStage = "main"
+
+// Stage is a room with its own queue and playback state.
+type Stage struct {
+	Name string `json:"name"`
+	// Waiting is the number of tickets after the current one.
+	Waiting int  `json:"waiting"`
+	Paused  bool `json:"paused"`
 }
 
 type Queue struct {
+	Stage    string `json:",omitempty"`
 	Queue    []ID
 	Position int
 	Paused   bool
 	Version  Version
+	// Requests maps queued tickets to the songs they requested.
+	Requests map[ID]string `json:",omitempty"`
+	// ETA maps upcoming tickets to the time they are expected to start
+	// singing. It is only set when the queue is requested directly.
+	ETA map[ID]time.Time `json:",omitempty"`
+	// Priorities and Slots hold the reservations of queued tickets.
+	Priorities map[ID]int `json:",omitempty"`
+	Slots      map[ID]int `json:",omitempty"`
+	// Held are waiting tickets that are passed over until they get names,
+	// because the names they were given have been rejected.
+	Held map[ID]bool `json:",omitempty"`
+	// Statuses maps queued tickets to their status and when they got it.
+	Statuses map[ID]StatusChange `json:",omitempty"`
+}
+
+// Status returns the status of a queued ticket, or "" if it is not queued.
+func (q Queue) Status(id ID) TicketStatus {
+	return q.Statuses[id].Status
+}
+
+// WithStatus returns the queued tickets that have status s, in queue order.
+func (q Queue) WithStatus(s TicketStatus) []ID {
+	var result []ID
+	for _, id := range q.Queue {
+		if q.Status(id) == s {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// Current returns the ticket that is on stage, if any.
+func (q Queue) Current() (ID, bool) {
+	current := q.WithStatus(StatusOnStage)
+	if len(current) == 0 {
+		return "", false
+	}
+	return current[0], true
 }
 
 type PlaybackState int
@@ -157,7 +276,240 @@ func (s Score) Total() int {
 }
 
 type Song struct {
-	Title  string `json:"title"`
-	Artist string `json:"artist"`
-	Year   int    `json:"year"`
+	ID       string `json:"id,omitempty"`
+	Title    string `json:"title"`
+	Artist   string `json:"artist"`
+	Year     int    `json:"year"`
+	Language string `json:"language,omitempty"`
+	Genre    string `json:"genre,omitempty"`
+	Edition  string `json:"edition,omitempty"`
+	// Duet songs have separate parts for two singers.
+	Duet   bool          `json:"duet,omitempty"`
+	Length time.Duration `json:"length,omitempty"`
+	// Video and Background tell whether the song has a video or a background
+	// image.
+	Video      bool `json:"video,omitempty"`
+	Background bool `json:"background,omitempty"`
+}
+
+type EventType string
+
+const (
+	EventQueueChanged    EventType = "queue-changed"
+	EventTicketCreated   EventType = "ticket-created"
+	EventTicketRenamed   EventType = "ticket-renamed"
+	EventSongRequested   EventType = "song-requested"
+	EventStateUpdated    EventType = "state-updated"
+	EventPauseToggled    EventType = "pause-toggled"
+	EventAnomaly         EventType = "anomaly"
+	EventAdvancePending  EventType = "advance-pending"
+	EventAdvanceResolved EventType = "advance-resolved"
+	EventSongsReloaded   EventType = "songs-reloaded"
+	EventNightStarted    EventType = "night-started"
+	EventStageCreated    EventType = "stage-created"
+	EventTicketNoShow    EventType = "ticket-no-show"
+	EventTicketCancelled EventType = "ticket-cancelled"
+	// EventResync is sent when events have been missed, for example because
+	// the backend restarted. Receivers have to reload everything.
+	EventResync EventType = "resync"
+)
+
+type Event struct {
+	ID      uint64          `json:"id"`
+	Type    EventType       `json:"type"`
+	Version Version         `json:"version"`
+	Stage   string          `json:"stage,omitempty"`
+	Ticket  *Ticket         `json:"ticket,omitempty"`
+	Queue   *Queue          `json:"queue,omitempty"`
+	State   *State          `json:"state,omitempty"`
+	Anomaly *Anomaly        `json:"anomaly,omitempty"`
+	Pending *PendingAdvance `json:"pending,omitempty"`
+	Night   *Night          `json:"night,omitempty"`
+}
+
+func (e Event) String() string {
+	return fmt.Sprintf("Event{%d %s %s}", e.ID, e.Type, e.Version)
+}
+
+// Anomaly is something unexpected a client noticed, for example a song that
+// was not requested by the current ticket.
+type Anomaly struct {
+	Message string `json:"message"`
+	Ticket  ID     `json:"ticket,omitempty"`
+	// Requested is the ID of the song the ticket requested.
+	Requested string `json:"requested,omitempty"`
+	// Source is the song that was actually played.
+	Source string `json:"source,omitempty"`
+}
+
+func (a Anomaly) String() string {
+	return fmt.Sprintf("Anomaly{%s %s %s %s}", a.Message, a.Ticket, a.Requested, a.Source)
+}
+
+// PendingAdvance is an advance the advancer did not make on its own, for
+// example because the song was aborted early. An admin has to confirm or
+// reject it.
+type PendingAdvance struct {
+	ID     uint64 `json:"id"`
+	Stage  string `json:"stage,omitempty"`
+	Ticket ID     `json:"ticket"`
+	Reason string `json:"reason"`
+	Source string `json:"source,omitempty"`
+	// Duration is how long the song has been playing.
+	Duration time.Duration `json:"duration"`
+	// Position is the relative position in the song when it ended.
+	Position float64   `json:"position"`
+	Created  time.Time `json:"created"`
+}
+
+func (p PendingAdvance) String() string {
+	return fmt.Sprintf("PendingAdvance{%d %s %s}", p.ID, p.Ticket, p.Reason)
+}
+
+type PlayedSong struct {
+	Stage    string        `json:"stage,omitempty"`
+	Ticket   ID            `json:"ticket"`
+	Names    []string      `json:"names,omitempty"`
+	Source   string        `json:"source"`
+	Length   time.Duration `json:"length"`
+	Position time.Duration `json:"position"`
+	Scores   []Score       `json:"scores,omitempty"`
+	Started  time.Time     `json:"started"`
+	Finished time.Time     `json:"finished"`
+}
+
+func (p PlayedSong) String() string {
+	return fmt.Sprintf("PlayedSong{%s %s %s}", p.Ticket, p.Source, p.Finished)
+}
+
+type SingMode string
+
+const (
+	AnyMode SingMode = ""
+	// Solo songs have been sung by a single player.
+	Solo SingMode = "solo"
+	// Duet songs have been sung by more than one player.
+	Duet SingMode = "duet"
+)
+
+func (m SingMode) Matches(players int) bool {
+	switch m {
+	case Solo:
+		return players == 1
+	case Duet:
+		return players > 1
+	default:
+		return true
+	}
+}
+
+type RankBy string
+
+const (
+	RankByBest    RankBy = "best"
+	RankByAverage RankBy = "average"
+)
+
+// LeaderboardFilter selects the played songs a leaderboard is built from. Zero
+// values do not restrict anything.
+type LeaderboardFilter struct {
+	Since  time.Time
+	Until  time.Time
+	Mode   SingMode
+	RankBy RankBy
+	Limit  int
+}
+
+// Ranking is an entry in a leaderboard. For songs, Name is the source.
+type Ranking struct {
+	Name    string `json:"name"`
+	Best    int    `json:"best"`
+	Average int    `json:"average"`
+	Count   int    `json:"count"`
+}
+
+type Leaderboard struct {
+	Singers []Ranking `json:"singers"`
+	Songs   []Ranking `json:"songs"`
+}
+
+type SongSort string
+
+const (
+	// SortByArtist sorts by artist and then by title.
+	SortByArtist SongSort = "artist"
+	SortByTitle  SongSort = "title"
+	SortByYear   SongSort = "year"
+)
+
+// SongFilter selects songs from the library. Zero values do not restrict
+// anything. Query matches songs whose artist and title contain all of its
+// words, ignoring case and accents.
+type SongFilter struct {
+	Query    string
+	MinYear  int
+	MaxYear  int
+	Language string
+	Genre    string
+	Sort     SongSort
+	// Cursor is the Next value of the previous page.
+	Cursor string
+	Limit  int
+}
+
+// SongPage is a part of the songs matching a SongFilter. Next is empty on the
+// last page.
+type SongPage struct {
+	Songs []Song `json:"songs"`
+	Next  string `json:"next,omitempty"`
+}
+
+type SchedulingPolicy string
+
+const (
+	// FIFO keeps tickets in the order they were created.
+	FIFO SchedulingPolicy = "fifo"
+	// RoundRobin places tickets of repeat singers behind those of singers
+	// who have had fewer tickets.
+	RoundRobin SchedulingPolicy = "round-robin"
+	// MaxTickets keeps the order, but limits the number of waiting tickets
+	// per singer.
+	MaxTickets SchedulingPolicy = "max-tickets"
+)
+
+// Scheduling decides where a ticket is placed in the queue once the names of
+// its singers are known.
+type Scheduling struct {
+	Policy SchedulingPolicy `json:"policy"`
+	// MaxTickets is the number of waiting tickets a singer may have with the
+	// max-tickets policy.
+	MaxTickets int `json:"maxTickets,omitempty"`
+}
+
+// Night is an evening of singing. Starting a new night archives the tickets,
+// the queue and the played songs of the previous one.
+type Night struct {
+	// ID is 0 for the current night.
+	ID      uint64    `json:"id"`
+	Name    string    `json:"name,omitempty"`
+	Started time.Time `json:"started"`
+	Closed  time.Time `json:"closed"`
+	// TicketCount and SongCount are the number of tickets and played songs.
+	TicketCount int        `json:"tickets"`
+	SongCount   int        `json:"songs"`
+	Scheduling  Scheduling `json:"scheduling"`
+}
+
+func (n Night) String() string {
+	return fmt.Sprintf("Night{%d %s %s}", n.ID, n.Name, n.Closed)
+}
+
+// ArchivedNight is a night with everything that happened in it.
+type ArchivedNight struct {
+	Night
+	Queue Queue `json:"queue"`
+	// Stages are the queues of all stages other than the default one.
+	Stages  []Queue      `json:"stages,omitempty"`
+	Tickets []Ticket     `json:"ticketList"`
+	History []PlayedSong `json:"history"`
 }
@@ -19,7 +19,9 @@ var tmpl = template.Must(template.New("foo").Funcs(Funcs).Parse(`{{reset}}{{.Dat
 {{big}}{{center}}+++ ULTRASTAR +++{{reset}}
 Deine Ticketnummer und PIN:
 {{big}}#{{.ID}}{{aligncolumn}}{{.PIN}}{{reset}}
-Jetzt Namen eintragen und Song raussuchen:
+{{if .Stage}}Deine Buehne: {{bold}}{{.Stage}}{{reset}}
+{{end}}{{if .ETA}}Du bist voraussichtlich ca. {{bold}}{{.ETA}} Uhr{{reset}} dran.
+{{end}}Jetzt Namen eintragen und Song raussuchen:
 {{center}}{{qr .Registration.URL}}{{bold}}{{center}}{{.Registration.Base}}{{reset}}
 {{center}}+++ Reminder +++
 Die Nummern werden angezeigt.
@@ -61,20 +63,29 @@ var (
 	verifyCompleted = []byte{0x1B, 0x00, 0x80, 0x00}
 )
 
+// Ticket is what is printed on a ticket. Stage and ETA are only printed if
+// they are set.
+type Ticket struct {
+	ID    model.ID
+	PIN   model.PIN
+	Stage string
+	ETA   time.Time
+	// RegBase is shown below the QR code that links to RegURL.
+	RegBase string
+	RegURL  string
+}
+
 type Printer interface {
-	Print(id model.ID, pin model.PIN, regBase, regURL string) error
+	Print(t Ticket) error
 }
 
 type nilPrinter struct {
 	l log.Logger
 }
 
-func (p nilPrinter) Print(id model.ID, pin model.PIN, regBase, regURL string) error {
+func (p nilPrinter) Print(t Ticket) error {
 	p.l.Info("printing ticket",
-		log.Any("id", id),
-		log.Any("pin", pin),
-		log.String("regBase", regBase),
-		log.String("regURL", regURL),
+		log.Any("ticket", t),
 	)
 	return nil
 }
@@ -143,24 +154,27 @@ func (f filter) Read(p []byte) (int, error) {
 	return j, err
 }
 
-func (p printer) Print(id model.ID, pin model.PIN, regBase, regURL string) error {
+func (p printer) Print(t Ticket) error {
 	p.m.Lock()
 	defer p.m.Unlock()
 
 	p.l.Info("printing ticket",
-		log.Any("id", id),
-		log.Any("pin", pin),
-		log.String("regBase", regBase),
-		log.String("regURL", regURL),
+		log.Any("ticket", t),
 	)
 
+	eta := ""
+	if !t.ETA.IsZero() {
+		eta = t.ETA.Format("15:04")
+	}
 	err := tmpl.Execute(p.w, map[string]interface{}{
 		"DateTime": time.Now().Format("02.01.2006 15:04"),
-		"ID":       string(id),
-		"PIN":      string(pin),
+		"ID":       string(t.ID),
+		"PIN":      string(t.PIN),
+		"Stage":    t.Stage,
+		"ETA":      eta,
 		"Registration": map[string]string{
-			"Base": regBase,
-			"URL":  regURL,
+			"Base": t.RegBase,
+			"URL":  t.RegURL,
 		},
 	})
 	if err != nil {
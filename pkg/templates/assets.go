@@ -116,7 +116,7 @@ func beamerBeamerCss() (*asset, error) {
 	return a, nil
 }
 
-var _beamerBeamerJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4c\x50\x3d\x4f\x84\x40\x10\xed\xf9\x15\x2f\x34\xec\x25\xe4\x82\xb6\x97\x2d\x8d\x16\xda\x18\x0a\x5b\x58\xe6\x4e\xcc\x3a\x83\x3b\xb3\x7e\xc4\xf0\xdf\x0d\x9c\x9c\x36\xd3\xbc\xaf\x79\xaf\xcc\x4a\x50\x4b\x63\xb0\xf2\x50\x14\xc7\xcc\xc1\x46\x61\xe4\x69\xe8\x8c\x5c\x4e\xb1\x46\xe8\x77\xf8\x2e\x80\xf7\x2e\xe1\xf3\x39\xc1\x83\xe9\x03\x4f\x0f\xf7\x77\x66\xd3\x23\xbd\x65\x52\x73\xbb\x43\x81\x05\xdd\xcb\x44\xec\xaa\xdb\x9b\xb6\xaa\xb1\xca\x2d\x65\xba\xa0\x89\x74\x12\x56\x6a\xbf\x26\x82\x47\xf5\xa2\xc2\xd5\x45\xca\x51\xba\x01\x1e\xdb\x1b\xee\x1c\x0c\x8c\x47\xb8\x85\xa1\xd6\x59\x56\x78\xef\x71\xdd\x34\x1b\x0a\x84\xde\xfd\x77\xaf\xc1\x39\xc6\x35\x14\x98\x41\x51\xe9\x8f\x2a\xac\x12\x69\x1f\xe5\xe4\xca\x20\x39\x0e\x60\x31\x9c\xc8\xb0\xb8\x53\xb9\xc9\xd6\xab\x64\xed\xf8\x4a\x92\xcd\x9d\x27\xa9\x71\xd5\x34\xcd\x6f\xb5\xd0\xaf\xe4\x79\x2b\xa0\xc4\xc3\xb2\xc4\x5c\xfc\x04\x00\x00\xff\xff\x63\x49\x37\x10\x58\x01\x00\x00")
+var _beamerBeamerJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x91\x41\x8f\x94\x40\x10\x85\xef\xfc\x8a\x27\x17\x7a\x12\x42\xd0\xeb\x84\xe3\x46\x63\x46\x4d\x76\xc7\xc4\x6b\xd3\xd4\x8c\x68\x5b\x8d\x5d\xd5\x3b\x1a\xc3\x7f\x37\x30\x30\x83\x66\x2f\x84\x4e\xd5\xab\xaa\xf7\xbd\x3c\x09\x41\x34\xf6\x4e\xf3\x7d\x96\x9d\x12\x3b\xed\x03\x23\x0d\x9d\x55\x32\x29\xfa\x12\xae\xdd\xe1\x4f\x06\x3c\xdb\x88\x5f\x5f\x23\x1a\x30\x5d\xf0\xe5\xc3\xe1\x9d\xea\xf0\x48\x3f\x13\x89\x9a\xdd\x3e\xc3\x54\xad\xc2\x40\x6c\x8a\xb7\x0f\xc7\xa2\xc4\x2c\xd7\x98\xe8\x56\x8d\x24\x43\x60\xa1\xe3\xef\x81\xd0\xa0\xf8\x26\x81\x8b\x9b\x94\x7d\xb0\x1d\x1a\xac\x67\x98\xeb\x62\xa0\x3f\xc1\x4c\x1d\xa2\x56\x93\xa0\x69\x1a\xbc\xa9\xeb\xb5\x0a\xb8\xd6\x6c\xa7\x97\xe0\xe4\xfd\xbc\x14\x18\x41\x5e\xe8\xde\x1a\x58\x82\xa7\xca\x87\xb3\xc9\x5d\x48\xbe\x03\x07\xc5\x99\x14\xd3\x74\xca\x57\xd9\xfc\x15\xd2\x63\xff\x83\x42\x52\x73\x45\x52\xe2\x75\x5d\xd7\x8b\x35\xd7\xce\xcd\xe3\x6a\x40\x88\xbb\x89\xc4\xb8\x21\x29\xa9\x15\x17\xfb\x76\x81\x79\xb2\xde\xb7\xd6\x7d\xff\xfc\x78\xb8\x93\x9d\xec\xbd\xba\xf4\xdc\x85\x4b\xf5\xf0\x4c\xac\x4f\x21\x45\x47\xab\xbf\x25\x8c\xff\xa5\xd3\x56\x20\x92\xa6\xc8\xd3\xff\xb8\x64\x24\xb3\x78\x89\x69\x33\x6e\x3a\x60\xbe\xf7\xda\x50\xd9\xae\x9b\xab\x87\x5e\x94\x98\xa2\x29\x66\x00\x45\x79\xe7\x7f\x3b\xc1\xb5\xe6\xfd\xd3\xa7\x8f\xd5\x60\xa3\x90\xa1\xaa\xb3\x6a\x77\x1b\xcc\xe3\x76\x70\x60\x8a\x31\xc4\x97\x82\xfc\x87\xbe\x0f\xa2\x53\x1e\x4c\xf3\xb6\x12\x91\xd6\x17\x9f\xf3\xdd\x3e\x03\xc6\x7d\x36\x66\x7f\x07\x00\xa0\x26\xc5\x00\xa5\x02\x00\x00")
 
 func beamerBeamerJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -132,7 +132,7 @@ func beamerBeamerJs() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "beamer/beamer.js", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x33, 0x9a, 0x35, 0x73, 0xa4, 0xe2, 0x76, 0x72, 0x5a, 0x45, 0xc5, 0xbd, 0x67, 0x6e, 0xa8, 0x15, 0x2a, 0x66, 0xed, 0x70, 0xf5, 0x8f, 0xf8, 0xfb, 0xde, 0x7c, 0x52, 0x2, 0xd5, 0x96, 0xd9, 0x42}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x65, 0xd9, 0x3, 0xf7, 0x21, 0x97, 0xce, 0x63, 0x66, 0xac, 0x60, 0xa8, 0xc2, 0x5e, 0x8f, 0x51, 0x7b, 0x55, 0xe, 0x68, 0xee, 0x8e, 0x80, 0x15, 0x99, 0x3b, 0x62, 0xbb, 0x6b, 0x84, 0xa2, 0x64}}
 	return a, nil
 }
 
@@ -176,7 +176,7 @@ func beamerIndexHtml() (*asset, error) {
 	return a, nil
 }
 
-var _beamerLargeHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x58\x4b\x8f\xdb\x36\x10\xbe\xef\xaf\x98\x32\x08\x76\x9d\xad\x64\x3b\x8f\x26\x90\x25\xa3\xd9\x34\x41\x8b\x26\x68\x81\x6e\x0f\x41\x90\x03\x57\x1a\x5b\x4c\x24\x52\x25\x69\xaf\xdd\x60\xff\x7b\x41\x89\x92\xa8\x57\xd6\x87\xb6\xc8\xc5\xa1\x38\xf3\x0d\xbf\x19\xce\x83\xd9\xf0\xbb\x9f\x7e\x7b\x75\xfd\xfe\xf7\xd7\xf0\xf3\xf5\xbb\xb7\xeb\xb3\x30\xd5\x79\xb6\x3e\x03\x08\x53\xa4\x89\x59\x00\x84\x39\x6a\x0a\xa9\xd6\x85\x87\x7f\xed\xd8\x3e\x22\xaf\x04\xd7\xc8\xb5\x77\x7d\x2c\x90\x40\x5c\x7d\x45\x44\xe3\x41\xcf\x8d\x81\x15\xc4\x29\x95\x0a\x75\xf4\xe7\xf5\x1b\xef\x05\x81\xb9\xb5\xa4\x99\xce\x70\x7d\x85\x34\x47\x19\xce\xab\xaf\x4a\x92\x31\xfe\x19\x24\x66\x11\x51\xfa\x98\xa1\x4a\x11\x35\x01\x7d\x2c\xd0\xda\x8d\x95\x22\x90\x4a\xdc\x44\xe4\xa6\xc4\xfb\x66\xc7\xa2\x4b\x4c\xb5\x06\xb8\x11\xc9\x11\xbe\xd8\x0f\x80\x4d\x86\x07\x2f\x61\x12\x63\xcd\x04\x0f\x40\x8a\xdb\x55\x2b\x14\x5c\x7b\x8a\xfd\x8d\x01\x3c\x5d\x2c\x1e\xb6\x82\xdb\x94\x69\xf4\x54\x41\x63\x0c\x80\x8b\x5b\x49\x8b\x56\x68\x08\x79\x62\x8f\x72\x93\x89\xdb\x00\x30\xcb\x58\xa1\x98\xaa\x15\xee\xce\x5c\x26\x6b\x48\xd8\xde\xe1\x93\x22\xdb\xa6\x3a\x80\xa5\x73\x5e\x83\x48\x97\x8e\x66\x4e\xe5\x96\xf1\x00\x16\x03\xb5\x07\x45\x46\x8f\x8c\x6f\x1d\xe5\x5b\x96\xe8\x34\x80\x67\xae\x13\x09\x53\x46\x31\x28\x63\xb0\x9a\x8c\x48\x2c\xb2\x5d\xce\x5b\xf9\xa7\x9d\xd2\x6c\x73\xf4\xec\xc5\x06\x10\x23\xd7\x28\x5b\x05\x9a\xb1\x2d\xf7\x98\xc6\x5c\xf5\x85\x43\x8a\x8f\x1c\x92\x75\xcc\xbc\x43\x00\x29\x4b\x12\xe4\x27\x07\xd5\x84\xe3\xe0\x59\x2f\x47\x63\xf7\x20\xde\x49\x89\x5c\x7f\xcb\x41\xe1\x34\x47\x35\x24\xb8\x5c\xfc\xef\x0c\x4b\x6b\x4a\x53\xa9\xa7\x58\xf6\x13\xf7\x44\x5a\x9d\xfa\x1a\x70\x1a\x1e\xfb\xd5\xc8\x8d\x46\xa8\x4f\xd4\x2f\xa4\xd8\x4a\x54\x23\x81\x7d\xe2\xc6\xb5\xae\xbc\x85\xff\x03\xe6\xed\xf6\x8d\x90\x09\x4a\x4f\xd2\x84\xed\x94\x91\x3e\x76\xa5\x55\x11\x7a\xb2\x86\x3a\x42\x27\xf3\x4c\xde\x76\x2a\xb7\x49\xd5\x67\x63\x0c\xe8\x4e\x8b\x81\x8b\xee\x66\x6b\x9a\x25\x6e\x2f\x1b\x6f\x57\x4e\xd1\x4d\x46\xe2\xc5\x18\x8f\x65\x27\x0e\x34\xfe\xbc\x95\x62\xc7\x13\x2f\x16\x99\x90\x41\xd5\x04\x07\x87\xfc\x98\x63\xc2\x28\x5c\x18\x27\xa9\x2a\x30\xd6\x9e\xa4\x9a\x89\x00\x9e\xce\x9f\xcc\x9c\x93\x7b\x8d\xf8\xfe\x0c\xbe\xeb\x42\xfb\x09\xd8\xf2\xee\x04\xb5\x45\x35\x3d\x87\xe5\xdb\x0e\xce\x50\xad\xb1\x8f\xef\xc1\xba\xb8\xd1\xf2\x74\x30\xc3\x8e\x73\x3f\xa6\xdf\x02\x26\x10\x36\x5a\x66\xf0\x04\xd0\x1d\x3f\x03\x63\xc3\x40\x8d\x25\xdf\xdd\x99\xfb\x6f\x38\x6f\x86\x66\x38\xaf\x87\x7d\x68\xe2\x6e\x67\xaa\x31\xc9\x92\x88\xd8\xb8\x10\x28\xf5\x23\xd2\xb4\x01\x2e\x38\xae\x48\x3d\x75\x43\x13\x73\xa3\x5f\xd6\x02\x01\x25\xe3\x88\xb4\xd2\xda\x5a\x39\xf3\xc9\xfa\x57\x2a\xa9\xc6\x70\x9e\xb0\xfd\x40\x85\x4a\xcd\x94\x26\xeb\xab\x97\x57\xef\xdf\xbd\xbe\x7e\xf9\x76\x5c\xad\x4e\x75\x02\x71\x46\x95\x72\x36\xd6\x8d\xcb\x8d\xf2\x0d\x95\x8d\x03\x55\x6c\x9e\x2d\x1e\x92\x75\xd7\x72\xfb\xe1\x2e\x6b\x13\xf6\xae\x5b\x97\xd2\x65\xb9\xcf\x92\x86\x01\x4b\x8c\xc9\x74\x39\xe0\x5a\x5e\x53\x9f\x97\xa5\xb3\x67\x8a\xdd\xb0\x8c\xe9\x63\x60\x47\x22\x59\x97\xf2\x81\x5b\x2e\xaa\x72\xe2\xf9\x93\xc6\x09\xfb\x6b\x7e\xae\x32\xea\xee\xfd\xeb\xa7\x3e\x7e\x31\x76\xea\x1b\x21\xfe\xd3\x53\x97\xcf\x47\x7d\xa5\xf2\x9b\x39\x75\x22\x81\x54\x2c\x59\xa1\xed\x5b\x96\x16\x45\xc6\x62\xd3\x2e\xf9\xfc\x13\xdd\xd3\x4a\x68\xcb\xc5\x3e\x6c\x3f\x19\x26\xf3\x4a\xd2\xb1\x51\x1f\x44\x76\x0a\x41\x69\xc9\x62\x4d\xea\xfa\xde\xec\x78\xd9\x55\x41\x22\x4f\x50\x5e\x28\x4d\x35\xba\xdd\x38\x11\xf1\x2e\x47\xae\xfd\x2d\xea\xd7\x19\x9a\xe5\xd5\xf1\x97\xe4\xc2\x64\xed\xcc\x67\x9c\xa3\x34\xff\x07\x80\x08\x4a\xa8\xaf\x59\xfc\x19\xb5\xcf\x92\xb6\x83\xec\xa9\x84\xba\x4d\x46\xd3\x06\xeb\x8e\x31\x6b\x80\x6c\x03\x15\x21\x3f\xa5\xea\x0f\xc1\xb7\xb3\x4e\xb7\x2a\xed\xa2\x8c\x9b\xb3\x95\xe0\x5b\x1f\x33\x5a\x28\x4c\x60\xee\x6e\x6a\xa1\x69\xe6\x76\x49\x63\xba\xc4\x86\xb0\xe8\x5a\x85\xda\xe6\xc2\xd5\xbf\x03\xcc\x14\xb6\xb0\x35\x2c\x27\x60\xcb\x0e\xcc\x59\x4f\x3a\x5e\xb5\xbe\x99\xaf\xfa\xae\x94\x82\xd5\x29\x26\xaa\xfe\x68\xef\xe3\x1a\x0f\xba\x6b\xa8\x14\x9f\x64\xc8\x76\xd1\x49\x4b\x95\xfc\x24\x53\xa6\x79\xce\xfc\xb2\x2c\xfc\xb2\x2a\x20\xb2\xc1\x7b\x64\x86\xd6\x0c\x2e\x81\x3c\x24\xae\x29\x9b\x01\x16\x63\x27\x06\x44\x40\xcc\x4c\x73\x34\xed\x65\x7c\x39\x05\x6a\xc6\x0d\x19\x1b\x82\x26\x7b\xaa\x39\xf8\x95\x9c\xac\x3a\xf0\xcc\x8f\x53\x96\x25\x12\x79\x07\x9d\xd3\x43\x37\x4d\x36\x42\xc2\x85\x91\xb0\x72\x1f\x18\x84\xdd\xaa\x50\xb1\x90\xa8\xfc\x0c\xf9\x56\xa7\x2b\x60\x97\x97\xc3\x8c\x56\xfd\x52\xaa\x40\x1f\xd8\xc7\x7e\xfe\x9a\x09\x9e\xd3\x43\x3f\x0f\x2b\x5a\x6a\x3c\x0d\xef\xbe\x4a\xb6\x74\x77\x9a\x9e\x39\x94\xc1\xba\xc7\xcf\x05\xf5\xb9\x94\xb2\x0f\xec\xa3\xbd\x97\xb6\xa5\x42\x04\xe7\x55\x57\x3d\x5f\x75\x10\xe6\xc9\xcf\xf8\x0e\xbb\xf4\xcf\x4e\x33\x59\xf5\xec\x0c\x3b\x59\xd5\x00\xea\x4b\xfc\xb0\xe8\xae\x7b\x29\x3a\x1e\x7c\x98\x97\x81\x9d\x4a\xdd\x13\x0f\x69\x1f\xcb\xaf\xcc\x5b\xd9\x50\x7e\x40\xe0\xb2\x1b\xd0\xf2\x19\xdd\xbf\xf0\xe1\x01\xcb\x8f\xd3\xed\xb7\xd6\x9e\x7a\xc6\x01\xec\x8a\x84\x6a\xbc\x38\x2f\x61\xe7\xdf\xdb\xfe\x3f\x5b\xd5\xaf\xbc\x66\x72\x84\xf3\xea\x75\x17\xce\xab\x3f\xf2\xfc\x13\x00\x00\xff\xff\x27\x3e\x57\xf1\xfc\x11\x00\x00")
+var _beamerLargeHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x58\xeb\x6e\x23\xb7\x15\xfe\xef\xa7\x38\xa1\xb1\xb0\x14\x5b\x23\xc9\xbb\x4e\xb6\x23\x8d\x52\xdb\xd9\x45\x83\x6e\xda\x02\x71\x7f\x04\x86\x7f\x50\x33\x47\x1a\xae\x29\x8e\x42\x52\xb2\x55\xc7\x40\x1f\xa2\x8f\x90\x37\xc9\x9b\xf4\x49\x0a\x72\x6e\x9c\x9b\xac\x22\xc9\x62\xb1\x86\x76\x86\xe7\xf6\x9d\x0b\x0f\x0f\x67\xfa\xc5\xb7\x7f\xbf\xbe\xf9\xf1\x1f\xef\xe0\x2f\x37\xdf\x7f\x98\x1d\x4d\x63\xbd\xe2\xb3\x23\x80\x69\x8c\x34\x32\x0f\x00\xd3\x15\x6a\x0a\xb1\xd6\xeb\x01\xfe\xb4\x61\xdb\x80\x5c\x27\x42\xa3\xd0\x83\x9b\xdd\x1a\x09\x84\xe9\x5b\x40\x34\x3e\xea\xa1\x51\x30\x81\x30\xa6\x52\xa1\x0e\xfe\x79\xf3\x7e\xf0\x96\xc0\x30\xd3\xa4\x99\xe6\x38\xbb\x42\xba\x42\x39\x1d\xa6\x6f\x29\x85\x33\x71\x0f\x12\x79\x40\x94\xde\x71\x54\x31\xa2\x26\xa0\x77\x6b\xcc\xf4\x86\x4a\x11\x88\x25\x2e\x02\x32\xb7\xf2\x9e\x59\xc9\xa4\xad\x4c\xfa\x0c\x30\x4f\xa2\x1d\x3c\x65\x2f\x00\x0b\x8e\x8f\x83\x88\x49\x0c\x35\x4b\x84\x0f\x32\x79\x98\x94\xc4\x44\xe8\x81\x62\xff\x42\x1f\xde\x8c\x46\xaf\x4a\xc2\x43\xcc\x34\x0e\xd4\x9a\x86\xe8\x83\x48\x1e\x24\x5d\x97\x44\x03\x68\x90\x6c\x51\x2e\x78\xf2\xe0\x03\x72\xce\xd6\x8a\xa9\x9c\xe1\xf9\x28\x7b\xb0\x48\x66\x10\xb1\xad\x83\x27\x46\xb6\x8c\xb5\x0f\x63\xc7\x5e\x21\x11\x8f\x1d\xce\x15\x95\x4b\x26\x7c\x18\x35\xd8\x8e\xd7\x9c\xee\x98\x58\x3a\xcc\x0f\x2c\xd2\xb1\x0f\x17\xae\x13\x11\x53\x86\xd1\xb7\x31\x98\x74\x46\x24\x4c\xf8\x66\x25\x4a\xfa\xc7\x8d\xd2\x6c\xb1\x1b\x64\x89\xf5\x21\x44\xa1\x51\x96\x0c\x94\xb3\xa5\x18\x30\x8d\x2b\x55\x27\x36\x21\x7e\xe9\x80\xcc\x63\x36\x78\xf4\x21\x66\x51\x84\xe2\xe0\xa0\x02\xac\xe8\xe3\x20\xf3\xb2\x35\x76\xc7\x1c\x69\x84\x72\x9e\x50\x19\x7d\x0e\x81\xb1\xda\x94\xa6\x52\xb7\xd6\xdb\x57\x2f\xb9\x10\x9f\xb7\xd5\x82\xf9\xe7\x5d\xe0\x0a\x46\x75\xb3\x0a\xf9\xa2\x3b\x1d\xae\xe6\x84\xb7\x69\x9e\xd4\x43\xf6\xa7\x97\x10\x72\x06\x6a\x4d\x85\xa3\x6c\xc1\x13\xaa\x7d\x90\xa6\xc4\x5f\x94\xad\xee\x8b\xdf\x50\x1b\xa5\x85\x70\x23\x25\x0a\x0d\x4f\x75\x5f\x3e\x9f\x7d\x21\xe8\x0a\x55\x13\xe0\x78\xf4\xc9\x11\x36\x0b\xb4\x8e\xb2\xde\xbb\x0e\x84\x55\x69\xb1\x0d\x4c\x6d\xfb\x62\x4f\xe4\x5a\x23\x54\x07\xea\xad\x65\xb2\x94\xa8\x5a\x02\xfb\xda\x8d\x6b\xde\x7c\x47\xde\x57\xb8\x2a\x97\xe7\x89\x8c\x50\x0e\x24\x8d\xd8\x46\x19\xea\xb9\x4b\x4d\xfb\xf0\x40\xe6\xa2\x0e\xb1\xc4\x11\x9a\xfa\x85\xa7\xb6\x6e\x75\xd1\x86\x80\x6e\x74\x32\xa9\x63\x75\x17\x4b\xd5\xcc\x6d\x67\x1d\x27\x56\xc9\x1d\x6d\x50\xb7\xf3\x3b\x1d\x07\x60\x4d\xa3\x88\x89\xa5\x6f\xfb\xc9\xeb\xc3\x83\x31\xa7\xe1\xfd\x52\x26\x1b\x11\x0d\xc2\x84\x27\xd2\x87\xe3\x37\xd7\x97\xef\x2f\x46\x4d\x24\x12\x15\xca\x2d\x35\x9b\xe9\x93\x02\x5a\x26\x3c\x42\x21\x93\xa8\x0d\xd2\x4f\x1b\x54\xfa\x0c\x8e\x05\x3e\x1e\x12\xa6\x8e\x43\xe7\x77\x6a\x57\x35\x10\x59\xa1\xe9\x64\xed\xc3\x18\x57\x4d\xfe\xee\x2a\x7f\xdb\x56\x63\xe3\xfd\x71\xb2\x33\x4e\xc3\xc8\x9f\x57\x18\x31\x0a\x3d\xe3\x39\x55\x6b\x0c\xf5\x40\x9a\x1c\xfa\xf0\x66\xf8\xba\xef\x58\xae\xcd\x59\x2f\x77\xa7\xe7\xe2\xa9\x75\x30\x2a\x71\x57\x36\x4c\x29\x55\x8c\x14\x6c\xe5\x4e\x3e\x69\x92\x72\xd9\xf3\x17\x64\x5d\xb9\xd6\xc4\x3a\x32\xcd\xd3\xe4\x65\x19\xf7\x8c\xfb\x7f\xe4\xea\xc7\x42\x87\x44\x16\x65\x33\x8f\xfa\x50\x9d\x4a\x1b\xca\x9a\x01\x6e\x6b\x48\xb9\x58\xfa\xff\x74\x58\xcc\xd2\xd3\x61\x7e\x07\x98\x9a\x7c\x65\xa3\xb6\x51\xc9\xa2\x80\x64\xf1\x24\x60\xf9\x03\x52\x1c\x0d\x22\x11\x38\xc9\x06\x73\x80\xa9\xc9\x95\xe1\xb7\xfd\x91\x80\x92\x61\x40\x4a\x6a\xae\xcd\x5e\x05\xc8\xec\xaf\x54\x52\x8d\xd3\x61\xc4\xb6\x0d\x16\x2a\x35\x53\x9a\xcc\xae\x2e\xaf\x7e\xfc\xfe\xdd\xcd\xe5\x87\x76\x36\xd3\xfe\x3a\x41\x7d\xbb\x41\xad\xdb\xe5\xf2\xad\x45\x20\xe4\x54\x29\x67\x21\x67\x75\x98\xe7\x54\x16\x36\xd2\x98\x5e\x8c\x5e\x91\x59\x55\x73\xf9\xe2\x3e\xe6\x2a\x9c\x3a\xe9\x84\x9b\x6b\x8a\xcf\x53\xab\x66\xb2\x1d\x64\xa1\x9a\x0e\xe3\xf3\xc2\x54\xc2\x4b\x06\x03\x23\xe1\xdd\x86\xb3\xa2\x76\xb4\x8f\xed\x3a\x8b\x0a\xd7\x99\x55\x12\x8f\x0b\x96\x5c\xd6\xe9\xe8\x9d\xa0\x1d\x9b\x15\x49\xdb\x78\xbb\xc8\xb6\xfa\x0b\x48\x19\x21\x33\xb0\x65\x8a\xcd\x19\x67\x7a\xe7\x67\x8d\x96\xcc\x2c\xbd\x91\x28\x57\x2a\x4d\xcb\xd7\xaf\x8b\xb4\x64\xbf\xe6\xe7\x8a\x53\x77\xed\x77\xb7\x7a\xfe\xb6\xcd\xea\xfb\x24\xf9\x43\xad\x8e\xbf\x6e\xf5\x95\xca\xcf\xc6\x6a\x47\xee\xf1\x51\x93\x59\xc7\x76\x51\xa1\x64\x6b\x9d\x7d\x08\xa0\xeb\x35\x67\xa1\x2d\xbf\xe1\x47\xba\xa5\x29\x31\x6b\x2a\xd9\x57\x81\x8f\x06\xe5\x30\xa5\x54\x74\xe4\x76\xc9\x46\x21\x28\x2d\x59\xa8\x49\xde\x05\xb7\x54\x82\xdd\x8a\x0a\x02\xb8\xcd\x16\x01\x9e\xec\x5e\xf3\x81\x5c\xa1\xd2\x08\x3f\xfc\xfa\x8b\x58\xa2\x24\x67\x70\x8f\x3b\x1f\x88\x62\xe6\x55\x91\xe7\xb3\x4e\x89\x44\x2c\x55\xc9\x6f\xdf\xf2\x7e\x7b\xe7\x1a\xcf\x36\xe5\x95\xc1\x00\x41\x79\x17\x33\x34\xf7\x44\x09\x40\x6c\x38\x9f\xe4\x67\xf5\x62\x23\xec\xc8\x0d\x12\x45\x84\xf2\x43\xc9\xd9\x73\x4f\x6a\xa3\xc5\x8c\xdf\x94\x09\x94\x10\x40\x94\x84\x9b\x15\x0a\xed\x2d\x51\xbf\xe3\x68\x1e\xaf\x76\xdf\x45\xbd\x4a\x53\xea\xe7\x18\x00\xd8\x02\x7a\x5f\x38\x34\x57\x37\x94\x9a\x3d\x5b\x18\x5e\xd6\x10\x20\x00\x62\x5a\x42\x11\x64\xf3\x27\x51\x6f\x64\xeb\x48\x50\xa4\x00\x82\x2c\x15\xb7\x6e\x50\x8a\x68\xa5\xde\xa0\xd0\x92\xa1\xc9\x96\x03\xeb\xd6\xfe\x7a\xf7\xb8\xbb\x83\x9f\x7f\x86\x5b\x47\xc6\x78\x90\xc9\x78\x1c\xc5\x52\xc7\x10\x04\x01\x8c\xfe\x28\x4f\x38\x53\x7a\x5f\xa0\x1b\x21\x7e\x88\x19\x47\xe8\x19\x39\x6f\xc1\xa4\xd2\xd7\x31\xe3\x51\x15\x9e\x25\x4a\x5c\x25\x5b\xb4\xd4\x06\x77\x1b\x9a\x45\x22\xa1\x67\x20\x31\x5b\x57\xc0\x60\x0a\xd5\x48\x4c\x80\x9d\x9e\x56\x2d\x19\x7e\xce\x5c\x07\x42\x89\x54\x63\xe6\x43\x8f\x70\xe6\x82\x4f\x05\x54\x98\x48\xdc\x23\x63\x3e\x15\x54\xa5\xac\x84\xc7\x84\x40\x79\x63\x26\xe1\x20\x47\x76\xcb\xee\x3c\x4b\xac\xdb\x30\xe7\xc4\x1e\x13\x11\xdb\x56\x2d\x18\xfe\x2e\x03\x86\xe6\xf2\x72\xe6\xd1\xf5\x1a\x45\x94\x06\xd7\xda\xef\xef\x61\x30\xf2\x35\xba\xd2\x15\x0e\xce\x5a\x53\xb2\xbf\x2a\xb2\x03\xbe\x5f\x81\x6d\xeb\xc5\xb3\x94\xc9\xd1\x01\xe5\x6a\x86\x44\xd2\x18\xed\x15\xea\xef\xcc\xf7\x80\x2d\xe5\xbd\xbc\x75\x54\x1a\x45\xad\x0d\xf5\x2a\xef\xa7\x30\xee\xc3\xab\x6c\x73\xe6\xb5\x53\x88\xb6\x74\xa0\xc2\xfe\x99\x19\x62\x47\xa3\x7e\x57\xdf\xea\x29\x4d\x35\xba\x40\x3a\x43\xc4\xa2\x3c\x32\xe6\x83\x35\x04\x60\x45\x3d\xcd\xc2\x7b\xd4\x1e\x2b\xae\x7c\x69\xb9\xb8\x37\xd0\xa0\x5b\xa9\x3b\xd6\x38\x19\x73\x96\x2b\xc9\xa8\x98\xe4\x74\x8e\xdc\xb4\x1b\x42\xda\x25\xeb\xb9\x69\x91\xfe\x06\x08\x01\xbf\xd1\x64\xf6\xe0\x4d\x87\xa9\x7e\x37\x2c\x95\x88\x65\x1d\x95\x89\x88\xbd\x71\x06\x95\x65\xd3\x1b\x53\x59\x43\x74\xb3\x00\x05\xfb\x25\x57\xf0\xb7\x5f\x7f\x09\x63\xa5\x51\xf9\x70\x4c\xe0\x34\xb3\x67\x38\x2a\x71\xaf\x2b\x4c\x7d\xac\xaa\xcd\x14\x9f\x06\x40\xa0\x57\xd3\x65\xd9\xe1\x14\x48\xdf\xc1\xe8\x6e\x9f\x86\x01\xe3\x6b\xb7\xfe\xff\xfe\xfb\x3f\x50\x33\x61\x04\xda\x75\x3f\xbf\x1c\x7c\xa3\xa1\x16\x79\xb3\x54\xea\x33\x61\xce\x6f\x9b\x7b\x8a\x2e\xbf\x40\xf5\x8f\x9a\x6e\xc5\x54\xfd\xd0\x70\xca\xea\x45\x19\x16\xa9\x36\x6e\x78\xc8\xe9\x5a\x61\x04\x43\x77\x51\x27\x9a\x72\xd7\x43\x13\x31\x2b\x3b\xad\x9f\x79\x90\xeb\x2c\x66\x0e\xf3\xf7\x0c\xc8\x15\x96\x62\x33\x18\x77\x88\x8d\x2b\x62\xce\x73\xa7\xe3\xe9\x4d\xb0\xef\xa9\xba\x2b\x96\x30\x39\x44\x45\x5b\x8b\x74\xdd\xaf\xf6\xc9\x3d\x8a\xb2\x4b\x65\xa7\xa6\x94\x7e\x90\x2a\x7b\xf1\xec\x37\x5a\xb1\xa3\xcc\x70\xc0\x37\x40\xe6\x3c\x09\xef\xdb\x36\xfc\x1e\xed\xe6\xc6\x99\x2b\xb7\x57\x1b\xd3\x9e\x6d\x0a\xbe\x34\xcd\xb5\x6f\x36\xcc\xab\x8a\xaa\xac\xbe\x1a\x80\xaa\x67\x83\xf9\x6b\x1b\x30\x6b\x85\xf0\x74\x88\xe2\x86\x37\x55\xc5\x69\x24\x9c\x35\xc7\xca\x21\xc7\x48\x39\x01\xa8\x7d\xfb\xca\x32\x90\xbe\x17\x9a\x23\x58\xa2\xa8\x48\xaf\xe8\x63\xb5\xd4\xdb\xc6\xa3\x6a\x23\x35\x63\xc0\x0b\xa3\x92\x82\xa0\x4d\xe8\x96\xdd\x4d\xea\x5d\x0b\x66\xe6\x6b\x55\x7d\x2f\xa5\xb0\x54\xfb\x56\x7a\xde\x0b\xd6\xba\xdb\x0d\xcf\xec\x60\x06\xb3\x1a\x3e\x57\xa8\x8e\xc5\xd2\xcc\x7c\x94\xe6\xb7\xbc\x1b\x42\x00\x27\xe9\xf5\xf0\xc4\x05\x9a\xce\x21\x4c\x6c\x2a\x3b\xee\xf9\xa8\x36\x85\x75\xa9\x4c\x2f\x9f\xbc\x5a\x38\x85\x40\x9e\xc4\xdb\x51\xf5\xb9\xb6\x11\xda\x83\x0f\x43\x13\xeb\xce\x0d\x72\xa0\x91\xf2\xbb\xe9\xb5\xf9\xde\x6d\x20\x3b\xc7\x5f\x66\xd3\x7e\x51\xad\x27\xbc\x69\x60\x7c\xd7\x3d\xbb\xe4\xdc\x93\x46\xe2\xf3\xff\xd5\x66\x6e\xee\xb5\x73\xec\x9d\xe0\x16\x85\x56\x27\x67\x70\x62\x5d\x3f\x39\xcb\x36\x4e\xb6\x59\xdc\x0b\xf0\x74\x98\x7e\xbd\x9b\x0e\x63\xbd\xe2\xb3\xa3\xff\x0d\x00\x78\x38\x50\xb8\xf3\x1f\x00\x00")
 
 func beamerLargeHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -192,11 +192,11 @@ func beamerLargeHtml() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "beamer/large.html", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x72, 0xff, 0x5, 0xea, 0xdd, 0xd2, 0xda, 0xa0, 0xf2, 0xf4, 0xfc, 0xb1, 0x7, 0x5c, 0x7d, 0x55, 0x87, 0xa2, 0x39, 0x33, 0x49, 0x2f, 0x8d, 0x25, 0xcc, 0x6a, 0xb8, 0xe4, 0x83, 0x9f, 0x10, 0x32}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3b, 0x7d, 0x6f, 0xb8, 0x72, 0xba, 0x6a, 0x6, 0x7d, 0xda, 0x9a, 0xf4, 0xaf, 0x86, 0x40, 0x5d, 0xaf, 0xfe, 0x5e, 0xdd, 0xd0, 0xc9, 0x1b, 0x52, 0x43, 0x23, 0x2f, 0x1d, 0xa3, 0x7a, 0x16, 0xde}}
 	return a, nil
 }
 
-var _beamerSmallHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x55\x5d\x8f\xeb\x34\x10\x7d\xef\xaf\x18\x8c\xae\x6e\x0b\x34\x69\x41\x42\x57\x69\xd2\x87\x7b\xb9\x08\x24\x10\x48\x94\x07\x1e\x1d\x7b\x1a\x7b\xd7\xb1\x83\x3d\x69\xb7\xac\xf6\xbf\xa3\x7c\x34\x71\x77\x55\x24\x9e\x3a\x1e\xcf\x39\x33\x3e\x3e\x4e\xf3\x2f\x7e\xf8\xed\xd3\xe1\xaf\xdf\x3f\xc3\x4f\x87\x5f\x7f\xd9\x2f\x72\x45\xb5\xd9\x2f\x00\x72\x85\x5c\x76\x01\x40\x5e\x23\x71\x50\x44\xcd\x1a\xff\x6e\xf5\xa9\x60\x9f\x9c\x25\xb4\xb4\x3e\x5c\x1a\x64\x20\x86\x55\xc1\x08\x9f\x28\xed\x08\x76\x20\x14\xf7\x01\xa9\xf8\xf3\xf0\xe3\xfa\x03\x83\x74\x64\x22\x4d\x06\xf7\x1f\x91\xd7\xe8\xf3\x74\x58\x0d\x3b\x46\xdb\x47\xf0\x68\x0a\x16\xe8\x62\x30\x28\x44\x62\x40\x97\x06\x47\x5e\x11\x02\x03\xe5\xf1\x58\xb0\xb2\xc7\x27\x5d\x66\x44\xf7\x98\x21\x06\x28\x9d\xbc\xc0\xf3\xb8\x00\x38\x1a\x7c\x5a\x4b\xed\x51\x90\x76\x36\x03\xe1\x4c\x5b\xdb\xdd\xb8\xff\xb2\x18\x03\xb5\x8d\x30\x35\xf7\x95\xb6\x19\x6c\xde\x94\x7d\xa9\x65\xcc\xed\x2c\xad\x83\xfe\x07\x33\xf8\x6e\xb3\xd9\xbc\x7b\x5b\xde\x78\x57\x79\x0c\x21\x02\x9d\xb5\x24\x95\xc1\x36\xaa\x07\x50\xa8\x2b\x45\x19\x7c\x8f\xf5\x9c\x2c\xb9\x78\xac\xbc\x6b\xad\x5c\x0b\x67\x9c\xcf\xe0\xac\x34\xe1\xfd\x2e\x67\xcf\x9b\x06\xfd\xdb\x66\x1f\xe2\x5e\x0d\x97\x52\xdb\x6a\x5d\x3a\x22\x57\x67\xb0\xfd\x76\x6e\xfa\x32\x08\x9a\x4e\x8a\xe6\xe9\xd5\x09\x79\x27\xec\x28\xb8\xd4\x27\xd0\xb2\x60\xa2\xf5\x1e\x2d\xb1\xab\xf6\xb9\xda\xf6\x79\x2d\x19\x08\xc3\x43\xe8\xc3\x7d\x9e\xaa\xed\x88\x4c\xa5\x3e\xbd\x22\x79\x35\xfd\x4c\xf6\xba\x60\xe2\x9c\x13\xfd\x9c\x05\x3b\xe9\xa0\x4b\x6d\x34\x5d\x32\x50\x5a\x4a\xb4\x13\x4b\xc4\x53\x72\xdf\xcd\x32\x4d\x70\x3b\x4e\x14\x06\xe1\x75\x43\xa3\xfd\x78\xd3\x18\x2d\x78\xe7\x9e\xf4\x81\x9f\xf8\xb0\xc9\x20\x78\x31\x79\xf1\x21\x74\xc4\xc3\xce\x0d\xc7\xb5\x11\x6b\x03\x42\x20\xaf\x05\xb1\xab\xd6\xc7\xd6\xf6\x9e\x04\x8f\x56\xa2\x5f\x06\xe2\x84\xab\xe8\xf2\xa4\x13\x6d\x8d\x96\x92\x0a\xe9\xb3\xc1\x2e\xfc\x78\xf9\x59\x2e\x3b\x4d\x57\x89\xb6\x16\x7d\xf7\x6c\xa1\x80\x1e\x9a\x90\x16\x8f\x48\x89\x96\xf3\x5d\x9f\xb8\x87\xc9\x83\xc5\x7d\xc6\x49\xd1\xd5\x0d\xb4\xe4\xfe\xbf\x50\x9d\xa0\x33\x40\x1f\x61\x38\x43\xa2\x78\xf8\xc3\xd9\x2a\x3e\xcb\x38\x0a\x7a\x31\x8d\x1b\x9c\xad\x12\x34\xbc\x09\x28\x21\x8d\x93\xe4\x88\x9b\x5d\x84\xed\xa8\x7b\x6c\x0e\x9b\x5b\x56\xb8\x72\x6e\xe2\xfa\x17\x40\x13\x70\x86\xed\x61\x7b\x07\xb6\xbd\x81\x45\x71\xc9\x7d\xd2\xbb\x2b\xe9\xdf\x10\x14\x23\xd5\x57\xdd\xcb\x5d\xc1\xd7\xc0\xde\xb1\x18\x2b\x9c\x0d\xce\x60\x62\x5c\xb5\x64\x41\xb9\xb3\xb6\x55\x24\x0e\x4c\xd7\x30\xb2\xce\x96\x85\x02\x06\x03\x1b\x8c\x18\xc7\x23\x3c\xdf\x6b\xa1\xb4\xfc\x5f\x1d\xc6\x67\x11\x35\x58\xdc\xfe\xb6\x8d\xe4\x84\xcb\xf7\xfd\x3d\xbc\xff\x66\x74\xe5\x6a\x77\xfd\x22\x4c\x7e\xce\xd3\xe1\x4b\x90\xa7\xc3\xbf\xc5\xbf\x01\x00\x00\xff\xff\x9f\xf0\x09\x68\x45\x06\x00\x00")
+var _beamerSmallHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x54\x51\x6f\xdb\x36\x10\x7e\xcf\xaf\xb8\x70\x28\xe2\x6c\xb1\x64\x6f\xc0\x50\xb8\x92\x1f\xda\x75\xd8\x80\x0e\x1b\xb0\xec\x61\x8f\x94\x78\x11\xaf\xa1\x48\x8d\x3c\x3b\xf5\xda\xfc\xf7\x41\x12\x2d\xd1\xf1\x12\xa0\x80\x01\x9f\xc8\xfb\xbe\x3b\xde\x77\x77\xc5\xe5\x4f\xbf\xbf\xbb\xfd\xfb\x8f\xf7\xf0\xcb\xed\x6f\x1f\xb6\x17\x85\xe6\xd6\x6c\x2f\x00\x0a\x8d\x52\xf5\x06\x40\xd1\x22\x4b\xd0\xcc\xdd\x12\xff\xd9\xd1\xbe\x14\xef\x9c\x65\xb4\xbc\xbc\x3d\x74\x28\xa0\x1e\xbf\x4a\xc1\xf8\x89\xf3\x9e\xe0\x0d\xd4\x5a\xfa\x80\x5c\xfe\x75\xfb\xf3\xf2\xb5\x80\x3c\x32\x31\xb1\xc1\xed\x5b\x94\x2d\xfa\x22\x1f\xbf\xc6\x1b\x43\xf6\x1e\x3c\x9a\x52\x04\x3e\x18\x0c\x1a\x91\x05\xf0\xa1\xc3\xc8\x5b\x87\x20\x40\x7b\xbc\x2b\x45\x35\xe0\xb3\xfe\x24\xa2\x07\xcc\x68\x03\x54\x4e\x1d\xe0\x73\xfc\x00\xb8\x33\xf8\x69\xa9\xc8\x63\xcd\xe4\xec\x06\x6a\x67\x76\xad\x7d\x13\xef\x1f\x2f\xa2\xa1\xd7\x09\xa6\x95\xbe\x21\xbb\x81\xd5\x99\xdb\x37\xa4\x52\x6e\x67\x79\x19\xe8\x5f\xdc\xc0\x0f\xab\xd5\xea\xd5\xff\xb9\x67\x1e\x03\xfa\x3d\xa6\xb8\xda\x19\xe7\x37\xd0\x38\xa3\xd0\x7a\xa7\xce\x71\x9d\x77\x8d\xc7\x10\x12\xd0\x03\x29\xd6\x1b\x58\x27\x71\x00\x34\x52\xa3\x79\x03\x3f\x62\x3b\x1f\x56\xb2\xbe\x6f\xbc\xdb\x59\xb5\x8c\x91\x1e\x34\x31\x3e\x1f\xe5\xc1\xcb\xae\x43\x7f\x1e\xec\x75\x1a\xab\x93\x4a\x91\x6d\x96\x95\x63\x76\xed\x06\xd6\xdf\xcf\x41\x1f\x07\x64\x91\x4f\x4a\x14\xf9\xb1\x83\x8a\x5e\x90\x28\x94\xa2\x3d\x90\x2a\x45\xbd\xf3\x1e\x2d\x47\xfd\xfa\x76\x5b\x0f\xe7\xa4\x04\xd4\x46\x86\x50\x0a\x52\x62\x5b\xe4\x7a\x1d\x91\xb9\xa2\xfd\x13\x92\x27\xd9\xcf\x64\x4f\x1d\x26\xce\xf9\x60\xc8\xb3\x14\x7b\x0a\x54\x91\x21\x3e\x6c\x40\x93\x52\x68\x27\x96\x84\xa7\x92\x5e\x6c\x93\x0c\x4e\xd3\x49\xcc\x50\x7b\xea\x38\xb6\xad\xec\x3a\x43\xb5\xec\xbb\x2e\xff\x28\xf7\x72\xbc\x14\x10\x7c\x3d\xf5\xf0\xc7\xd0\x13\x8f\x37\x27\x1c\xc7\x40\x62\x17\x10\x02\x7b\xaa\x59\x1c\x6b\x7d\xb7\xb3\x43\x2f\x83\x47\xab\xd0\x2f\x02\x4b\xc6\xeb\x44\xbc\xbd\xf4\x40\x0a\x4a\x50\xae\xde\xb5\x68\x39\x6b\x90\xdf\x1b\xec\xcd\xb7\x87\x5f\xd5\xa2\x2f\xee\xf5\x91\x0e\x80\x54\x46\xd6\xa2\xef\x77\x00\x94\x30\xf0\x65\x4c\xf5\x3d\x72\x46\x53\x73\x0e\x7e\xc3\xcc\x3e\xf5\x31\xb2\x42\x03\x5f\xbe\x80\x98\x72\x1c\x9c\x87\xaa\x7f\xa0\xc0\x19\xbb\xa6\x31\xb8\x10\xc7\x59\x10\x37\x70\x79\x79\xce\x91\xe4\xd4\xbf\xe1\x28\xd7\x4b\x2f\x99\x24\xbd\x3e\x81\x56\xd2\xbf\x84\xea\x15\x9d\x01\x74\x07\x63\x11\x33\x2d\xc3\x9f\xce\x36\x69\x31\x63\x2a\xe8\xeb\xe9\xd9\xc1\xd9\x26\x43\x23\xbb\x80\x0a\xf2\xf4\x90\x1d\x4b\x33\x3f\x62\xa4\x1e\xb0\x05\xac\x4e\x59\xe1\xc8\x39\x2d\x99\xfe\xf7\x08\x68\x02\xce\xb0\x2d\xac\x9f\x81\xad\x4f\x60\x89\x5d\x49\x9f\x0d\xed\x9d\x0d\x1b\x03\xca\x48\xf5\x6d\xbf\x3a\xae\xe1\x3b\x10\xaf\x12\x99\xfa\x65\x64\x83\x33\x98\x19\xd7\x2c\x44\xd0\xee\x81\x6c\x93\x14\x07\x26\x19\x22\xeb\x3c\x33\x50\xc2\x38\x41\x06\x13\xc6\xf8\x84\xcf\xcf\x85\xd0\xa4\xbe\x2a\x42\x9c\xcb\x24\x40\xb4\x8e\xff\x61\x57\xf5\x13\x54\xe1\xe2\x0a\xf7\x68\x39\x5c\xdd\xc0\xd5\x20\xca\xd5\x4d\x9c\x91\xd8\x58\xe9\xa8\x15\xf9\xb8\x97\x8a\x5c\x73\x6b\xb6\x17\xff\x0d\x00\x6b\xbf\x31\x2e\x0b\x07\x00\x00")
 
 func beamerSmallHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -212,7 +212,7 @@ func beamerSmallHtml() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "beamer/small.html", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc9, 0xe1, 0x64, 0x1b, 0xa2, 0x5, 0x17, 0x9a, 0x74, 0x8b, 0x67, 0xbc, 0x46, 0xa4, 0x25, 0x52, 0xc7, 0x1f, 0x9d, 0xcb, 0x25, 0x1a, 0x18, 0xe7, 0xdf, 0x7c, 0x7e, 0x90, 0x80, 0x6b, 0x6f, 0x67}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8e, 0xae, 0x47, 0x2e, 0xb9, 0x68, 0x21, 0xa1, 0x44, 0x55, 0xf6, 0x89, 0xa3, 0xf7, 0x14, 0x34, 0x11, 0x58, 0x3, 0xf7, 0x28, 0xf0, 0xce, 0x6a, 0xae, 0x60, 0x76, 0x98, 0x90, 0x1e, 0xf6, 0x15}}
 	return a, nil
 }
 
@@ -496,7 +496,7 @@ func registrationIndexHtml() (*asset, error) {
 	return a, nil
 }
 
-var _registrationTicketHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x54\x4d\x73\xd3\x30\x10\xbd\xf7\x57\x2c\xee\xf4\x56\xd7\x01\x86\x19\x70\x15\x5f\x5a\x18\x98\x81\x81\x83\x39\x70\x54\xac\xb5\xbd\x44\x96\x84\xbc\x4e\x1a\x3c\xfe\xef\x8c\xe3\x8f\x24\x6e\xaf\x9c\xb2\x7a\x4f\x79\xda\xb7\x7a\xb2\x78\xf5\xf8\xfd\x21\xfd\xf5\xe3\x23\x7c\x4e\xbf\x7d\x4d\xae\x44\xc9\x95\x4e\xae\x00\x44\x89\x52\xf5\x05\x80\xa8\x90\x25\x94\xcc\x2e\xc4\x3f\x0d\xed\xd6\xc1\x83\x35\x8c\x86\xc3\xf4\xe0\x30\x80\x6c\x58\xad\x03\xc6\x27\x8e\x7a\x81\x7b\xc8\x4a\xe9\x6b\xe4\xf5\xcf\xf4\x53\xf8\x3e\x80\x68\x54\x62\x62\x8d\x49\x4a\xd9\x16\x59\x44\xc3\x6a\x60\x6a\x3e\x4c\x35\x40\xaf\x71\x0b\x1b\xab\x0e\xd0\x8e\x10\x40\x25\x7d\x41\x26\x86\xd5\xfd\x0c\x39\xa9\x14\x99\xe2\x02\x2b\x91\x8a\x92\x63\x78\xbd\x5a\xdd\x4c\x68\x37\xfe\x5e\xe7\x8d\xd6\x61\x4d\x7f\xf1\x4c\xf7\xa5\x3f\x00\xec\x49\x71\xb9\x04\x9d\xad\x89\xc9\x9a\x18\xe4\xa6\xb6\xba\x61\x3c\x71\x6c\xdd\x45\x1f\x1a\x73\xbe\x00\xec\x0e\x7d\xae\xed\x3e\x86\x92\x94\x42\x73\x62\x14\xd5\x4e\xcb\x43\x0c\xb9\xc6\xa7\x13\xfc\xbb\xa9\x99\xf2\x43\x38\xce\x37\x86\x0c\x0d\xa3\x3f\x6d\x90\x9a\x0a\x13\x12\x63\x55\x2f\xc9\xd9\xf1\xde\x4b\xe7\xd0\x9f\xf9\x1d\x9d\x7d\x38\x37\x36\xcd\xe0\x02\xec\xaf\x33\x3c\x9e\xf1\xfc\xe8\xff\xd8\xb2\x3b\xeb\x35\xb7\x86\x8f\xf7\x15\x43\xdb\x52\x0e\x77\xce\x93\xe1\x5c\x92\x46\xd5\x75\x6f\x57\xab\x9b\xb6\x45\x5d\x63\xd7\xbd\x1b\x6a\xa3\xba\x6e\x29\x88\xd5\x52\x71\x3f\xda\xdd\x58\xad\xee\x17\x87\xf5\x39\x8c\xc1\x58\x5f\x49\xbd\xe4\x8e\x8d\xbc\x79\x16\x2c\x11\xcd\xe9\x15\xd1\xf4\x6e\x44\x1f\xdf\x31\xdc\x8a\x76\x40\x6a\x1d\xcc\xf1\x0b\xa6\xa4\xcf\xd4\x78\x4f\x33\x01\x20\x5c\xf2\x88\x64\x10\x86\xe7\x62\x9a\xaa\x42\x1f\x8b\x8d\x9f\x5e\xd3\x71\x13\x56\xc9\x75\xdb\xde\x7d\x79\xec\x3a\x11\x61\x95\x88\xc8\x9d\xd8\x17\x66\xd6\xab\xfa\x26\xdb\x42\x8e\xa5\x2e\xb0\xce\x4a\x2d\x0b\x34\xb7\x83\xee\x86\x98\x11\xa4\x01\x85\x1e\xd2\x12\xb7\x08\x15\x6a\x85\xa6\x97\x1d\xc7\x3b\x75\x1e\x29\xda\x8d\xfe\xa6\x52\x44\x83\x69\x11\x0d\x9f\x91\x7f\x01\x00\x00\xff\xff\xff\x84\x86\x2f\x5e\x04\x00\x00")
+var _registrationTicketHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x53\xcf\x6e\xd4\x3e\x10\xbe\xef\x53\xcc\x6f\xab\xde\x9a\x66\x7f\x20\x24\x48\xbd\x91\xa0\x2d\x02\x09\x04\x12\xe9\x81\xa3\x37\x9e\xc4\xa6\xb6\x63\x9c\xc9\x6e\x97\x28\x6f\xc6\x8d\x17\x43\xf9\xbb\x4d\x8a\xb8\xa1\x1c\x32\xfe\xbe\x99\x6f\xc6\x33\x63\xf6\xdf\xcd\xa7\xeb\xe4\xeb\xe7\x5b\x78\x97\x7c\xfc\x10\xaf\x98\x24\xa3\xe3\x15\x00\x93\xc8\x45\x6b\x00\x30\x83\xc4\x41\x12\xb9\x00\xbf\x57\x6a\xbf\x5d\x5f\x17\x96\xd0\x52\x90\x1c\x1d\xae\x21\xed\x4f\xdb\x35\xe1\x03\x85\xad\xc0\x15\xa4\x92\xfb\x12\x69\x7b\x97\xbc\x0d\x5e\xae\x21\x1c\x94\x48\x91\xc6\x38\x51\xe9\x3d\x12\x0b\xfb\x53\xcf\x94\x74\x1c\x6d\x80\x56\xe3\x02\x76\x85\x38\x42\x3d\x40\x00\x86\xfb\x5c\xd9\x08\x36\x57\x13\xe4\xb8\x10\xca\xe6\x33\x4c\xa2\xca\x25\x45\xf0\xff\x66\x73\x3e\xa2\xcd\xf0\x3f\xcb\x2a\xad\x83\x52\xfd\x40\xa8\xff\x1a\x00\x70\x50\x82\xe4\x12\x74\x45\xa9\x48\x15\x36\x02\xbe\x2b\x0b\x5d\x11\x9e\x38\x2a\xdc\xac\x0e\x8d\x19\xcd\x80\x62\x8f\x3e\xd3\xc5\x21\x02\xa9\x84\x40\x7b\x62\x84\x2a\x9d\xe6\xc7\x08\x32\x8d\x0f\x27\xf8\x5b\x55\x92\xca\x8e\xc1\xd0\xdf\x08\x52\xb4\x84\xfe\xe4\xc0\xb5\xca\x6d\xa0\x08\x4d\xb9\x24\xa7\x1b\x1f\x3c\x77\x0e\x3d\xd4\xcb\x9b\xbd\xda\x9c\x3f\x6d\xda\x0c\x6c\xc7\x19\x74\x39\x9e\xa6\xfe\x87\x25\xbb\x47\xb5\x66\x85\xa5\x6e\x5e\x11\xd4\xb5\xca\xe0\xd2\x79\x65\x29\xe3\x4a\xa3\x68\x9a\xe7\x9b\xcd\x79\x5d\xa3\x2e\xb1\x69\x5e\xf4\xb6\x15\x4d\xb3\x14\x44\xb3\x54\x3c\x0c\xd7\xdd\x15\x5a\x5c\xcd\xa9\x6e\x0f\x23\xb0\x85\x37\x5c\x2f\xb9\xae\x90\x67\x8f\x56\xa2\x4f\xc1\xc2\x69\x7b\x59\x38\xbe\x1b\xd6\xae\xef\xb0\xdc\x42\xed\x41\x89\xed\x7a\x5a\xbf\xf5\xb8\xe9\x13\x35\xcc\x69\x22\x00\x98\x8b\x6f\x50\x59\x84\xfe\xb9\xd8\xca\x18\xf4\x11\xdb\xf9\xf1\x35\xb5\x1f\x43\x13\x9f\xd5\xf5\xe5\xfb\x9b\xa6\x61\x21\x9a\xb8\x6f\xd3\x17\xe2\x39\x36\xcd\xc2\xf9\xcd\xaf\x9f\xd2\x76\xad\x1c\x1d\x86\x8e\xf5\x41\xb7\xc9\xeb\x27\x21\x29\xbf\x6c\xdd\x3b\x0a\xee\xa4\x1f\x02\x58\xe8\x4e\x3e\x7f\x98\x4c\x5b\xbb\xaf\xd2\x7b\xc8\x50\xea\x1c\xcb\x54\x6a\x9e\xa3\xbd\xe8\xd5\x77\x8a\x08\x81\x5b\x10\xe8\x21\x91\x78\x8f\x60\x50\x0b\xb4\xad\xec\x90\x61\x50\x67\xa1\x50\xfb\x78\x35\x33\x59\xd8\xb7\x96\x85\x92\x8c\x8e\x57\xbf\x07\x00\x95\x2e\xff\x76\xc4\x04\x00\x00")
 
 func registrationTicketHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -512,11 +512,11 @@ func registrationTicketHtml() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "registration/ticket.html", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x23, 0x47, 0x84, 0x19, 0x12, 0x93, 0xb, 0x5e, 0x33, 0x63, 0x6d, 0xd9, 0x30, 0x6e, 0x3e, 0x71, 0x9c, 0xa0, 0xdd, 0x38, 0x9c, 0xe1, 0x1f, 0xcb, 0xd5, 0xb2, 0x6d, 0x84, 0x2c, 0x2a, 0xd3, 0x56}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x46, 0xfc, 0xa5, 0x16, 0x1b, 0x36, 0xcc, 0x1e, 0xfd, 0x25, 0xba, 0xab, 0x80, 0x65, 0x5c, 0x5e, 0xef, 0xe5, 0x88, 0xf4, 0x89, 0xd5, 0x6, 0x3, 0xb7, 0xa6, 0x87, 0x95, 0x29, 0x6f, 0x7, 0x96}}
 	return a, nil
 }
 
-var _webAdminHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x56\xdb\x6e\xe3\x36\x10\x7d\xcf\x57\x4c\x69\xf4\x6d\x65\xc7\x0b\x04\x68\x1d\x59\x45\x9b\xdd\x5e\x80\xdd\x6d\x80\x3a\x28\xfa\x38\x16\x27\x12\x1b\x8a\xd4\x92\x94\x1d\x57\xd0\xbf\x17\xd4\x5d\xb6\xb6\x69\xd0\x3e\x99\x1c\x9e\xb9\x9d\x39\x22\x1d\x7e\xf5\xee\xd7\xbb\xdd\x1f\xf7\xef\xe1\xe7\xdd\xc7\x0f\xd1\x55\x98\xba\x4c\x46\x57\x00\x61\x4a\xc8\xfd\x02\x20\xcc\xc8\x21\xa4\xce\xe5\x01\x7d\x2e\xc4\x61\xcb\xee\xb4\x72\xa4\x5c\xb0\x3b\xe5\xc4\x20\x6e\x76\x5b\xe6\xe8\xd9\xad\x7c\x80\x5b\x88\x53\x34\x96\xdc\xf6\x61\xf7\x63\xf0\x0d\x83\x55\x1b\xc9\x09\x27\x29\xfa\x1d\x8d\x23\x1b\xa7\x12\x55\x42\xe1\xaa\x31\x36\x00\x29\xd4\x13\x18\x92\x5b\x66\xdd\x49\x92\x4d\x89\x1c\x03\x77\xca\xa9\x0d\x1f\x5b\xcb\x20\x35\xf4\xb8\x65\x47\xda\x2f\xfd\xb6\x75\xad\x1d\x9a\x35\xc0\xe2\x73\x41\x05\x41\xd9\x6e\x01\xb8\xb0\xb9\xc4\xd3\x06\x1e\x25\x3d\xdf\xf6\x66\xbf\x0b\xb8\x30\x14\x3b\xa1\xd5\x06\x62\x2d\x8b\x4c\x0d\xe7\x7f\x16\xd6\x89\xc7\x53\xd0\xf6\xd8\xb8\x07\xd6\xa1\x71\x03\xe8\x28\xb8\x4b\x37\xf0\xed\xf5\xd7\x9d\xad\xba\x6a\x17\x4b\x27\xe2\x27\x72\xa3\x42\x5a\xf0\xfa\x7a\x40\x03\xec\xb5\xe1\x64\x36\xb0\xce\x9f\xc1\x6a\x29\x38\xec\x25\xc6\x4f\x03\x20\x43\x93\x08\x15\xec\xb5\x73\x3a\xdb\xc0\xf5\xf2\x86\xb2\xcb\x64\x82\x8f\x12\x79\xba\x02\x94\x22\xf1\x6d\x91\x72\x64\x46\x6d\x6b\xe5\x02\x2b\xfe\xa2\x0d\xac\x6f\xc6\x85\xd4\x07\x47\x12\x49\xea\x36\xb0\xd7\x92\x5f\x94\xe0\x74\xee\xf3\xaf\x87\xfc\x33\xd5\xad\xe7\xaa\x53\x98\x91\x1d\x15\xf8\x9f\x03\x62\xec\xc4\x61\x3c\xe4\x3d\xc6\x4f\x89\xd1\x85\xe2\x41\xac\xa5\x36\x1b\xf8\xe0\x3b\xf9\x41\x16\x74\xe1\xbd\x40\x9e\x09\xf5\x06\x16\xd6\xa1\x1b\x07\x19\x71\xf3\xf6\x7a\x66\xa4\x17\x0e\x5f\x26\xfa\x2c\xd9\x4b\x2a\xf8\xbf\x34\x7a\x3e\xed\x4b\x5a\x8e\xa9\x70\xf4\x45\xba\xe7\xc8\x6e\x3b\xc0\xb9\x4f\x6a\x2f\xf5\x58\xab\x97\xe9\xa4\x9f\x42\x62\xf0\x34\x80\xda\x93\x33\x99\xbf\xf8\x1d\x34\x80\xc0\x20\x17\x85\xdd\xc0\x4d\x3e\xe2\x29\x47\xce\x85\x4a\xbc\x5c\xde\x5e\x8a\xe9\xc2\x5c\x8f\x8d\x53\xac\x0d\x36\xbc\x2a\xad\xe8\xf6\x55\x53\xcd\xf4\x81\x32\x52\x2f\x7e\xde\xff\x72\xb0\x36\xc7\x98\x82\x3d\xb9\x23\xd1\xeb\xee\xa0\x99\x92\xf0\xb2\xa8\x9b\x19\x35\x97\xa5\x78\x84\xe5\x3d\x16\x96\x78\x55\x9d\x0d\x7b\x91\x7b\xfb\x3f\x7e\x60\x3b\x9d\xa1\xd3\x43\xd8\x2e\x2a\xa9\x2e\x5c\xb8\xea\x6f\xe6\x70\xd5\xbd\x2a\xe1\x5e\xf3\x53\x7b\x71\x73\x71\x00\xc1\xb7\xec\x68\x30\xcf\xc9\xb0\xee\x0e\xef\x0f\x14\x1e\x7a\x23\x40\x88\xed\xfd\x5f\x5f\xf1\xec\xfc\x35\xc1\xa8\x07\x78\xce\x85\x4a\x58\xf4\x49\x1f\xe1\xbe\xd9\x4c\x00\xc4\x85\x63\xd1\x27\xcc\x48\x01\x09\xe5\x0c\x26\xa4\x26\x08\xab\x55\x62\x59\xf4\x9b\xff\xf1\x07\x5d\x69\x2b\x2e\x0e\xd1\x84\xc2\xf7\xc6\x68\x53\x55\x7d\xd1\xe4\xf7\x2c\x2a\xcb\xfe\xa4\xf6\x19\x33\xd3\xb9\x7e\xb4\xc9\xc8\x31\x23\x6b\x31\xa1\xda\xb5\x39\x99\x71\xec\xd1\xf5\x65\x34\x66\x27\x8f\x16\x65\xb9\xbc\x2b\x8c\x21\xe5\xaa\xaa\xc9\xf0\x90\xc7\x3a\x13\x2a\xa9\xaa\x37\xc0\x51\x61\x9c\x42\x59\x8e\xac\xcb\x36\x7a\xb8\xca\x67\x5b\xec\xd3\xd5\xc2\x98\x0e\xc3\x9b\x6b\x9d\x74\xef\x72\x8d\xf9\x0e\x6b\x51\x6f\x9b\x93\x68\x2a\xb3\x07\x55\x9b\xcb\x92\xa4\xa5\xaa\xba\x6f\x37\x4d\x05\x38\x8a\xde\x73\xd2\xaa\x9a\x0d\x93\x99\x64\x49\xb4\x57\x26\x8b\x7e\xd2\xb5\x44\x27\x23\x9c\x00\x91\x1f\x50\xc5\xc4\xa2\xef\x9b\x45\x8d\x9c\x74\x3a\xdb\x76\xf3\x8a\xdb\x51\xe3\x65\x69\xbc\xde\x60\xb9\x6b\x8e\xfa\xc9\x8c\x04\xda\x64\xf6\x22\xdb\x96\xe5\xf2\x97\x77\x55\xc5\xa2\x3a\x64\x2c\xd1\xda\x2e\x2a\x8b\xc2\xbc\xb3\x08\xce\xea\xf1\x79\xac\x9f\x45\x43\x9b\x17\xa8\xad\xaa\x50\xcb\x0e\x57\x3f\xa5\x9e\xd5\xb6\x88\x0e\x21\x85\x57\x8d\xf7\xad\x57\x2d\xa3\x5a\x0e\x6b\xdf\xdd\x84\xe3\x33\x5d\x0d\xed\xf7\xcb\x70\xd5\x7c\xac\xe1\xaa\xf9\x73\xf8\x77\x00\x00\x00\xff\xff\xf6\x79\x8d\xdb\x34\x0a\x00\x00")
+var _webAdminHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x56\x4b\x8f\xdb\x36\x10\xbe\xef\xaf\x98\x6a\xd3\x9e\x22\x3b\x1b\x20\x40\xeb\x95\x55\xb4\x9b\xf4\x01\x24\xdb\x45\xb2\x69\xd1\x23\x2d\x8e\x25\x66\x29\x52\x21\x69\x7b\x5d\x41\xff\xbd\x20\xa9\x07\x65\xd9\x4e\xd2\xe6\x24\x92\xf3\xcd\xeb\x9b\xe1\x50\xc9\x37\x2f\xff\xb8\xb9\xff\xfb\xee\x15\xfc\x76\xff\xe6\x75\x7a\x91\x14\xa6\xe4\xe9\x05\x40\x52\x20\xa1\x76\x01\x90\x94\x68\x08\x14\xc6\x54\x31\x7e\xdc\xb0\xed\x32\xba\x91\xc2\xa0\x30\xf1\xfd\xbe\xc2\x08\x32\xbf\x5b\x46\x06\x1f\xcd\xdc\x1a\xb8\x86\xac\x20\x4a\xa3\x59\xbe\xbf\xff\x25\xfe\x3e\x82\x79\x6b\xc9\x30\xc3\x31\xfd\x8b\x28\x83\x3a\x2b\x38\x11\x39\x26\x73\x7f\xe8\x01\x9c\x89\x07\x50\xc8\x97\x91\x36\x7b\x8e\xba\x40\x34\x11\x98\x7d\x85\xad\xf9\x4c\xeb\x08\x0a\x85\xeb\x65\xb4\xc3\xd5\xcc\x6e\x5b\x55\xa7\xe0\xd7\x00\x97\x1f\x37\xb8\x41\xa8\xdb\x2d\x00\x65\xba\xe2\x64\xbf\x80\x35\xc7\xc7\xeb\xfe\xd8\xee\x62\xca\x14\x66\x86\x49\xb1\x80\x4c\xf2\x4d\x29\x06\xf9\x87\x8d\x36\x6c\xbd\x8f\xdb\x1c\xbd\x7a\xac\x0d\x51\x66\x00\xed\x18\x35\xc5\x02\x7e\x78\xf6\x6d\x77\xd6\x5c\xb4\x8b\x99\x61\xd9\x03\x1a\xa8\x0f\xc1\x57\xcf\x06\x34\xc0\x4a\x2a\x8a\x6a\x01\x57\xd5\x23\x68\xc9\x19\x85\x15\x27\xd9\xc3\x00\x28\x89\xca\x99\x88\x57\xd2\x18\x59\x2e\xe0\xd9\xec\x05\x96\x53\x67\x8c\x06\x8e\x2c\x5d\x31\xe1\x2c\xb7\x69\xa1\x30\xa8\x06\x73\x6b\x29\x4c\xac\xd9\x3f\xb8\x80\xab\x17\x61\x20\x4e\xb0\x43\x96\x17\x66\x01\x2b\xc9\xe9\x24\x04\x23\x2b\xeb\xff\x0a\xcb\x89\x68\x88\xee\xea\x58\x74\x82\x94\xa8\xa1\x3e\xd4\xfa\x1f\x06\xa5\x2e\xe4\x4e\x7f\x66\xce\x99\xe4\x52\x2d\xe0\x5e\x96\xc4\xc8\x89\xab\xff\x1e\x05\xc9\x0c\xdb\x86\xad\xb6\x22\xd9\x43\xae\xe4\x46\xd0\xb8\xf5\xf9\xda\xf2\xf9\x33\xdf\xe0\x44\xfb\x92\xd0\x92\x89\xa7\x70\xa9\x0d\x31\xa1\x91\xa0\x42\xcf\x83\x56\x19\x14\x0f\x15\x4e\xa7\x7e\xe0\xec\x53\xbd\xf8\xb5\x6e\xca\x21\xff\x53\x5a\x76\x05\x33\x78\x92\xee\x63\x64\xb7\x19\x10\xa8\xa7\xe1\xae\xb8\xcc\x1e\xce\xb9\xe3\xb6\x0a\xb9\x22\xfb\x01\xd4\x4a\x0e\x2e\xdb\x27\x6f\xa3\x07\xc4\x8a\x50\xb6\xd1\x0b\x78\x51\x05\x3c\x55\x84\x52\x26\x72\x7b\x0b\x9e\x63\x79\x98\xdd\xe4\xd8\x95\x8d\x62\x26\x15\xf1\x13\x48\x48\x81\xd7\x5f\x54\xd5\x52\x6e\xb1\x44\x61\x9e\xc2\xac\x42\x61\x9d\x03\x65\xdb\xaf\x54\x66\x5d\x91\x0c\xe3\x15\x9a\x1d\xe2\x97\xcd\xc5\x69\x80\x40\x82\x10\xc9\x34\xc0\x60\x12\xf5\xca\x3d\xbe\x9e\x94\xe7\x79\x5f\x9e\x13\x77\xfa\xfc\xb4\xac\x6b\xb6\x86\xd9\x1d\xd9\x68\xa4\x4d\x73\xd0\x60\x97\x95\x3d\x87\xfa\x4c\x3b\x8d\x9d\x76\x06\xea\x1a\x45\x67\x2e\x99\xf7\x6f\x52\x32\xef\xde\xd3\x64\x25\xe9\xbe\x7d\xb2\x6c\x99\x18\x5d\x46\x3b\x45\xaa\x0a\x55\xfb\x92\x05\x02\x41\xb6\xfd\x21\x40\x42\xda\x97\xcf\x3d\x6e\xd1\xe1\x3b\x4a\xd2\x1e\x60\x6f\x04\x13\x79\x94\xde\xca\x1d\xdc\xf9\xcd\x08\x80\x94\x99\x28\xbd\x25\x25\x0a\x40\x26\x8c\x22\x39\x8a\x11\x42\x4b\x91\xeb\x28\x7d\x67\x3f\x56\xd0\x85\x36\xa7\x6c\xdb\x6d\x3c\x85\xaf\x94\x92\xaa\x69\xfa\xa0\xd1\xee\xa3\xb4\xae\x7b\x89\xd3\x09\x99\xe9\x54\xdf\xe8\x3c\x50\x2c\x51\x6b\x92\xa3\x53\xf5\x92\x23\x8a\x3d\xda\x0d\xc0\x90\x9d\x2a\xbd\xac\xeb\xd9\xcd\x46\x29\x14\xa6\x69\xbc\x87\xf7\x55\x26\x4b\x26\xf2\xa6\x79\x0a\x94\x08\x92\x15\x50\xd7\xc1\xe9\xac\xb5\x9e\xcc\xab\xa3\x29\xf6\xee\x5c\x63\x84\xee\x88\x3b\x76\x7d\xd2\xfd\x91\x38\xcc\x8f\xf6\x41\x90\x62\xe9\x24\xdf\x91\xb2\xba\xde\xa2\xd2\x4c\x8a\x65\x5d\xcf\xfe\xf4\xcb\xa6\x89\xd2\x71\xff\xbd\x17\x0e\x5f\xd7\xc8\x35\x36\xcd\x5d\xbb\xb1\x89\x07\xec\x07\xf1\x74\x97\x2a\x1a\x4a\x36\x72\x9f\x4b\x3b\x01\xcf\xf8\xff\x55\xba\xa6\x1e\x15\x7d\x64\x81\xd0\x2d\x11\x19\x9e\x31\xf1\x93\x47\x38\x13\x21\x69\x3d\x3b\xfe\x85\x3e\x4a\x8f\x17\x9d\x31\x7e\x2b\x63\x8b\x18\x65\x5f\xd7\x4f\x5a\x30\x2c\x96\x30\xa0\x03\x80\xb2\x97\x01\x66\x77\x7e\x6c\x04\x22\xc7\x5c\xc6\x89\xd6\xcb\xa8\x1d\x2a\x41\x39\xfb\xfe\xb9\x77\x3f\x6c\x4d\xb3\xb0\x7d\xf2\x16\x89\x96\x62\xd4\x1d\x9d\xa9\x13\xa4\x29\xfc\x80\x99\x71\x69\xb5\x4e\x6c\x5a\xbf\xbf\x6c\x9a\x28\x7d\xeb\x64\xa7\x09\xcf\xa4\x58\x33\x55\x1e\x53\x3e\xe0\xe9\xc9\xf6\xb3\x8a\x30\xde\xb6\xed\x74\xb6\xcb\xfd\xef\x6a\xf7\x4f\x3d\x62\xd4\x13\xa3\x43\x46\xc7\x59\xd8\x99\x32\x24\x1b\xd2\xed\xad\x46\x69\x52\x75\x27\x8c\x46\x8e\x6d\x8b\xb5\xe4\xfa\xcb\x70\x2b\xdf\xd9\x1f\xba\xa6\x19\x80\xbe\x4d\x74\xdf\x0e\xda\xd5\x65\x00\x3a\x5d\x97\x97\x37\x71\x63\x1b\x92\x73\xa4\x47\x8d\xf4\xd2\x89\x9e\x1d\x85\xd6\x9e\xe4\xbd\x92\x3d\x89\xd2\x3e\xff\x0e\xc1\x99\x9d\x4f\x36\x6c\xb7\x6a\xaf\xa8\xe4\xc3\xda\xb2\x7e\xd0\xb6\x27\x98\xef\x97\xc9\xdc\x3f\x0b\xc9\xbc\x30\x25\x4f\x2f\xfe\x1d\x00\xb6\xc6\x78\x10\x98\x0d\x00\x00")
 
 func webAdminHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -532,7 +532,7 @@ func webAdminHtml() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "web/admin.html", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x42, 0x45, 0x52, 0x34, 0xd8, 0xc5, 0xe0, 0xbc, 0xb7, 0x24, 0x4e, 0x7d, 0xa1, 0x28, 0xd1, 0x63, 0x21, 0xc5, 0x3, 0x12, 0x29, 0xb1, 0x48, 0xfe, 0x6b, 0xd4, 0xeb, 0x8f, 0xb8, 0x92, 0x62, 0xb}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1e, 0x68, 0xaf, 0x9e, 0x6e, 0xf4, 0x2b, 0x1, 0x39, 0x64, 0xe0, 0xf, 0xde, 0xec, 0xc0, 0x18, 0xf9, 0x44, 0xf2, 0xd5, 0x4c, 0x6d, 0x19, 0x5, 0x17, 0xc5, 0x20, 0x72, 0x36, 0xaf, 0x89, 0x4e}}
 	return a, nil
 }
 
@@ -556,7 +556,7 @@ func webEditHtml() (*asset, error) {
 	return a, nil
 }
 
-var _webIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x3a\xed\x72\xdb\x36\xb6\xff\xfb\x14\x27\x4c\x6f\x25\xb5\x22\x29\xcb\xce\xbd\x2d\x43\xe9\xce\x6d\xea\xa6\xed\x4d\xd2\x4e\xe2\x76\x77\x27\xc9\xce\x40\xe4\x91\x88\x98\x04\x58\x00\x94\xe5\x75\x3d\xb3\x4f\xb3\x4f\xd1\x7f\x7d\x93\x7d\x92\x1d\x80\xa4\x04\x7e\x48\x8e\xeb\xf4\x6b\x67\xfb\xa3\x21\x80\xf3\xfd\x85\x73\x20\x87\xf7\x3e\xfb\xfa\xd1\xd9\x5f\xbe\x39\x85\x2f\xce\x9e\x3e\x99\xbf\x17\x26\x2a\x4b\xe7\xef\x01\x84\x09\x92\x58\x7f\x00\x84\x19\x2a\x02\x89\x52\xb9\x8b\xdf\x17\x74\x3d\x73\x1e\x71\xa6\x90\x29\xf7\xec\x32\x47\x07\xa2\x72\x35\x73\x14\x6e\x94\xaf\x09\x3c\x84\x28\x21\x42\xa2\x9a\x7d\x7b\xf6\xb9\xfb\xb1\x03\xbe\x4d\x89\x91\x0c\x67\xce\x9a\xe2\x45\xce\x85\xb2\xf0\x2f\x68\xac\x92\x59\x8c\x6b\x1a\xa1\x6b\x16\x63\xa0\x8c\x2a\x4a\x52\x57\x46\x24\xc5\xd9\x91\x37\x71\x2a\x52\x8a\xaa\x14\xe7\xdf\xa6\x4a\x10\xa9\x88\x08\xfd\x72\xa3\x3c\x4c\x29\x3b\x07\x81\xe9\xcc\x91\xea\x32\x45\x99\x20\x2a\x07\x12\x81\xcb\x99\x93\x11\x85\x82\x92\xd4\xaf\x3f\x5c\x1a\x71\x26\xbd\x48\xca\x9a\xb6\x41\x2a\xbf\x01\x16\x3c\xbe\x1c\x83\x56\x0b\xae\xaa\x2d\x00\x23\x5d\x00\x47\x93\xc9\x7f\x3d\xdc\x6e\xf2\x35\x8a\x65\xca\x2f\xdc\x4d\x00\x09\x8d\x63\x64\xbb\xb3\x9c\xc4\x31\x65\xab\x00\x26\xbb\xbd\x8c\x88\x15\x65\xd6\xd6\xf5\x7b\xd5\xc7\x7d\x92\xe7\x37\x71\x8b\xa9\xcc\x53\x72\x19\xc0\x32\xc5\xcd\x6e\x5b\xaf\xdc\x98\x0a\x8c\x14\xe5\x2c\x80\x88\xa7\x45\x66\x09\xf2\xa6\x90\x8a\x2e\x2f\xdd\xca\xea\x25\xba\xab\x4d\xa8\x76\x40\x24\xa5\x2b\xe6\x52\x85\x99\x0c\x20\x42\xa6\x50\xf4\xcb\x38\x87\x0f\x0f\xcb\xb9\x03\x67\x64\x6d\x81\x26\x48\x57\x89\x6a\xeb\xd4\xab\x68\xce\x25\x2d\x75\x59\xd2\x0d\xc6\xbb\x03\xc5\xf3\x86\x3d\x53\x5c\xaa\xc6\xc6\x82\x44\xe7\x2b\xc1\x0b\x16\xbb\x11\x4f\xb9\x08\xe0\xfe\xa3\x47\x8f\xde\xce\x61\xbf\x9a\x79\xb5\xd4\x96\x4e\x82\xb0\x5a\x5d\x63\x0d\x98\x78\x27\xb2\xdf\x9a\x4d\xe3\x97\xd1\xe4\x2e\xb8\x52\x3c\x0b\x60\xe2\x3d\xc0\xcc\x92\x9b\x33\xe5\x4a\xfa\x37\x0c\x60\xda\xe7\x1e\xe2\x35\xfd\x53\xd9\x6b\x91\x92\xe8\xdc\x92\x0e\x37\xca\x8d\x31\xe2\x82\x94\x22\x32\xce\xf0\x06\xf7\x2d\xf8\x46\x33\x36\xc1\xbf\xe0\x22\x46\xe1\x2e\xf8\xc6\x3e\xd7\x7b\x01\x1c\xe5\x1b\x90\x3c\xa5\x71\x9b\x69\x85\x24\x48\x4c\x0b\x19\xc0\x83\x7c\x73\xd0\xc3\xa7\xa7\xa7\x5d\x73\xe9\x6a\x86\xc2\xd2\xef\x70\x68\xec\x10\xa3\x94\x4b\x5c\x28\x36\x86\xfb\x3c\x47\xb6\x50\xcc\x22\x62\x19\xf5\x78\xba\x13\x6b\x8b\xed\xd1\x38\x08\x16\xb8\xe4\x02\x1b\xa6\xad\x42\xc3\xb9\xef\x74\x51\xbe\x2f\xb0\xb0\xa1\x7f\xa3\x34\xdf\xe3\x4c\x4b\xe3\xa3\x07\x3d\x61\x54\x89\xef\xd1\xd8\x52\xc1\x44\x8d\xe1\xd5\xe5\x62\x08\x5e\x54\xc5\x60\xc1\xd3\x78\x1f\xc9\x98\xae\xbb\x95\xe6\x93\x66\xa0\xbd\xdb\x40\x42\xc4\x9e\x5a\xad\xd3\xaa\xa7\x62\x7b\x92\xb3\x95\xbc\xa9\x66\x57\x12\x44\x3c\x4d\x49\x2e\xd1\xb8\xcd\x7c\x75\xb5\xb0\x33\xab\xcd\x45\xd9\xd6\xad\x11\x26\x87\x2e\x9b\x0e\x05\x11\x30\x95\xb8\x51\x42\xd3\x78\xc8\xe3\x78\x74\x38\x35\x2c\x53\x74\x65\xb1\x28\x4d\x6c\x3a\x17\x5c\xc4\xee\x42\x20\x39\x0f\xc0\xfc\xe3\x92\x34\xdd\x91\xd9\x4f\xe5\xe8\x16\x54\x1a\x64\xdc\x0b\x41\xf2\xbc\x91\xe7\xbf\xcf\xab\x53\x22\x11\x51\xd2\x15\xf3\x63\x5b\xca\x3d\x21\x77\xa7\x40\xdf\x19\x0c\x63\xaa\x7a\xec\x75\xd3\x3d\x41\x59\x5e\xa8\x7e\xf8\xde\x6b\xdf\xc0\xbf\x54\x97\x39\xce\x74\x19\x78\x7d\x93\x6b\xaa\xd0\x85\xa3\x69\x7e\xf0\x8e\xb8\x1f\x45\xd1\x5e\x85\x4f\x7a\x14\x4e\xc9\x02\xed\x0e\x6e\x9b\x23\x9a\x93\xf5\xbf\x49\x4f\x98\x50\x96\x52\x86\xee\x22\xe5\xb6\x95\x33\xb2\x71\x0f\x75\x3c\x96\xea\xb2\x58\x64\xb4\xa1\x7c\x4f\x92\x9d\x3c\xfa\xbf\xcf\x1f\x58\xec\xab\xfd\x8b\x84\x2a\xec\xc9\x6d\x23\xed\x74\xd2\x67\xa6\xe6\xad\xbc\xdf\x38\x00\x51\x21\xa4\x66\x92\x73\xda\xaa\xcb\x29\x27\x2a\x00\xa1\x0b\x73\x3b\x24\xdd\xb2\xf1\xb2\x1b\x8c\x5d\x5c\xa5\x9c\x68\xf9\xc6\xe0\xa1\x10\x5c\x8c\xc1\x93\x64\x8d\xf1\x4d\x7e\x3f\x74\x47\xd4\x6a\x4d\x7f\x4e\x61\x3f\x90\x45\x87\xfa\x92\x8e\x42\xb7\xef\x1d\x4a\x03\x1c\xc6\x5b\x2e\xff\x87\xfc\xf7\xc7\x3d\xe5\xac\x65\xb3\x1b\xc3\xa5\xac\xa7\xa1\x6f\x8d\x2e\xe1\x3d\xd7\x0d\x65\x24\x68\xae\x40\x8a\x68\xe6\xac\x0b\xf4\xde\x48\x67\x1e\xfa\xe5\xee\xdc\x75\xeb\x81\xc7\x82\xd2\x83\x9e\x0c\x7c\x7f\x5d\xe0\x1b\xe9\x71\xb1\xf2\xdf\x48\xbf\x83\xda\xc5\x5b\x17\xe8\x0a\x5e\x28\x14\x1d\xc8\xd0\xaf\xa7\xc9\x50\x4f\x53\x15\xb2\xbe\xd1\x69\x3c\x73\x48\x9e\x3b\xf5\xb0\xb5\xdd\x64\x64\xed\x80\x51\xa6\x9a\x0b\x83\x89\x33\x0f\x09\x44\x29\x91\x72\xe6\xd4\x6d\x59\x3d\xd4\xbd\x21\x6b\x52\xf2\x0b\xd6\x9c\xea\x8b\xc8\x01\xce\xa2\x94\x46\xe7\x15\xf0\x33\xb2\x1e\x8e\x9c\x79\x48\x6b\x12\xcd\xf1\xcf\x99\x1b\xa8\xd0\xa7\xf3\xd0\x27\xf3\xb0\xd4\xc4\x35\x83\x64\x85\x60\x44\x52\x7c\xe6\xf8\xa6\x23\x71\xe6\x7f\x22\x42\xa1\x8c\x92\x94\xb0\x15\x86\xbe\x85\x72\x03\xbe\xb9\xac\x9c\xf9\x0b\xfd\xcf\x6d\xf0\x74\xcd\x76\xe6\xcf\x48\x86\x0c\x90\x32\x25\xc8\x0a\x59\x8b\x80\x1f\xd3\x75\xc7\x9a\x65\xfb\x6b\x0c\xa8\x97\x55\x27\xfb\x56\xc6\xd3\xb0\x37\xda\x2e\x43\x56\x6c\x4d\xd7\x2b\x41\x75\x67\x3a\x5b\x0d\xf5\xfc\x3f\xdf\xca\x5e\xad\xb6\x98\xd5\x67\xf9\xad\x30\xcb\x53\xa2\xd0\x10\x52\x59\x9e\xba\x95\x03\xc2\x36\xa7\x4a\xbc\x2a\x65\x1d\x58\xbb\x74\xb9\x5b\xce\xb7\xe9\xf4\x84\xc4\x08\xff\xfc\xfb\x3f\x6a\xdc\x8e\xc8\x15\x21\x93\xc1\x35\x99\x72\xb1\x23\xf2\x39\x26\xa9\x2e\x4b\x57\x57\x50\x66\xfa\xf5\xf5\x4d\xf4\x2a\xb9\xb7\x24\xcc\xd9\xda\x5d\x72\x31\x73\x86\x8a\x46\xe7\xa8\xc6\x40\x59\x8c\x9b\x11\x50\x06\x6d\x70\x80\x30\xaf\x49\xd1\xd8\x99\x5f\x5d\x95\x38\x1e\x8d\xaf\xaf\x43\x3f\x6f\x40\xf2\xd4\x5e\x9a\x47\x91\x9a\x15\x23\x19\x6a\xfa\x15\xb6\x5e\x4a\x4d\x4d\x7f\x68\x42\x29\x6d\x50\xf2\x6d\x52\x4d\xdd\xda\x1e\x0b\xfd\xda\x59\xfb\x9d\x57\x45\xbf\x6d\x97\x46\xf7\xe6\xfc\xfe\x5d\x5a\x76\x41\xe6\x6a\x37\xaf\x5e\x9a\x5e\xc6\x63\xf3\xe0\x64\x5a\x3b\x07\xf2\x94\x44\x98\xf0\x34\x46\x31\x73\x5e\x14\x51\x82\x8e\x31\x41\x7d\x5e\x0a\x70\xaf\xbe\x5d\x3e\xf8\x00\xee\x35\xa5\x09\x15\x59\xa4\xd8\xb0\xd0\x5b\x60\x69\x3c\x31\x0f\x55\x32\xff\xff\x9f\x7e\x64\x52\xa5\x28\x42\x5f\x25\x66\xe7\x8c\x2a\x4c\xb7\xab\xaf\x48\x52\x1d\xf9\x4a\x34\xd0\xeb\x30\xd1\x3c\x75\x98\x2c\x69\xaa\x50\x60\xfc\xa2\x72\x9c\x8a\xe7\x57\x57\xfa\xd0\x23\x42\x51\xa9\x74\xc8\xa8\xd8\xde\x37\x6f\x71\xdd\xed\x4b\x24\xa2\xde\xb5\x98\x86\xbe\x51\xf5\xb6\x71\x54\x56\xc3\x86\x6f\xad\x9e\xf6\x0f\x10\x45\x76\x02\xe8\xfb\xbe\xa6\x57\x2e\x76\xf4\x1e\xa3\xcc\x91\x46\x09\x0a\xe5\xf5\x12\x5a\x72\x91\xc1\xda\xe5\x2c\x28\x1b\x4d\x2f\x17\xb8\x36\x4f\xaa\x9c\xbd\x30\x3b\xed\x9a\x53\xb1\x15\xfc\xa2\x69\x41\xd3\x25\x3b\xf3\xb0\xec\x96\x4d\x14\xe8\x42\x73\x66\x0a\x05\x2b\xb2\x4c\x87\x93\x39\xac\xaa\xb5\x8d\x6c\xb2\x42\x5f\x13\x26\x3b\xb6\x19\x41\x63\xa7\x91\x2a\xe5\xfb\xaf\xde\x6d\xe4\xc8\xc9\xd4\xa9\x69\xda\xca\xdd\x5e\xe0\x9c\x32\x67\xfe\xcd\x97\xcf\x6e\x2d\xa8\x46\xec\x91\xd4\x6c\x37\x44\x3d\x9a\x1e\x9f\xbc\x23\x61\x35\x8b\x23\x67\xfe\x94\x9e\x0b\x0e\xf7\x8f\x60\xf8\x58\xfc\xf4\x23\x1b\x83\xbe\xcc\xe5\xe8\xd6\x2a\x94\xe4\x7a\x94\xa8\x0e\x1a\x6a\xe8\x46\xe2\x1d\xaa\x31\xdd\xaa\x31\x85\xe1\xa7\x29\x29\xc6\x90\x51\xa5\xf0\x2e\xba\x4c\xf7\xe9\x32\xfd\x65\x75\x39\xde\xea\x72\x0c\xc3\xe7\x5c\xd5\xaa\x08\x8c\x12\xf5\x33\x75\x39\xfe\x8d\x74\x39\xd9\xea\x72\x02\xc3\xc7\x98\x2e\xc6\x77\x52\xe3\x64\x9f\x1a\x27\x77\x51\xc3\xbe\x51\x65\x55\xb2\x5a\xad\x86\xae\x72\x87\xef\x08\x7b\x36\x01\x70\x0a\x89\x20\x95\xa0\x91\xda\xbe\xb3\x2e\x0b\x66\x1e\x77\x60\xdb\xd6\xda\x4f\xad\x3c\x2a\x32\x64\xca\x5b\xa1\x3a\x4d\x51\x7f\x7e\x7a\xf9\x65\x3c\x1c\x30\xb2\x1e\x8c\x3c\x33\x8d\x78\xe5\xa3\xfc\x0c\x06\x7a\x7e\x1d\xb4\x9f\xb3\xb6\xf4\x77\x33\xc7\xcf\x66\x30\x19\x74\xe6\xc2\x2d\xf9\x9c\x4b\xf5\xd5\x8b\xaf\x9f\x0d\x0b\x91\x8e\x21\x26\x8a\x8c\x21\x5a\x8c\xf5\x7d\x63\x33\x5c\x13\x01\x9b\x44\xc0\x0c\x18\x5e\xc0\x9f\x9f\x3e\xf9\x42\xa9\xfc\x39\x7e\x5f\xa0\x54\xc3\xd1\x6e\x16\xde\x24\xc2\xd3\x16\x19\x0e\xbe\xf9\xfa\xc5\xd9\x60\x0c\x86\xac\x12\x05\xb6\x80\x04\xca\x9c\x33\x89\x67\x97\x39\x6a\x19\xdf\x48\xce\x06\x2d\x3a\x4c\x5f\xab\x30\xdb\x0a\xdb\x30\x01\x00\x5d\xc2\x50\xc3\x49\x45\x54\x21\x61\x3e\x83\xe9\x64\xa2\x3b\x19\x6b\x33\x9c\xc1\xf4\x93\x4f\x9a\x78\x00\xd1\x62\x68\xcb\x60\x89\x06\x70\x0d\x98\x4a\x6c\x21\xa0\x10\x16\xab\xb1\xc5\xe1\x0c\x37\xaa\x89\xbf\xfd\xbe\x6e\xaa\x23\x91\xc5\x43\x6d\x6a\x4f\x87\x12\x5b\xd1\xe5\xe5\x50\xdb\x7b\x34\xda\xef\x9d\x15\x5a\xce\x79\x27\x6e\x79\x7c\xfa\x1f\xaf\x74\xbc\xd2\xf5\x80\x9e\x46\x55\x39\x36\xc1\x0c\xbe\x2b\xd0\x8b\x78\x96\x73\x86\x4c\x0d\x07\x66\x7b\x30\xb6\xf3\x91\x28\xd2\xb2\x83\x40\x55\x08\xd6\x92\xb8\xea\x13\x03\x63\xfb\x71\x5b\x19\x2e\x02\x70\x9c\xe6\xb6\xe1\x15\xc0\xcb\xd7\xe3\x7e\x6d\xc6\xd6\xc3\x57\x59\xc3\x82\xfd\xd5\x61\x37\xf2\x0e\x46\x1e\x65\x0c\xc5\x17\x67\x4f\x9f\xec\x48\x44\x02\x89\xc2\xb8\xa5\x89\x4a\xa8\xf4\x96\xa8\xa2\xe4\x33\xa3\xe6\xc3\x3e\xee\x19\xaa\x84\xc7\x32\x68\x60\x5a\x48\x2d\x43\x18\x9a\x65\x4f\x3b\x03\x56\xec\x1e\xe2\xad\xe3\xda\xfa\x2f\x5f\xf7\x1c\xd6\x83\xcb\xcc\x98\xb2\x09\x50\x67\xcd\x80\xe4\xd4\xaf\x9c\xd5\x00\x00\x28\x33\x0f\x66\xf3\x96\x5c\x3d\xf4\x97\x24\x95\x2d\x06\x1d\x11\x35\x35\xaf\xc8\x23\x9e\x51\xb6\x6a\xc3\x5e\x77\x98\xd7\x01\xab\x2f\xbe\xbb\x0b\x51\x9b\xd1\x71\xe0\x23\xa8\x32\xec\x23\x70\x02\xd0\x6b\xcd\xe2\x06\x81\x9a\x89\x32\xee\x7a\xf7\x7a\xd4\x4c\x8b\xf2\x97\x96\x4e\x5a\x98\xed\x5f\x29\x2d\x0c\xaf\x76\x5a\x00\x94\x33\x71\x1b\xfc\x4e\xe9\x52\x6a\xf5\x07\x48\x97\xda\x2b\x77\x4b\x97\xca\x89\xbf\x4c\xba\xd4\x22\x6a\x6a\xff\x76\x59\xa2\xb3\x23\xcb\x0b\x85\x71\xcb\xab\xf6\xa3\x47\xb0\xef\xda\x2c\xef\x72\xa1\x8b\xc9\x73\x5c\x9d\x6e\xf2\x61\x69\x31\x13\xd0\x9e\x40\xd3\x10\x0f\xfd\x97\xee\x2b\xff\xd5\xab\xbf\xbe\xff\xe1\x47\xff\xeb\x0d\x47\x3f\xbc\x7c\xf5\xfa\xea\xfa\xb5\xbf\x1a\xc3\xe0\xd5\xab\xf7\x3f\x18\x8c\xc6\x30\xa0\x83\x51\x53\x95\x2a\xe5\x76\x1e\xf0\x4a\x91\x86\xe6\x59\xa6\xc7\xae\x15\x82\x40\x4f\xe9\x3e\xc2\x7a\xa0\x19\xc1\x0f\x3f\x34\xf7\xcd\x03\x4d\x8b\xe1\xf5\x68\x8f\xa9\xf6\xd4\x13\x8c\xa9\xea\x96\x13\xbd\xdb\xa8\x26\xb9\xe0\xb9\xce\xf9\x01\x8d\x07\x63\x18\xe4\x94\x0d\xac\xf4\xbf\x75\xad\x31\xc1\xf2\x76\xc5\x86\xac\xb5\x53\x7b\x10\xcc\x30\xdc\x45\x30\xb3\x58\xff\xf6\x71\xff\xf6\xc9\x3b\xad\x59\xc6\x74\xbd\x25\xab\xb7\xee\xd4\x6f\x3b\xfd\x65\xe7\x50\xe9\x28\x63\xca\xfc\xc4\xd4\x9b\x7d\xad\xcc\x6b\x1e\x5e\x50\x16\xf3\x0b\x4f\x46\x82\xa7\xe9\x19\x1f\x4e\xc6\x30\x69\x45\xd2\x76\x3a\x29\x6b\x13\x59\x37\xdb\xae\xf2\x3f\x1a\x07\x25\x23\x1a\xb7\x6b\x48\x4e\x59\x75\x96\x53\xd6\x3e\x34\xaf\xd5\x01\xbc\xec\xaf\x17\xc6\xb5\x6d\x14\xeb\x6c\x7a\xe0\xec\xf8\xc0\xd9\x49\xfb\xac\x75\x85\xb5\xca\xce\xfe\xca\xfb\x36\xb5\xaf\xe1\x9f\xae\xfb\xda\xbc\x6e\xac\xb8\xb7\xe6\xb9\x1f\xe2\x96\xf5\xf8\x60\x35\xbe\xb1\xc2\x94\x3f\x0e\x55\x63\xd2\x77\x05\x3e\x37\xeb\xe1\x4e\x3d\x03\xd0\x0a\x86\x2b\xc8\x89\x4a\x02\x18\xf8\x83\x31\x08\x2c\xff\x9e\x43\x2f\xcb\x96\xb2\x29\xd2\x0e\xb8\x9e\x0e\xb6\xa5\x2c\xa8\xe6\x88\x3d\xf0\x75\xdb\x64\xc1\x97\xf7\xe4\x1e\xf8\x3c\x25\x97\x94\xad\x9a\x18\x57\x56\x6d\x18\x98\x5f\xb3\x2a\xb0\xf2\x85\x63\x00\xd7\xfb\xc8\x55\x65\xd6\xa2\x65\xca\xf1\x01\x68\x3f\xa0\xb1\x1f\xe8\xf2\xdb\x41\x1b\xd7\x45\x5a\xc7\x9a\x4d\xe3\xf5\xd6\x31\x0f\x6b\xcf\x54\x3f\xf1\x96\x7f\xd5\x76\x4a\xa2\x64\x38\x54\x7c\x0c\x4b\xc1\xb3\x31\xb0\x6e\x04\xee\x1e\x42\x76\xbe\xd7\x60\xd6\xf4\xd6\xf2\x3a\xc9\xf3\x9d\xcb\xdb\xce\x16\x5b\x24\xef\xfd\x8c\x17\xfa\xc6\xb9\x4f\xf2\xbc\xbe\x3c\x9b\xbf\x3a\x97\x3f\x36\x87\x7e\xf9\x47\xcd\xff\x0a\x00\x00\xff\xff\x07\xff\x1f\x69\xec\x2c\x00\x00")
+var _webIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x3c\xed\x92\x23\x35\x92\xff\xe7\x29\x72\x6a\x0e\x6c\x1f\xfe\xea\x0f\x76\xc1\x63\x9b\x80\x99\x81\x65\x0f\x06\x62\x66\x60\x6f\x8f\x20\x36\xe4\xaa\xb4\x4b\x4c\x59\x2a\x24\x95\xbb\x9b\xc6\x11\xfb\x0e\xf7\x0a\x17\xf7\x14\xfc\xe3\x4d\xf6\x49\x2e\x52\x52\x7d\x97\xdd\xdd\x33\xc3\xc7\xdd\x5e\x40\x4c\x57\x49\xa9\x54\x66\x2a\xbf\x94\x52\x79\x7e\xff\xf1\x17\x8f\x5e\xfc\xf5\xcb\x27\xf0\xa7\x17\x9f\x7f\xb6\xbc\x37\x8f\xcd\x36\x59\xde\x03\x98\xc7\xc8\x22\x7a\x00\x98\x6f\xd1\x30\x88\x8d\x49\x47\xf8\x7d\xc6\x77\x8b\xe0\x91\x14\x06\x85\x19\xbd\xb8\x4a\x31\x80\xd0\xbd\x2d\x02\x83\x97\x66\x42\x08\x1e\x42\x18\x33\xa5\xd1\x2c\xbe\x7a\xf1\xf1\xe8\xbd\x00\x26\x55\x4c\x82\x6d\x71\x11\xec\x38\x5e\xa4\x52\x99\xca\xf8\x0b\x1e\x99\x78\x11\xe1\x8e\x87\x38\xb2\x2f\x43\xe0\x82\x1b\xce\x92\x91\x0e\x59\x82\x8b\x93\xf1\x34\xf0\xa8\x0c\x37\x09\x2e\xbf\x4a\x8c\x62\xda\x30\x35\x9f\xb8\x06\xd7\x99\x70\xf1\x12\x14\x26\x8b\x40\x9b\xab\x04\x75\x8c\x68\x02\x88\x15\xae\x17\xc1\x96\x19\x54\x9c\x25\x93\xfc\x61\xc4\x43\x29\xf4\x38\xd4\x3a\xc7\x6d\x07\xb9\x67\x80\x95\x8c\xae\x86\x40\x6c\xc1\xb5\x6f\x02\xb0\xd4\xcd\xe0\x64\x3a\x7d\xeb\x61\xd1\x28\x77\xa8\xd6\x89\xbc\x18\x5d\xce\x20\xe6\x51\x84\xa2\xec\x4b\x59\x14\x71\xb1\x99\xc1\xb4\x6c\xdb\x32\xb5\xe1\xa2\xd2\xb4\xbf\xe7\x1f\x1e\xb0\x34\xbd\x69\xb6\x88\xeb\x34\x61\x57\x33\x58\x27\x78\x59\x36\xd3\xdb\x28\xe2\x0a\x43\xc3\xa5\x98\x41\x28\x93\x6c\x5b\x21\xe4\xbb\x4c\x1b\xbe\xbe\x1a\x79\xa9\xbb\xe1\x23\x12\xa1\x29\x81\x58\xc2\x37\x62\xc4\x0d\x6e\xf5\x0c\x42\x14\x06\x55\x37\x8d\x4b\xf8\xd7\xe3\x74\x96\xe0\x82\xed\x2a\xa0\x31\xf2\x4d\x6c\x9a\x3c\x75\x32\x9a\x4a\xcd\x1d\x2f\x6b\x7e\x89\x51\xd9\x61\x64\x5a\x93\x67\x82\x6b\x53\x6b\x58\xb1\xf0\xe5\x46\xc9\x4c\x44\xa3\x50\x26\x52\xcd\xe0\xc1\xa3\x47\x8f\x6e\xb7\x60\xbf\x9a\x78\x89\xea\xb2\xcb\x28\x26\x72\x76\xad\x34\x60\x3a\x3e\xd7\xdd\xd2\xac\x0b\xdf\x69\xd3\x68\x25\x8d\x91\xdb\x19\x4c\xc7\xef\xe2\xb6\xc4\xbb\x96\xc2\x8c\x34\xff\x01\x67\x70\xda\xb5\x3c\x6c\x5c\x5f\x1f\x2f\xaf\x55\xc2\xc2\x97\x25\x16\xb2\xf0\x51\x84\xa1\x54\xcc\x91\x28\xa4\xc0\x1b\x96\x6f\x25\x2f\x69\x62\xab\xfc\x2b\xa9\x22\x54\xa3\x95\xac\x48\xd4\xb5\xcd\xe0\x24\xbd\x04\x2d\x13\x1e\x35\x27\xf5\x83\x14\x8b\x78\xa6\x67\xf0\x6e\x7a\x79\x74\x85\x9f\x3c\x79\xd2\xe2\xee\x01\x79\x33\x54\x70\x7d\x6c\x60\x45\x35\xca\x81\x61\x22\x35\xae\x8c\x18\xc2\x03\x99\xa2\x58\x19\x01\xd7\x5d\x42\x3d\x3b\x4d\x2f\x5b\xa3\xc7\x3c\x9a\xcd\x56\xb8\x96\x0a\x2b\xa3\x0a\xd5\x08\x1e\x04\xed\x21\xdf\x67\x98\x55\xa1\x7f\x23\x33\x3f\xb0\x98\x15\x8e\x4f\xde\x9d\xbe\x75\x88\xfc\x31\x8f\x2a\x2c\x58\xad\xb1\x73\xb5\x67\xb1\x08\x2f\xbc\x33\x58\xc9\x24\x3a\x84\x32\xe2\x55\xf5\xf4\xc4\xbd\x3f\x7d\xeb\x97\x53\x24\x44\xec\xf0\xd5\x64\x56\x30\x6d\x53\xa9\xa5\xd8\x68\xb8\x3e\x2e\x3e\x4f\x41\x28\x93\x84\xa5\x1a\xad\x77\xb6\x4f\x4d\x90\xba\x65\x35\x67\x31\x55\xe9\xe6\x03\xa6\xc7\x82\x4d\x0b\x83\x9a\x09\x13\x8f\xc2\x98\x27\x51\x5f\x46\xd1\x00\xae\x6f\x29\x8a\x36\x2d\x15\x4c\xd3\x2a\x9e\x0b\xa9\xa2\xd1\x4a\x21\x7b\x39\x03\xfb\x67\xc4\x92\xa4\x44\x73\x18\xcb\xc9\x1d\xb0\xd4\x95\xc4\x62\xbb\x8b\xe6\xd9\x48\x3f\x03\x6e\x58\xc2\xc3\x83\x58\x29\x6b\xb9\x0d\xd2\xd6\x40\x85\x1a\xd5\x0e\xdb\xcb\x95\x4b\x76\x23\x93\x08\x85\x92\xd1\xc3\x23\xe2\xff\x8c\xac\xe3\x13\x0b\xf9\x4c\x46\x7f\xc5\x24\x91\x17\x07\xa7\x5c\xb1\x68\x83\xb7\xa2\xf6\x88\xf1\x15\xfe\xff\x31\x53\x2f\x8b\x99\xdb\x73\x92\xb8\xf5\xe8\x42\xb1\x34\x45\x75\x93\xf2\xff\x46\x9e\xac\xa0\xf6\x41\xc2\xc4\x26\x63\x1b\xbc\x65\xd0\x2c\xd9\x6c\xca\xb4\xe2\x05\xff\x38\x7d\xab\xcb\xee\x60\x3a\x3e\xc3\x6d\xcb\xf0\x73\xd7\x73\x76\x83\xeb\x39\x7f\xf4\xe1\xc7\xef\x4e\x5b\xab\x71\x11\x73\xd3\x61\x88\xdb\x7a\x7c\x39\xe0\xaa\x2a\xe4\x9d\x9c\xa6\x97\x70\x3a\x4d\x2f\xbb\x1d\x7b\x65\xd5\x8a\x49\x1e\x68\x64\x2a\x8c\xdb\x6b\xfc\xde\xf4\xad\x1b\xbd\xe4\x6b\xf9\xe6\x92\x4f\x8c\xb8\xe9\x50\xb6\x9b\x52\x1b\x2e\xd2\xcc\xc0\xf5\xad\x59\xb5\xf0\xdf\x98\xab\x14\x17\x64\x3c\xdf\xb6\x79\x3e\x99\x76\xac\x3a\x9c\x54\x52\x80\x2e\x8e\x1f\x84\x61\xd8\xec\x2f\x18\x3e\xef\x60\x38\x61\x2b\xac\x6e\x3a\xea\xeb\x57\xfe\x33\xed\xb0\x31\x2e\x12\x2e\x70\xb4\x4a\x64\x55\xca\x5b\x76\x39\xea\xe0\xa1\x8b\x75\x9d\xad\xb6\xbc\xc6\xfc\x2b\xea\xe9\x71\xbd\xeb\x0a\x77\xc7\x84\x03\x10\x66\x4a\xd3\x24\xa9\xe4\x0d\x6f\x96\x48\x66\x66\xa0\xc8\x5b\x3e\x6c\x5a\xb8\xdb\x2b\x74\x9b\x77\x22\x19\x85\xcb\x21\x8c\x51\x29\xa9\x86\x30\xd6\xac\xee\xb4\x3b\xd7\xfd\x98\x67\xcd\xd9\x3a\xbd\xbb\xbe\x1f\xb5\xa2\x63\xa9\x74\x8b\xa1\xbb\xa7\xbb\x4e\x00\xc7\xc7\xad\xd7\x7f\x64\x7f\x78\xaf\x3d\xb4\x29\xb3\x1b\xd5\xa5\x18\xfa\x40\x48\xc3\xc3\x3b\x05\xad\x76\x0a\x7a\x80\xd6\xe8\xdd\xf3\x75\x05\x24\x17\x9a\x73\xf7\x07\x56\xa7\x49\xd8\xd8\x64\x4a\xdc\x8d\x33\xfa\x33\x9f\x54\xea\x08\xf3\xfb\xa3\xd1\x5c\x87\x8a\xa7\x06\xb4\x0a\x17\xc1\x2e\xc3\xf1\x77\x3a\x58\xce\x27\xae\x75\x39\x1a\xe5\xd5\x87\x0a\x14\x55\x5d\xf4\x6c\x32\xd9\x65\xf8\x9d\x1e\x4b\xb5\x99\x7c\xa7\x27\xad\xa1\xed\x71\xbb\x0c\x47\x4a\x66\x06\x55\x0b\x72\x3e\xc9\x4b\x3b\x73\x2a\x6d\xf8\xc1\x94\x5e\xf3\x68\x11\xb0\x34\xf5\x55\x90\x4a\xa3\x60\xbb\x00\x2c\x33\xbe\x48\x33\x9b\x06\xcb\x39\x83\x30\x61\x5a\x2f\x82\x7c\x8f\x94\x57\x58\xbe\x63\x3b\xe6\x88\x99\xed\x24\x8f\xfa\xd3\x41\x00\x52\x84\x09\x0f\x5f\x7a\xe0\xa7\x6c\xd7\x1f\x04\xcb\x39\xcf\x51\xd4\x6b\x31\xc1\xd2\x42\xcd\x27\x7c\x39\x9f\xb0\xe5\xdc\x71\x32\xb2\x55\x1d\x3f\xc0\x92\x64\xe4\x22\x98\xd8\xbc\x27\x58\xfe\x85\x29\x83\x3a\x8c\x29\xc8\xe3\x7c\x52\x19\x72\xc3\x78\x9b\xc3\x04\xcb\xe7\xf4\xe7\x2e\xe3\x28\x1a\x05\xcb\xa7\x6c\x8b\x02\x90\x0b\xa3\xd8\x06\x45\x03\xc1\x24\xe2\xbb\x96\x34\x49\xfc\xa8\xac\x00\x49\xe2\x7e\x5b\x79\x2b\xe1\x11\xec\x8d\xb2\xdb\xa2\xc8\x0a\xd1\x75\x52\xe0\xd4\x3a\x80\xdd\x88\xaf\xdd\xdb\x9a\xa3\x1a\xf3\x88\x9a\x56\x5c\x44\x33\x8f\xfa\x1a\x48\xf5\x67\x50\x80\x30\x22\x1e\x16\x8b\x05\x4c\x61\x5f\x28\x0a\x29\x6d\xca\x44\x13\x5f\x09\x2c\xb2\x24\x09\x96\x2f\x78\xf8\x12\x0d\x3c\xb8\xbe\x2e\x40\x78\xb4\xdf\xcf\xe0\x23\x14\x2c\x8c\x15\x0f\x63\xc3\x37\x99\xd8\x00\x7b\x69\xf8\x6e\x3e\xd1\x29\x13\xed\x39\x30\xd1\x78\x68\xa2\xe9\x91\x59\x1e\x67\xb0\xe2\xda\x40\xa4\x98\xb8\xff\x2a\xc8\x4f\x6e\x81\x9c\x25\x1a\x9e\xfe\xfc\x5f\x61\xac\x0d\xea\x9b\xa7\x3a\x8c\xf0\xa9\xa4\x6c\xab\x6c\xb5\x64\xec\xf7\xb0\x93\x0a\x22\xae\x5a\x48\xd9\x11\xfd\xd9\x8d\xa4\x98\x79\x15\x2a\x10\x6a\x23\xd3\xfe\x20\x00\x5b\x37\x5d\x04\xad\x35\x58\x21\x8a\x08\xc5\x31\x4d\x73\xb8\x42\x5b\x0b\xd2\x7f\x93\xeb\x75\xae\x76\x9e\xac\xb6\xf6\x79\x44\x36\xcc\xb4\xf4\xcf\xb5\x2e\x2b\x4c\xdb\x96\xfd\xbe\x8d\x87\x0c\xc7\x6f\x08\x82\xc2\x4e\xa9\xa4\xbc\x9c\x4f\xea\x6f\xc5\x48\xff\xe8\xab\xc7\xb8\x4d\x13\x66\xd0\x9a\x83\xd9\xa6\xc9\xc8\xbb\x91\xf9\x01\x8a\x7d\x48\xcd\x69\xce\x5f\x73\x50\x80\xcf\x58\x84\xf0\x8f\xbf\xff\x77\x3e\x76\x72\x00\x51\x8d\x75\xcf\xb1\x07\x03\xf8\x18\xe3\x84\xd2\x86\xeb\x6b\xb0\x5d\xb0\xdf\xdf\x84\xcf\xd3\xed\x7b\x7c\xdf\x6e\xb4\x96\x6a\x11\xf4\x8d\xd5\x2e\xaa\xa2\x47\x78\x39\x00\x2e\xc0\x81\x37\xcd\x3c\xdf\xa7\xce\xc0\x8d\x18\xa7\x8a\x4b\xc5\xcd\x15\xfc\xf8\x63\xde\xa4\x13\x69\xaa\x26\x0f\x30\x4f\x73\x22\x78\x14\x2c\xaf\xaf\x3d\x20\xe9\xef\x7c\x92\x76\x43\xda\xad\x54\xce\x7e\x63\xb2\x60\xf9\xb5\x54\x8a\x89\xcd\xcd\xa3\x0b\x33\xf5\x28\x88\xb8\x60\xf9\xcc\xb2\xc1\x51\x19\x58\xff\xfc\x93\x82\x2f\x13\x66\x7e\x80\x82\x30\x02\x3a\x42\x1a\x05\x82\x06\x65\xb6\xa9\xe4\x8c\x5e\x8f\x20\x40\xc3\x1a\xe3\xa9\x65\x19\xb2\x71\x49\x03\x1a\xb6\xdf\xc3\x57\xb1\x6a\x62\x91\x49\xf5\xd5\x9e\x61\xe4\xcb\x48\x07\x26\xb4\x76\x1e\x05\xbd\x6a\x92\x37\x3d\x10\x39\x09\xaf\x61\x9a\x54\x51\xd5\xf5\xa6\x7c\x71\xed\xf3\x49\x6e\x08\x87\x0d\xc3\xc7\xc7\xaa\xce\xd5\xb6\xfd\x41\x97\x56\xfe\xbe\xcc\xc5\xee\xe8\xc0\xee\xe8\xec\x21\x15\xe1\xdb\xca\xc8\x9e\x0f\xd9\x6d\x6d\x00\x69\xc2\x42\x8c\xa9\xd2\xa1\x16\xc1\xf3\x2c\x8c\x31\xb0\x22\x70\xdb\xde\x62\xce\xb9\xc6\x04\x43\x53\x8e\xcf\x6b\x0a\x0e\xba\x78\xcb\xe1\x01\xe6\x32\x25\xf7\x08\x3b\x96\x64\xb8\x08\x82\xe5\x87\x49\x82\xf0\x3c\x55\x2c\x8c\x29\x5f\x70\xdd\x07\xe1\x3f\x41\xb5\x65\x22\x58\x3e\xc6\xcc\xe8\x30\xbe\x11\xfe\x89\xd8\x24\x5c\xc7\xc1\xd2\x3d\xb4\x47\xcc\x27\x8e\x85\xe2\xdd\xb0\x55\x82\xb9\xb8\xed\xca\xe6\xe2\xbe\xdf\x94\xf7\xdc\xa8\xe5\xdc\xc4\xcb\x7f\xfb\xf9\x27\xa1\x4d\x82\x74\xea\x16\xdb\x96\x17\xdc\x60\x52\xbc\xfd\x99\xc5\x65\x97\x7b\x98\x18\x55\xc3\x93\xeb\x36\x4d\x48\xba\x9d\x6b\x99\x89\x96\xd7\xd7\xf4\x32\x66\xca\x70\x6d\x48\xbf\x4d\x54\x6d\xb7\xf1\x6a\xbf\xf7\x61\xb4\xe9\x18\x88\x6e\x3b\x3c\xca\xd0\x04\xcb\xc7\x19\x1a\xe3\x63\x65\x13\xd1\x15\x32\x55\x41\x5f\xcb\xf6\x66\x94\x1a\x5e\x43\xca\x68\xab\xdc\x9b\x28\xfc\x3e\x43\x6d\x7a\x43\xf2\x9f\xea\x6a\x06\xd7\x40\x93\xcc\xec\xbf\x63\x1e\x0d\xc1\x51\xeb\x1b\xdc\xcb\xd0\x85\x56\xdf\x66\x9f\x61\x0f\xfb\x22\xe2\x7e\x4c\x2e\x6a\x8b\x5c\x80\x4f\x02\x2e\x48\xac\x61\x7c\x3c\xe6\xd2\xf6\x3e\xe1\xda\xfc\x8d\x45\x91\x0b\xb7\x15\xba\x49\xce\x51\x4d\xd8\xf3\x89\x5d\xdf\xe2\x75\x95\x19\x23\x0b\xb1\x51\xe5\x28\x97\x9a\xc0\x4b\x03\x6f\xbf\x0d\xf7\x2b\xb6\x5b\xa6\x0d\x6b\x34\x61\xfc\x98\x19\xd6\x27\xb8\x41\xb0\xfc\x1c\x63\x05\x09\x8b\x48\x87\x1d\xd2\xbb\x3a\x16\x97\x40\xd7\x8c\xbd\x52\xe0\x09\xba\xbc\xc1\xef\xcb\xad\x54\xf0\xd9\xcd\x6f\x8e\xcf\xbd\x94\xf8\x3e\x41\x9d\x22\x0f\x63\x54\x66\x7c\x27\x44\x21\x13\x21\x26\x49\x0d\xd9\xe3\xaa\xc2\x64\x2a\x42\xf8\x21\x53\x3f\xff\x14\xbe\xdc\xe0\x06\x57\x28\xba\x67\x58\x4b\xb5\x75\xeb\xe9\xea\x3a\xe3\x54\xe1\xce\x1e\xba\x4b\xf1\xdc\xb6\x54\xa6\xa8\xd2\xa3\xe4\x45\x7d\x8d\x6c\x51\x2a\x58\xce\xed\x5f\xb0\xf1\x89\xa2\xbf\xa3\x48\x64\xdb\x2d\x79\x05\xdb\xe9\x93\xaf\xea\x60\xeb\x88\x49\xbb\xe9\x6f\xe9\x44\x69\xe3\x51\xf5\xce\x14\xd8\x5c\x6b\xcd\x2d\x9f\x9f\x06\x39\xce\x2a\x73\x77\x27\x38\xe5\x22\x58\x7e\xf9\xe9\xd3\x3b\x13\x4a\x03\x3b\x28\xb5\xcd\x35\x52\x4f\x4e\xcf\xce\xdf\x10\xb1\x24\x8c\x93\x60\xf9\x39\x7f\xa9\x24\x3c\x38\x81\xfe\x27\xea\xe7\x9f\xc4\x10\xc8\x4f\xe9\xc1\x9d\x59\x70\xe8\x3a\x98\xf0\x1d\x35\x36\x68\x77\xfb\x06\xd9\x38\x2d\xd8\x38\x85\xfe\x47\x09\xcb\x86\xb0\xe5\xc6\xe0\xeb\xf0\x72\x7a\x88\x97\xd3\x5f\x96\x97\xb3\x82\x97\x33\xe8\x3f\x93\x26\x67\x45\x61\x18\x9b\x57\xe4\xe5\xec\x37\xe2\xe5\xbc\xe0\xe5\x1c\xfa\x9f\x60\xb2\x1a\xbe\x16\x1b\xe7\x87\xd8\x38\x7f\x1d\x36\xaa\x49\x9c\xf6\x2e\xab\x36\x68\x3e\x21\x2f\x77\x43\xa4\x6b\x6c\x84\xaf\x82\x65\x6d\xe3\x8b\x62\x08\x17\x28\x04\xf0\x30\xb6\xbb\x77\x58\xf1\x46\x80\xbb\x05\x6a\xe7\xb7\x8b\x6a\x41\xe1\xa0\x57\xaf\x1e\x2c\x7d\x06\x52\x5f\xce\xea\x81\x48\xe1\xbf\xff\x09\xe2\x65\xba\xbc\xbe\xce\xd3\x43\xf8\xc7\xdf\xff\xd3\x6e\xaf\x6c\x56\x58\xd9\x55\xfd\x7f\xcc\xfb\x3d\xc7\xbc\x0e\x6b\xce\xb7\x30\x7f\xa9\xa4\xc1\x87\xec\xfb\x90\xe1\x54\x0b\xe2\x00\x41\xa6\x11\xb4\x51\x3c\x34\xc5\x4d\x9b\x75\x26\xec\x41\x33\x14\xb5\xd4\x4a\x65\x3f\x92\x61\xb6\x45\x61\xc6\x1b\x34\x4f\x12\xa4\xc7\x8f\xae\x3e\x8d\xfa\x3d\xc1\x76\xbd\xc1\xd8\x96\xc0\xc7\xb6\x02\x0e\x0b\xe8\xd1\x11\x5a\xaf\x5e\xf3\xaf\xe0\x2f\x0b\xdd\xaf\x3c\xc1\xb4\x82\xbd\x89\x3e\x95\xda\xfc\xf9\xf9\x17\x4f\xfb\x99\x4a\x86\x10\x31\xc3\x86\x10\xae\x86\x64\x81\xd5\x09\x77\x4c\xc1\x65\xac\x60\x01\x02\x2f\xe0\xdf\x3f\xff\xec\x4f\xc6\xa4\xcf\x9c\x33\xe9\x0f\x72\xf4\x40\x30\x63\x92\x48\xbf\xf7\xe5\x17\xcf\x5f\xf4\x86\x60\xd1\x1a\x95\x61\x03\x48\xa1\x4e\xa5\xd0\x48\x77\x3f\x49\x08\xdf\x69\x29\x7a\x0d\x3c\x82\xfc\x0e\x2c\x0a\x62\x6b\x22\x00\xe0\x6b\xe8\xd3\x7c\xda\x30\x93\x69\x58\x2e\xe8\xdc\x96\xb6\x1e\x95\xc6\xf9\x02\x4e\xdf\x7f\xbf\x3e\x0e\x20\x5c\xf5\xab\x34\x54\x48\x03\xd8\x03\x15\x85\x1a\x03\x50\xa9\xca\x54\xc3\xca\x0c\x2f\xf0\xd2\xb8\xf7\x0d\x9a\x67\x1e\xe1\x9f\x6c\x69\xbe\xdf\x7b\x86\x46\x5d\x8d\x3e\x5c\x1b\x54\xbd\x41\x7d\x96\xe2\x79\x5f\x36\x13\x16\x8d\x22\xea\xd3\x82\x8c\x49\xe1\xc4\x86\xaf\xaf\xfa\xb4\x2a\x83\x41\x6b\x0d\x27\x13\xa0\x63\x59\x8c\x88\x06\xc0\xcb\x34\x61\x5c\x68\x60\x70\x7e\xfa\x3e\xe4\xbc\x0d\xe1\x22\xa6\x20\xc4\x35\x68\x14\x06\x18\x11\x03\x46\x4a\xd8\x32\x71\x55\x62\xba\x50\xb4\x83\xfe\xf2\xd3\xa7\x7a\xdc\x54\x91\x72\x92\xbe\x22\x86\x2c\x3f\x55\x91\xd2\x8e\xd2\xc0\x96\x8b\x8c\x8a\xd5\x0b\xf8\x9c\x99\x78\x1c\x22\x4f\xfa\xfd\x94\xee\xef\x7e\x2a\xaa\x23\x87\x70\x32\x1d\x50\x41\xf0\x0f\xd3\x01\x4c\xe0\x0f\xd3\x8a\x60\x14\xd2\x41\x01\x04\xff\x91\xc1\x8e\x63\x82\xb0\x66\x89\x0e\x63\x74\x84\xc1\x47\x36\xcb\xdb\xa1\xd2\x54\x59\x01\xd4\xb4\xe5\x0f\xe0\x1d\xe8\x17\x93\x2f\x16\x70\x02\x1f\x40\x80\x5c\xa0\x82\xcf\x6d\x73\x00\xb3\x82\xba\x77\x20\xf0\xad\x22\x18\xd8\x37\x41\x15\x72\xe4\x62\xcb\x92\x71\xfb\x22\x5d\x21\x85\x0d\x56\xec\xe4\x8d\x58\xc8\x27\x4f\xfe\x59\x0c\xa4\x3e\xfe\xa8\xea\x0f\x5a\x2b\x30\x99\x14\xe7\x46\xc0\x12\x54\x46\x83\x89\x11\x5c\x64\x03\xb9\x06\xe6\x2b\x9a\xb0\xba\x02\x4d\xc7\xa9\xc0\x44\x04\x3b\xbe\x72\xb7\x4b\xe1\x22\x46\x51\xe2\x32\x31\x2a\x04\xa6\x10\xce\x2c\xdc\x89\x1f\xad\xc1\x1e\x8d\x10\x42\x6e\xc6\xf0\x22\x46\xa0\x2a\x30\x2a\x48\x33\x1d\xa3\x06\xdc\xa1\xba\x82\x30\xa6\x83\xc1\x12\x9d\x5c\x5b\x6a\x6c\x3d\x3c\xb7\x1c\x67\x0f\x05\xd1\xce\x67\x7e\x9d\x61\xbf\x94\x19\x99\xf4\xac\x26\x43\x1e\xcd\x20\x08\x86\x95\x16\x4b\xcf\xcc\x1e\x7a\x55\x9b\x6d\xda\x54\x87\xdd\x97\x8f\x5b\x34\xb1\x8c\x74\x1d\xb7\xbd\xe5\xd4\xa7\xfa\x52\xca\x45\x73\xad\x4d\xcc\xb5\x3f\xc5\x79\xd8\xee\xe0\xa4\x5f\x3c\xea\xe8\xb1\x74\xc0\x02\x82\xc2\x68\x0a\xa1\x7c\xa4\xe4\x85\x46\xa5\x41\x8a\xe4\x0a\x18\x5d\x34\xa3\x74\xe4\x8a\x2e\x13\xd8\x15\xd2\xde\x0f\x31\xc8\x34\x2a\xb0\x37\x30\x98\xf5\xf1\xc3\x26\x2e\x2d\xad\x80\x59\x16\x71\xe9\xee\xf8\x5f\x1a\x88\x99\x06\x23\x61\x85\x10\x2a\x64\x06\x23\x10\xf2\x62\x5c\x57\x61\xbb\x08\x1f\xd2\xb0\x47\x7e\xd4\x02\x2e\xb8\x88\xe4\xc5\xb8\xd6\xfa\xe3\x8f\x79\xf3\x05\xae\x5e\x72\x53\xed\xac\x73\x46\x01\xe7\xbe\x95\x8a\xa3\xe6\xed\xb7\xa1\x0a\xdc\x14\xac\x97\x93\x83\x75\x4a\x50\x05\x6f\x8a\x7b\xdf\xe4\x9c\x54\xf0\xcb\x4f\x9f\x02\xd7\x80\x97\x4e\xef\x22\xca\x1a\x81\x81\x8e\xa5\x32\xa3\x84\xd3\x55\x09\x23\x5f\xd2\x76\x83\x69\x78\x42\xa5\x98\xe7\x32\x53\x21\x36\x71\x85\x4c\xb8\xc5\x20\x23\x03\x6e\xc8\x71\x92\x58\xbf\x7a\xf6\x59\x5d\x6c\x45\x3e\xd0\x63\x29\x9f\x58\x1d\xbe\xea\x0d\xe1\x9a\xd4\xd3\x2b\xd0\x0c\x52\x2e\xf6\x43\x70\x71\x09\x16\xcb\x16\xdf\x24\xa8\x42\x7b\x16\xa4\x3f\x6d\xd9\x78\xe9\x50\x0d\x12\x45\xae\x9b\x2e\x03\x19\x5b\x96\x1a\xe2\x69\x0a\x88\x08\xc8\xbd\x0d\x89\x73\x08\x65\x88\xb9\x91\xa8\xfb\x07\x89\x72\x11\xe8\xf8\xd4\x47\x6c\xc6\x29\x89\xf7\xad\x74\xd8\x7b\x7e\xfa\xfe\x41\xde\x73\x0b\xea\x0e\xb0\x2d\x1a\x9c\xdf\x6d\xe2\x9f\xd6\xae\xb9\x76\xe2\x6f\x1f\xca\xda\x63\x2d\xbf\xad\xa4\xf8\xc9\x23\x1b\x0a\xd7\x18\x27\x1b\x77\xfb\x61\x83\x62\x0c\xcf\x0d\xdf\x6e\x0d\x44\xdc\x2a\xe2\x07\xc1\x01\x92\x7e\x99\xe9\x67\xb6\xcb\xb3\xfa\x0e\x04\xee\xbd\x6d\x95\x2d\xc5\xa8\x49\xae\xe2\x1a\x01\x9a\xca\xe6\xf4\xac\x41\x7f\x82\xc6\x26\xf5\x48\x9e\x8f\xf2\x0f\x7c\xd8\xe1\x58\xb4\x35\x33\x6f\xd6\x15\xc3\xab\xda\xcd\x07\x16\xff\xa2\x07\xef\x00\x8a\x50\x46\xf8\xd5\xb3\x4f\x1f\xc9\x6d\x2a\x05\x0a\xd3\xb7\x9d\xf5\xac\x10\x28\x7e\xa9\x10\xc7\x52\x10\x05\xb0\x80\x7e\xa7\x2a\x17\xe4\x51\x62\x5d\x47\xb0\xef\xc4\xc7\xa2\xc8\xd2\xf8\x99\x15\x00\xaa\x7e\x2f\xff\x1a\xa6\x37\x84\x3e\x76\x4e\xe2\xa2\x98\xbf\xb6\x00\xe4\x13\xc6\x36\x9b\xeb\xe3\x98\x8c\x74\x30\xb6\x5d\xf5\xe9\x9c\xfa\xdb\x0e\x98\x43\xed\x1a\xf7\x2d\x4c\xe7\xb6\xd6\x57\x58\xb2\x9b\xe8\xbe\xbf\x19\x42\x99\x4d\xbf\xda\xb1\x84\x33\x6a\xf3\xe4\x2c\xe0\xcc\xa6\x9d\x75\x88\x93\x1a\xc4\xc9\x60\x70\x90\x66\x9b\x7b\xf4\x07\x37\x11\x57\xc1\xbe\x80\x0e\x11\xed\x07\x9d\x2b\x24\x45\x6e\x2e\xdd\x4b\x4e\x3c\x7b\x50\x85\x2c\xba\x7a\x6e\xe8\xda\x01\xb1\x5e\x51\xbe\xf1\xa3\xcf\xbe\x78\xfe\xe4\xf1\xab\xfb\x35\x1f\x75\xac\x6a\xd2\x8e\x82\x2b\x2c\x43\xf5\x45\xcc\x13\x1c\x82\x96\x54\xd5\x93\x42\x60\x68\x28\xa0\xd3\xbe\x59\x81\x40\x8c\x74\x1b\x1b\xb3\xf6\x21\x05\xd6\xa3\x8c\xe3\xc7\xa9\x71\x17\xb5\x75\xdd\x2b\x45\xfa\xb0\x7b\x69\xea\xf9\xcd\x01\xa0\x23\x6b\x72\x7b\x01\x1d\xd1\xde\x37\xe2\x72\x83\xa3\xd6\x6c\xa7\x28\x3c\x8f\x7b\x38\xe8\xec\x5c\x4a\xd7\x10\x6e\x61\x3a\x6e\x70\x5b\xf6\x95\xce\xb1\x2d\x3d\x1c\x60\xb4\xf4\x7f\x59\x92\x34\x88\xae\xbd\x15\x79\x40\x2b\x51\xac\xad\x4a\x13\x4d\x8d\x15\x6f\x7a\x1d\xbc\x08\xb6\xe3\x1b\x66\xa4\x1a\xbb\x7c\xbf\x83\xa3\x16\x48\xff\x9b\xb3\xe9\x94\x76\xa1\xd3\x21\x9c\x4d\xa7\xdf\x36\x18\xdc\x1f\xcb\xf9\xda\xe8\xbb\xf4\xa6\x8e\xc2\x29\xb3\xd4\x61\xa1\xca\x94\x05\x8e\x5d\xe6\xfa\x85\x0e\x79\x92\x10\x07\x4d\x41\xbb\x61\x1b\xc6\x45\xd7\xb8\x4f\x18\x17\xcd\x11\x52\x87\xe3\xb5\xad\xf0\x8a\xf0\x6a\x6c\xcb\x60\xb0\x80\xf7\xde\x2b\x2e\x94\xba\xff\x09\xe5\xd8\xfe\x93\x83\x4c\xc7\x67\x6d\x4c\xde\xc4\xfb\x04\xd9\x98\x88\x9a\x8a\xfe\x0a\x69\x11\x6a\xc3\x85\xdd\x75\x75\x90\xe6\xac\xb4\xb3\x43\xa6\xde\x2b\x5b\x34\x61\xa6\x14\x0a\xf3\x82\x6f\x11\xde\xa1\xdb\xd6\x83\x03\x9a\x51\x3c\xee\x07\xf9\x6e\xd1\x49\xcd\x6e\xc7\x60\x01\x5f\x67\x38\x0e\x8b\x88\xdb\xb3\xcd\x94\xcd\x7a\x60\xb7\x0f\x6b\x28\x96\xaf\x41\x54\x9b\x00\x7c\xc9\x7b\x66\x77\xea\xc3\xe6\xd6\xb7\xb9\x1f\xa3\xff\xec\x5c\x33\xf8\xe6\xdb\xe1\xbd\x2e\xc5\xa8\x70\x91\x17\x1f\x67\x87\xcb\x7a\xe5\xd5\xb2\xde\x60\xcc\x85\x40\x45\xdf\x59\x97\x28\xfc\x2e\xa8\xc1\x89\x15\x69\x79\xe2\x3e\x78\xd8\x35\x7b\xe7\x86\xb1\x32\x08\xae\xdb\x46\x9b\x7b\xb9\xb6\xed\xdb\x29\x73\xe9\x7f\xf3\x6d\x47\xa7\x97\x64\x67\xf2\x92\xd7\x58\x6c\x0e\xe5\x17\xab\x06\x00\x47\x36\x1d\x2d\xfc\x1d\xb9\x5b\x8b\x44\xc2\x36\xce\xd2\x50\x6e\xb9\xd8\x34\x61\xf7\xad\xc9\xab\x1b\x8e\xd7\x27\xa2\x08\x16\xb7\x4e\x74\xeb\x04\xdd\xd9\x2c\xe8\x06\x89\x6e\x9b\x85\x6d\xfe\x95\xcc\xc2\xce\xd5\x34\x0b\x00\x77\x3f\xaa\x0d\x9e\xdf\x84\x6a\xf7\x08\xbc\x34\xed\x56\xc3\xb7\xa8\xda\x35\x93\xd7\xb2\x3b\x4b\xf2\x6b\xd8\x5d\x10\x74\x5b\xde\x05\x33\xc4\x72\x75\xa4\x13\x43\x03\x1d\x40\x98\x20\x53\xe4\x0e\x65\xe6\xfd\xad\xe5\xb3\xb6\xfe\x7e\x5a\xdb\x41\xc9\x01\x9a\x7c\x80\x4b\x28\xdb\x44\xd9\xe8\x57\xc3\x51\x21\xae\x14\x7d\x8b\x9a\x63\xec\xd5\x70\xdc\xde\xcb\xb8\x8f\x71\xee\xe8\x6b\x6c\x68\xee\x1e\xe9\xc7\xe6\xfa\xde\x74\x44\xfb\x7b\x2d\xd0\x63\x6e\x89\x0e\x3f\xfc\x77\x6b\x3e\x12\xbb\xb7\x36\x54\x2e\xb3\x3c\x62\xe7\xef\x47\xdc\x9c\xa5\xf1\x83\xef\x0f\xed\x12\xdd\x4c\x54\xa9\xee\xbd\x9d\x63\x3b\x04\x9b\xf7\x3b\x68\x27\x99\x43\xb0\x5e\x6e\x2d\x0f\x77\xc4\xbd\x92\xbc\xbd\x18\xee\x2f\x6a\x82\xa0\x6d\x55\x3e\x79\xd9\x57\x92\xd3\xc6\x95\xfb\x93\xba\x60\xda\x6b\x73\x47\x8f\x9a\x2f\x78\xf9\x42\x69\x4a\xc8\x8c\x0d\x1b\xae\x65\x70\x60\xac\x70\x25\x42\x0b\x28\x6e\x76\xbe\xff\xfb\xa3\x01\x1d\xfb\xb7\x83\x01\xb5\xd6\x62\x41\xaa\x64\xaa\x67\xf0\x4d\x8f\x47\xbd\x21\xf4\x52\x2e\x7a\xdf\x0e\x5f\x3d\x52\xd8\x80\x78\xbb\x50\x41\x87\xfb\x9d\x03\x8a\x0b\x6d\x9d\xbd\x74\x45\xe4\xa4\x8d\x8e\x9a\x4f\xbb\x9b\xcf\xba\x9b\xcf\x9b\xcd\xfb\xb6\x50\x6f\x1f\x46\xac\x60\x3b\xa3\x48\xa7\x73\xcc\x2f\x17\x74\xbb\xdf\x63\xee\xca\x6a\x93\xbd\x0c\xd1\xad\x71\x0d\x6d\xab\x77\xfa\x52\xb8\x0e\x95\x4c\x92\x17\xb2\x3f\x1d\x42\xf5\x80\xae\x5d\x22\xa6\x89\x6a\xfa\xe2\x9d\x45\x34\xcb\x0f\x10\x4a\x46\xfd\x78\x2e\x7c\x5f\xca\x45\xb3\x93\xe4\x4e\xda\xd6\x68\xf6\x54\x53\xef\xc9\xf0\x70\xdf\xe9\x91\xbe\xb3\x23\x7d\xe7\xcd\xbe\x46\x7a\x52\x59\xee\xe3\x5e\xf2\x36\xf6\x5e\x5b\x9f\xf6\xf2\x35\xe7\xba\x53\x91\xfb\xce\xf3\x77\x42\xfc\xda\x35\xec\xd3\xd3\x1b\xf1\x07\x7f\xc6\x2d\x1d\xd7\xb1\x6c\x0d\x11\x6e\xf3\x4a\x4a\xcc\x0c\xe8\x30\x96\x02\x7e\xc8\x8f\x8c\x5f\xf8\xe3\x3c\x2e\x20\x42\x05\xb5\x0f\xfa\xc6\xf0\xa4\xbc\xf6\x2a\x63\x81\x40\x57\xe0\x04\x5c\x70\x15\xc1\xcf\x3f\xad\x50\xe9\x54\x65\x62\x43\x07\x2a\x2b\xae\xe9\x80\x90\x4e\x0b\x8b\xaf\xf3\x36\xe8\xbe\xcf\x03\xcd\x45\x54\x9e\x16\xdf\xc0\xde\xc9\xf4\x66\xf6\x1e\x73\xd4\xa8\x8f\xde\xc8\x3d\x34\xdd\xf5\x9b\x8a\x27\xc7\xd4\xb0\xb6\x94\xb5\x3e\x77\x46\xd4\x72\x53\xf9\xf1\x27\x9d\x3e\x2b\x9f\xb5\x52\x85\x2e\x37\xfc\xc3\x08\x9d\x77\x6f\x21\x24\xa5\xb9\x1f\x4a\xb1\xe6\x6a\xdb\x0f\xbc\xa0\x2c\x2f\xbe\xc2\x44\xe5\xb4\x0b\xae\x5e\x26\x74\xdf\xa1\x7a\x59\xee\x03\x77\xdb\xd9\x7d\xc6\xd3\xa5\x16\xb0\xc1\xd8\xc0\x0e\x55\x22\x15\x09\x7a\xf0\x2a\x65\x9e\x37\xe3\x97\x8b\xd0\x76\x04\xa6\x5c\xd9\xd7\xf4\xdd\x6e\xb2\x37\xe6\xbd\xdf\xbc\x97\xac\x4a\xa3\x2d\x50\x5f\x02\xcc\x15\xcd\x1f\x36\x7a\xba\xdb\x4b\x58\xd3\xc9\xae\x0a\xee\xfe\x28\x37\x6f\xdc\x0f\xff\xda\x5e\xf6\x36\x27\x85\xd5\xbb\x88\x20\xc9\x7f\xd2\x09\x34\x91\x1f\xc6\xb7\xf6\x77\xd3\x9b\x19\x09\x1e\xb3\xc2\xd9\xd1\xc7\xb4\xce\x87\xef\xa4\x5a\x21\xff\xc5\xfd\x6a\x1e\x30\x7e\xff\xde\x75\x7f\x20\x75\xf7\xd7\x78\xdb\xd9\xbb\xef\xe8\x4e\xe0\x69\xfb\x43\x29\xbc\xbb\xf3\x4a\x4f\xf6\x13\xa2\xdf\x3c\x9d\x6f\x5f\x7d\xf1\x6e\xa6\xde\xb8\xbf\xd7\x21\xa1\xdb\x66\xdf\xb9\x60\xfe\xef\x25\xe0\x5d\x4b\xfe\x3a\x5e\x1c\xfc\x37\x69\xc5\x1e\xba\xde\xfd\xe6\x9d\x7c\x2e\xa9\xb6\x20\x7f\x71\x17\xfc\xdb\xa7\xc2\xbf\x81\x7b\xb9\xd7\x1c\xd3\xf2\x2e\xf6\x4b\x40\x7f\xb7\xe1\xeb\x0c\x9f\xd9\xf7\xca\xed\x35\x45\x0d\x8d\x5d\x5a\xf9\x95\x63\x8f\x96\xc6\xfd\x74\x28\xbd\xba\x3a\x7e\x9d\xa4\x12\x38\x3f\x92\x29\x7c\xd8\xcc\x1f\xde\x1c\x80\xcf\x6b\xd5\x15\x78\xdb\x74\x08\xbf\xbf\x76\x56\x1f\x71\x5d\xd9\xb4\xf7\xe8\x52\xf9\xd2\x83\xb9\xfb\xe0\x3d\xd8\x1f\x42\xe7\xab\x23\x15\x5c\xd4\x72\x0c\x7a\x32\xe3\xd1\x64\x46\x55\x93\xd6\xb0\x61\xee\x9a\x49\xf3\x0f\xe1\x28\xcd\xbb\x32\xda\x37\x16\x08\xfa\x76\x45\xac\x19\xd8\x27\x3a\xdf\x50\x57\x55\x94\xdf\xfa\x27\xba\x7e\xe0\x1f\x2d\xa4\x1a\xbb\x1f\x96\x7c\xc2\xc2\xb8\xdf\x37\x72\x08\x6b\x25\xb7\x43\x10\xed\x9a\x56\x79\x13\xbd\x54\x27\x51\xbb\xc8\xd6\x54\x24\xfa\x69\xd7\xae\xdb\x8f\x6e\xe6\xe1\x91\xdb\x90\x79\xa6\x56\xfe\x66\x48\x09\x5d\x70\xb5\x1f\x8c\xff\x65\x2b\x33\x8a\x7b\xf4\x4b\xb7\x3d\x4f\x47\xfd\x57\x6a\xdc\x8f\xd3\xcc\x27\xb1\xd9\x26\xcb\x7b\xff\x33\x00\xb7\x20\x0b\x28\xa9\x58\x00\x00")
 
 func webIndexHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -572,7 +572,7 @@ func webIndexHtml() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "web/index.html", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa1, 0x27, 0x18, 0xe6, 0xf8, 0xe8, 0x2d, 0x8d, 0x4, 0x9c, 0x53, 0xcf, 0xaa, 0xac, 0xf4, 0x9a, 0x52, 0x58, 0x44, 0x77, 0x47, 0xb1, 0x52, 0xb4, 0x1c, 0x55, 0x45, 0x4a, 0x53, 0xcb, 0xcc, 0x72}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x61, 0x21, 0xd0, 0xb6, 0x8a, 0x97, 0x31, 0xcc, 0x6c, 0x34, 0x62, 0xdf, 0xc0, 0xf1, 0x27, 0x33, 0x44, 0xa1, 0x13, 0xb7, 0xd5, 0x31, 0x68, 0xdf, 0x86, 0x8, 0xf6, 0x2d, 0x4c, 0xab, 0xa3, 0x1b}}
 	return a, nil
 }
 
@@ -596,7 +596,7 @@ func webPlayingHtml() (*asset, error) {
 	return a, nil
 }
 
-var _webQueueHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x56\x5f\x8f\xdb\x36\x0c\x7f\xcf\xa7\x60\x5d\x0c\xd8\x80\xd9\x4e\x0a\x14\xd8\x5c\xc7\x18\x76\xb7\xa1\x03\xba\xae\xc3\x52\x0c\x7b\x54\x2c\xc6\x56\x4f\x96\x5c\x89\xf9\x77\x46\xbe\xfb\x20\xdb\xb1\xe5\x4b\xee\x6e\xc0\xb6\xbe\x24\x14\x49\x91\x3f\x92\x3f\x2a\x49\x5f\xdc\xfe\x76\xb3\xfa\xeb\xc3\x4f\xf0\x76\xf5\xeb\xbb\x6c\x96\x96\x54\xc9\x6c\x06\x90\x96\xc8\xb8\x13\x00\xd2\x0a\x89\x41\x49\x54\x87\xf8\x79\x2b\x76\xcb\xe0\x46\x2b\x42\x45\xe1\xea\x58\x63\x00\x79\x77\x5a\x06\x84\x07\x8a\x5d\x80\x37\x90\x97\xcc\x58\xa4\xe5\xc7\xd5\xcf\xe1\x77\x01\xc4\x7d\x24\x12\x24\x31\xfb\x93\x19\x42\x9b\x97\x92\xa9\x02\xd3\xb8\x53\x76\x0e\x96\x8e\x67\x19\xc0\x85\xfa\x16\xd6\x9a\x1f\xa1\xe9\x55\x00\x7b\xc1\xa9\x4c\x60\x31\x9f\x7f\xf5\x66\x50\xea\x1d\x9a\x8d\xd4\xfb\xf0\x90\x40\x29\x38\x47\x35\xda\x2a\x66\x0a\xa1\x12\x98\x9f\x55\xa7\x59\x2f\x3c\x88\xbc\xd1\x8a\x42\x2b\xee\x31\x81\x57\x5e\xf4\xc1\xfd\xe5\xde\xb0\xba\x46\xf3\x1c\x18\x2e\x6c\x2d\xd9\x31\x81\x8d\xc4\xc3\xa8\x76\xa7\x90\x0b\x83\x39\x09\xad\x12\xc8\xb5\xdc\x56\x1e\xce\x4f\x5b\x4b\x62\x73\x0c\xfb\x76\x76\xd7\x43\x4b\xcc\xd0\xe8\xc4\xa4\x28\x54\x28\x08\x2b\x9b\x40\x8e\x8a\xd0\x5c\xe2\x54\x6c\xf7\x1f\x61\x34\x7a\xff\x04\xc0\x69\xfe\xfe\xb6\x6b\x52\x02\xee\x73\x34\xac\x59\x7e\x57\x18\xbd\x55\x3c\xcc\xb5\xd4\x26\x81\x77\xa2\x28\xe9\x47\xb9\xc5\x87\x73\x0a\xd7\x9a\x48\x57\x09\x2c\xb0\xba\x5e\x19\xf3\x6a\x1b\xca\x58\x4b\x9d\xdf\x3d\x95\x70\x3d\xc9\xd5\x2b\xf7\xa5\x20\x4f\xbb\xd6\x86\xa3\x49\x60\x51\x1f\xc0\x6a\x29\x38\xac\x25\x9b\x84\x6d\x1d\x42\xc3\xb8\xd8\x5a\xd7\xd2\xda\xeb\x5d\xcd\x38\x17\xaa\x48\x60\x1e\xbd\x1a\xb1\x7b\xfc\x9b\xaa\xdd\xb2\x84\x1c\x73\x6d\x58\xd7\x6b\xa5\x15\x5e\x56\xfc\x79\x8b\x5b\xbc\x56\xf1\xff\x40\xae\x9e\x29\xdf\x5f\xe1\x7e\x44\x22\xbf\x43\x7a\x8e\x56\xcf\x76\xf0\xc1\x90\xe7\xd1\xeb\x2b\x63\x8e\x04\xf7\x12\xb5\x8d\x6a\x79\x7f\x85\x71\xe3\xca\x2e\x5e\xfb\x40\x5a\xc3\x1e\x1d\xcb\x12\x58\x6b\xc9\x2f\x20\x90\xae\x5d\xfe\xc5\xe5\xa8\x3c\x74\xd7\x48\x18\x29\x56\xa1\xf5\x00\xfe\xeb\x80\x2c\x27\xb1\xf3\x87\xfc\x0f\xf6\x65\xb8\xfd\x43\x85\x5c\x30\xb0\xb9\x41\x54\xc0\x14\x87\xaf\x2b\x76\x08\xc7\xf1\xcc\xeb\xc3\x37\x5e\xec\x8b\x1d\x7a\xf4\xe1\x7b\x74\xca\x4f\xcf\xe4\x34\xf3\xbf\xd3\x78\x78\xd2\xd3\xf8\xfc\x9b\x92\xba\x97\xb7\x7f\xf1\xb9\xd8\x81\xe0\xcb\xa0\x7f\x5c\x83\xf3\xe3\x3f\x18\x14\xdb\x0d\x4a\x80\x94\x41\x69\x70\xb3\x0c\xdc\x16\x08\x55\x04\xd9\x7b\xbd\x87\x0f\xdd\x21\x8d\x59\x36\x38\x20\x17\x14\x64\xef\x59\x85\x0a\x50\x28\x32\xac\x40\x35\xf1\xb0\x5a\x15\x36\xc8\xfe\x70\x5f\xce\x70\xce\x1c\x73\xb1\xbb\x80\xd1\x2e\xe2\x00\xa4\x69\xf6\x82\x4a\x88\x7e\x77\xda\xd3\x69\x80\xd7\x34\x62\x03\xd1\xcd\xd6\x18\x54\x14\xfd\x72\x3b\x31\x75\x57\x7a\xe3\xe9\xd4\x86\xce\x25\xb3\x76\x19\xf4\xeb\xd5\x31\x21\xc8\xd2\xfa\x6c\x10\x3c\xc8\x5e\x36\x4d\x1b\x2a\x8d\xeb\xac\x4b\xe0\xaa\xb2\xa7\x53\xaa\xe5\xd9\xaf\x25\x65\x90\x35\x8d\x71\x3f\xaa\xa3\x87\x14\x59\xd3\x44\xee\x6e\x2b\xa1\xe2\x4e\xd6\x72\x94\x5d\xb1\xfd\x61\xe6\x81\xed\x14\xe3\xb9\x8f\xfb\xb1\xce\x75\x25\x54\xe1\xd9\xfa\x26\x4d\x2a\xf9\xf2\x25\xf8\xd0\xa5\xc5\x29\xbe\x3a\xbb\x15\x08\x93\xff\x1d\x20\x2c\x81\x44\x34\x11\xbc\xd5\x12\xb8\x30\xf0\x09\xe9\x9e\x1c\x57\x60\xd5\x16\xf1\xc2\xa1\x7d\xa4\x25\xd3\x93\x07\x61\x10\xd3\xb8\x63\x79\x1a\x77\xff\xa9\xfe\x0e\x00\x00\xff\xff\xc7\xd9\x39\xc1\x6b\x09\x00\x00")
+var _webQueueHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x56\x5f\x6f\xdc\x36\x0c\x7f\xcf\xa7\x60\xdd\x15\xd8\x80\xd9\x77\x29\x50\x60\x73\x7d\xc6\xb6\xa6\x5b\x0b\x74\x5d\xd6\x5e\x36\xf4\x51\x67\xf1\x6c\x35\xb2\xe4\xca\xbc\x7f\xf1\xfc\xcd\xf6\xb6\x2f\x36\x48\xfe\x7b\x71\x92\x66\xd9\xf6\x72\x27\x91\x14\xf9\xe3\x8f\xa4\xe4\xe8\xd1\xd9\x2f\x2f\x96\x1f\xce\x5f\xc2\xab\xe5\xcf\x6f\xe2\x93\x28\xa3\x5c\xc6\x27\x00\x51\x86\x8c\xdb\x05\x40\x94\x23\x31\xc8\x88\x0a\x1f\x3f\x6d\xc4\x76\xe1\xbd\xd0\x8a\x50\x91\xbf\x3c\x14\xe8\x41\xd2\xec\x16\x1e\xe1\x9e\x66\xd6\xc1\x73\x48\x32\x66\x4a\xa4\xc5\xc5\xf2\x47\xff\x1b\x0f\x66\xad\x27\x12\x24\x31\xfe\x9d\x19\xc2\x32\xc9\x24\x53\x29\x46\xb3\x46\xd8\x18\x94\x74\xe8\xd6\x00\xd6\xd5\xd7\xb0\xd2\xfc\x00\x55\x2b\x02\xd8\x09\x4e\x59\x08\xa7\xf3\xf9\x93\xe7\xbd\x50\x6f\xd1\xac\xa5\xde\xf9\xfb\x10\x32\xc1\x39\xaa\x41\x97\x33\x93\x0a\x15\xc2\xbc\x13\xd5\x27\xed\xe2\x9a\xe7\xb5\x56\xe4\x97\xe2\x0a\x43\x78\x3a\xf2\xde\x9b\x3f\xde\x19\x56\x14\x68\x3e\x07\x86\x8b\xb2\x90\xec\x10\xc2\x5a\xe2\x7e\x10\xdb\x9d\xcf\x85\xc1\x84\x84\x56\x21\x24\x5a\x6e\xf2\x11\xce\x8f\x9b\x92\xc4\xfa\xe0\xb7\x74\x36\xc7\xfd\x92\x98\xa1\xc1\x88\x49\x91\x2a\x5f\x10\xe6\x65\x08\x09\x2a\x42\x33\xc5\xa9\xd8\xf6\x3f\xc2\x68\xf4\xee\x0e\x80\xc7\xf1\xdb\xd3\x96\xa4\x10\xec\xef\xa0\x58\xb1\xe4\x32\x35\x7a\xa3\xb8\x9f\x68\xa9\x4d\x08\x6f\x44\x9a\xd1\x0f\x72\x83\x83\x51\x53\x27\x7f\xa5\x89\x74\x1e\xc2\x29\xe6\x37\x67\xc6\xa0\x9a\xa6\xb1\x92\x3a\xb9\xbc\x2b\xe0\xea\x28\x56\x2b\xdc\x65\x82\x46\xd2\x95\x36\x1c\x4d\x08\xa7\xc5\x1e\x4a\x2d\x05\x87\x95\x64\x47\x6e\x9d\x81\x6f\x18\x17\x9b\xd2\x52\x5a\x8c\xb8\x2b\x18\xe7\x42\xa5\x21\xcc\x83\xa7\x98\x5f\xcf\x6b\x22\xb6\xc3\xe2\x73\x4c\xb4\x61\x4d\x3f\x28\xad\x70\x9a\xf1\xa7\x0d\x6e\x10\xaa\x7f\x5a\xb8\x07\x35\x57\xdb\x29\xdf\xce\x9f\x4c\x70\x04\x24\x92\x4b\x24\xa8\xae\x1b\x1f\xb7\xd5\x67\x19\xbc\x56\xe4\x79\xf0\x0c\xf3\x69\x30\xc1\x47\x81\x1c\x51\xae\xef\x6f\xe8\xb8\x61\x64\x4f\x9f\x8d\x81\xb8\x59\xde\xa1\xed\xb2\x10\x56\x5a\xf2\x09\x04\xd2\x85\xad\xc9\xa8\xcd\x6e\x40\x77\x53\x13\x06\xf6\x36\xbc\x1f\xbc\x7f\x1d\xca\x60\x89\x66\x8b\x63\x3a\x1a\x8a\xbb\xb6\x4e\xb5\xe4\xa8\x8c\xe6\x77\xf5\xbe\x1b\xb6\x9f\x9c\xe5\x3b\xcd\x3f\xa0\x94\x7a\x37\x8d\xb5\x62\x3c\xc5\x7b\x26\x76\x07\xbd\x6d\xcc\x33\x66\x2e\xfb\x90\x93\xb4\x1f\xce\x88\x62\x39\x96\x50\x5d\x3f\xf5\x70\x87\x2c\x21\xb1\x1d\xe7\x7d\x8f\xcb\xaa\x3f\xfd\x5d\x8e\x5c\x30\x28\x13\x83\xa8\x80\x29\x0e\x5f\xe6\x6c\xef\x0f\xb3\x31\x2f\xf6\x5f\x8d\x7c\x4f\x2e\xb0\x5b\x5f\x9d\x5b\x47\xec\xee\xc2\xd4\xed\xaa\xf9\x8f\x66\xfd\x7b\x1a\xcd\xba\x07\x3d\xb2\xcf\x5e\xfb\xdc\x72\xb1\x05\xc1\x17\x5e\xfb\xb2\x79\xdd\xcb\xdb\x2b\x14\xdb\xf6\x42\x80\x88\x41\x66\x70\xbd\xf0\xec\x15\x24\x54\xea\xc5\x6f\xf5\x0e\xce\x9b\x4d\x34\x63\x71\x6f\x80\x5c\x90\x17\xbf\x65\x39\x2a\x40\xa1\xc8\xb0\x14\xd5\x91\x45\xa9\x55\x5a\x7a\xf1\x7b\xfb\x67\x15\x5d\xe4\x19\x17\xdb\x09\x0c\x77\x0b\xf6\x40\xaa\x6a\x27\x28\x83\xe0\x57\x2b\xad\xbb\x94\x01\xaa\x4a\xac\x21\x78\xb1\x31\x06\x15\x05\xaf\xcf\x8e\x54\xcd\x91\x56\x59\xd7\xce\x75\x22\x59\x59\x2e\xbc\xf6\x6e\x6b\x3a\xc1\x8b\xa3\xa2\x53\x08\xee\xc5\x8f\xab\xca\xb9\x8a\x66\x45\x5c\x55\x84\x79\x21\x19\x21\x78\x6e\x5c\x3c\x08\xea\xba\x09\x6b\x73\x2d\xeb\x3a\xd2\xb2\x3b\xed\x5a\xd5\x8b\xab\xca\xd8\xef\x9c\xc1\x42\x8a\xb8\xaa\x82\xba\x8e\x66\x6e\x85\x8a\xdb\xb5\x96\xc3\xda\x52\xd0\x6e\xba\x4e\xb3\xd9\x35\x82\x61\xff\x85\xbd\x89\xc2\x05\x04\x2f\x97\xdf\x1f\x29\xda\x80\x17\x45\xa2\x73\xa1\xd2\x91\xae\xe5\xb4\x45\xd8\x24\xee\xf0\x6b\x03\xc1\xb9\x11\xda\x08\x3a\x40\xf0\x5e\x6a\xaa\x6b\xe8\xae\x9f\x36\xf4\x43\xa8\x71\xb4\x0b\xc5\x71\x0f\x0e\x6e\xf0\xfa\x0c\xfe\x80\xb5\x36\x39\x23\x07\x7b\x70\x89\xc4\xbc\x38\x61\x01\x38\x76\xe0\x22\x33\x8d\x63\x17\xfb\xff\x21\x79\xc4\x19\xca\x72\xdc\x4b\x00\x51\x11\x9f\x09\x84\xa3\x8f\x55\x10\x25\x81\x44\x34\x01\xbc\xd2\x12\xb8\x30\xf0\x11\xe9\x8a\x6c\x8f\xc3\xd2\xb1\xf9\xc8\x82\xbe\xa5\x68\xc7\xbb\x11\x84\x7e\x19\xcd\x9a\xe9\x8c\xdc\x77\x74\x7c\x52\x55\x1c\xd7\x42\xf5\xa4\x76\x44\x74\xa5\x1a\xf3\xd7\xd0\x1e\xff\xa6\x8d\xad\x7f\xcb\x9d\x2c\x11\xc4\xba\xab\xe8\xc4\xf8\x9d\xab\xb0\x40\x43\xb0\xfe\xeb\x4f\x63\x67\x99\xae\x6c\x01\x5a\xfb\x71\x01\x50\xf1\xba\x3e\xf9\x7b\x00\xe6\xec\x13\x87\x32\x0c\x00\x00")
 
 func webQueueHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -612,7 +612,7 @@ func webQueueHtml() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "web/queue.html", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf4, 0xc4, 0x7, 0xee, 0x84, 0x23, 0xc4, 0xf4, 0x6a, 0xb1, 0x4c, 0x13, 0xf9, 0xa3, 0x42, 0x9a, 0x2d, 0xaf, 0xab, 0x7b, 0xac, 0x14, 0x99, 0xe0, 0x90, 0x8b, 0x27, 0xaf, 0xf5, 0x18, 0x2a, 0x57}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x58, 0x4, 0x23, 0x4c, 0x44, 0x19, 0x31, 0x90, 0x9e, 0x1a, 0x7c, 0xc1, 0x8, 0xb2, 0xd0, 0xd9, 0x25, 0x9, 0x17, 0x94, 0x9e, 0xe6, 0xa, 0x75, 0xa8, 0x7d, 0x8d, 0xf1, 0xc4, 0x91, 0x73, 0x80}}
 	return a, nil
 }
 
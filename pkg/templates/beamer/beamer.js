@@ -14,3 +14,17 @@ function update(url, cb) {
   };
   xhr.send();
 }
+
+function subscribe(url, fallbackURL, cb) {
+  if (!window.EventSource) {
+    update(fallbackURL, cb);
+    return;
+  }
+  var source = new EventSource(url);
+  source.addEventListener('state', function(e) {
+    cb(JSON.parse(e.data), null);
+  });
+  source.onerror = function() {
+    console.log("lost connection, reconnecting");
+  };
+}